@@ -6,12 +6,16 @@ import (
 	"github.com/ForgeLogic/nojs/runtime"
 )
 
-// ConditionalsPage demonstrates {@if}/{@else} conditional rendering.
+// StepCount is the number of steps in the ConditionalsPage wizard demo.
+const StepCount = 3
+
+// ConditionalsPage demonstrates {@if}/{@else} and {@switch} conditional rendering.
 type ConditionalsPage struct {
 	runtime.ComponentBase
 
 	IsLoggedIn  bool
 	ShowAlert   bool
+	Step        int
 	RenderCount int
 }
 
@@ -28,3 +32,8 @@ func (c *ConditionalsPage) ToggleAlert() {
 	c.ShowAlert = !c.ShowAlert
 	c.StateHasChanged()
 }
+
+func (c *ConditionalsPage) NextStep() {
+	c.Step = (c.Step + 1) % StepCount
+	c.StateHasChanged()
+}
@@ -3,18 +3,33 @@
 package pages
 
 import (
+	"slices"
+	"strings"
+
 	"github.com/ForgeLogic/nojs/events"
 	"github.com/ForgeLogic/nojs/runtime"
 )
 
-// FormsPage demonstrates event binding and live data binding with @oninput / @onchange.
+var topics = []string{"Frontend", "Backend", "Tooling", "Games"}
+
+// Attachment is a file chosen in the attachments input.
+type Attachment struct {
+	Name string
+	Type string
+	Size int
+}
+
+// FormsPage demonstrates two-way binding with @bind and event binding with @onclick.
 type FormsPage struct {
 	runtime.ComponentBase
 
 	Name     string
 	Language string
+	Years    int
 	IsSenior bool
-	HasName  bool
+
+	Interests   []string
+	Attachments []Attachment
 
 	RenderCount int
 }
@@ -27,18 +42,36 @@ func (c *FormsPage) OnParametersSet() {
 	c.RenderCount++
 }
 
-func (c *FormsPage) HandleNameInput(e events.ChangeEventArgs) {
-	c.Name = e.Value
-	c.HasName = c.Name != ""
+func (c *FormsPage) ToggleSeniority() {
+	c.IsSenior = !c.IsSenior
 	c.StateHasChanged()
 }
 
-func (c *FormsPage) HandleLanguageChange(e events.ChangeEventArgs) {
-	c.Language = e.Value
+// ToggleInterest adds or removes the checkbox's topic from Interests.
+func (c *FormsPage) ToggleInterest(e events.ChangeEventArgs) {
+	c.Interests = slices.DeleteFunc(c.Interests, func(topic string) bool { return topic == e.Value })
+	if e.Checked {
+		c.Interests = append(c.Interests, e.Value)
+	}
 	c.StateHasChanged()
 }
 
-func (c *FormsPage) ToggleSeniority() {
-	c.IsSenior = !c.IsSenior
+// InterestList returns the chosen topics as a comma-separated list.
+func (c *FormsPage) InterestList() string {
+	return strings.Join(c.Interests, ", ")
+}
+
+// AttachFiles lists the chosen files, reading each one to count its bytes.
+func (c *FormsPage) AttachFiles(e events.ChangeEventArgs) {
+	c.Attachments = nil
+	for _, file := range e.Files {
+		file.ReadBytes(func(data []byte, err error) {
+			if err != nil {
+				return
+			}
+			c.Attachments = append(c.Attachments, Attachment{Name: file.Name, Type: file.Type, Size: len(data)})
+			c.StateHasChanged()
+		})
+	}
 	c.StateHasChanged()
 }
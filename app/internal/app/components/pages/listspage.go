@@ -3,6 +3,9 @@
 package pages
 
 import (
+	"slices"
+
+	"github.com/ForgeLogic/nojs/events"
 	"github.com/ForgeLogic/nojs/runtime"
 )
 
@@ -17,14 +20,12 @@ type ListsPage struct {
 
 	Items       []string
 	NextIndex   int
-	HasItems    bool
 	RenderCount int
 }
 
 func (c *ListsPage) OnMount() {
 	c.Items = []string{"Go", "Rust", "WebAssembly"}
 	c.NextIndex = 3
-	c.HasItems = true
 }
 
 func (c *ListsPage) OnParametersSet() {
@@ -35,7 +36,6 @@ func (c *ListsPage) AddItem() {
 	if c.NextIndex < len(techPool) {
 		c.Items = append(c.Items, techPool[c.NextIndex])
 		c.NextIndex++
-		c.HasItems = true
 		c.StateHasChanged()
 	}
 }
@@ -43,14 +43,56 @@ func (c *ListsPage) AddItem() {
 func (c *ListsPage) RemoveLast() {
 	if len(c.Items) > 0 {
 		c.Items = c.Items[:len(c.Items)-1]
-		c.HasItems = len(c.Items) > 0
 		c.StateHasChanged()
 	}
 }
 
+func (c *ListsPage) Remove(item string) {
+	if i := slices.Index(c.Items, item); i >= 0 {
+		c.Items = slices.Delete(c.Items, i, i+1)
+		c.StateHasChanged()
+	}
+}
+
+// MoveUp moves the item at index i up one row, or to the top with Shift held.
+func (c *ListsPage) MoveUp(i int, e events.ClickEventArgs) {
+	if i <= 0 || i >= len(c.Items) {
+		return
+	}
+	to := i - 1
+	if e.ShiftKey {
+		to = 0
+	}
+	item := c.Items[i]
+	c.Items = slices.Insert(slices.Delete(c.Items, i, i+1), to, item)
+	c.StateHasChanged()
+}
+
+// DragStart puts the dragged item in the drag data.
+func (c *ListsPage) DragStart(item string, e events.DragEventArgs) {
+	e.DataTransfer.SetData("text/plain", item)
+	e.DataTransfer.SetEffectAllowed("move")
+}
+
+// DragOver lets rows accept drops, which requires preventing the default.
+func (c *ListsPage) DragOver(e events.DragEventArgs) {
+	e.PreventDefault()
+	e.DataTransfer.SetDropEffect("move")
+}
+
+// Drop moves the dragged item to index i.
+func (c *ListsPage) Drop(i int, e events.DragEventArgs) {
+	from := slices.Index(c.Items, e.DataTransfer.GetData("text/plain"))
+	if from < 0 || from == i {
+		return
+	}
+	item := c.Items[from]
+	c.Items = slices.Insert(slices.Delete(c.Items, from, from+1), i, item)
+	c.StateHasChanged()
+}
+
 func (c *ListsPage) Reset() {
 	c.Items = []string{"Go", "Rust", "WebAssembly"}
 	c.NextIndex = 3
-	c.HasItems = true
 	c.StateHasChanged()
 }
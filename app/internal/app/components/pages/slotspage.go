@@ -13,7 +13,6 @@ type SlotsPage struct {
 
 	IsModalVisible bool
 	LastResult     string
-	HasResult      bool
 	RenderCount    int
 }
 
@@ -24,17 +23,14 @@ func (c *SlotsPage) OnParametersSet() {
 func (c *SlotsPage) OpenModal() {
 	c.IsModalVisible = true
 	c.LastResult = ""
-	c.HasResult = false
 	c.StateHasChanged()
 }
 
 func (c *SlotsPage) HandleModalClose(result modal.ModalResult) {
 	c.IsModalVisible = false
-	c.HasResult = true
 	if result == modal.Ok {
 		c.LastResult = "✅ You clicked OK"
 	} else {
 		c.LastResult = "❌ You clicked Cancel"
 	}
-	c.StateHasChanged()
 }
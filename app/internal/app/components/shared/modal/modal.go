@@ -23,9 +23,10 @@ type Modal struct {
 	// ShowCancelButton determines if the 'Cancel' button is rendered.
 	ShowCancelButton bool
 
-	// OnClose is the function callback prop. The parent provides
-	// a method that matches this signature.
-	OnClose func(result ModalResult)
+	// OnClose is raised when the dialog is closed. The parent binds
+	// a func(ModalResult) method with @onclose="HandleClose" and is
+	// re-rendered after it runs.
+	OnClose runtime.EventCallback[ModalResult] `nojs:"event"`
 
 	// BodyContent is our "slot". The framework will
 	// inject any child VNodes from the parent here.
@@ -53,14 +54,10 @@ func (c *Modal) OnParametersSet() {
 
 // HandleOk is bound to the 'Ok' button's @onclick event.
 func (c *Modal) HandleOk() {
-	if c.OnClose != nil {
-		c.OnClose(Ok)
-	}
+	c.OnClose.Invoke(Ok)
 }
 
 // HandleCancel is bound to 'Cancel' and 'X' buttons.
 func (c *Modal) HandleCancel() {
-	if c.OnClose != nil {
-		c.OnClose(Cancel)
-	}
+	c.OnClose.Invoke(Cancel)
 }
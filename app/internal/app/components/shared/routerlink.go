@@ -5,7 +5,6 @@ package shared
 import (
 	"fmt"
 
-	"github.com/ForgeLogic/nojs/events"
 	"github.com/ForgeLogic/nojs/runtime"
 	"github.com/ForgeLogic/nojs/vdom"
 )
@@ -35,11 +34,9 @@ type RouterLink struct {
 }
 
 // HandleClick is called when the link is clicked.
-// It prevents the default browser navigation and uses the router instead.
-func (c *RouterLink) HandleClick(e events.ClickEventArgs) {
-	// Prevent the browser from navigating (which would reload the page)
-	e.PreventDefault()
-
+// The template's .prevent modifier stops the browser from following the link
+// (which would reload the page); the router navigates instead.
+func (c *RouterLink) HandleClick() {
 	println("[RouterLink.HandleClick] Href value: ", c.Href)
 	println("[RouterLink.HandleClick] c pointer:", fmt.Sprintf("%p", c))
 
@@ -3,23 +3,15 @@
 package main
 
 import (
-	sharedlayouts "github.com/ForgeLogic/app/internal/app/components/shared/layouts"
-	"github.com/ForgeLogic/app/internal/app/context"
 	router "github.com/ForgeLogic/nojs-router"
 	"github.com/ForgeLogic/nojs/console"
 	"github.com/ForgeLogic/nojs/runtime"
+	"github.com/ForgeLogic/nojs/vdom"
 )
 
 func main() {
-	// Create shared layout context
-	mainLayoutCtx := &context.MainLayoutCtx{
-		Title: "My App",
-	}
-
-	// Create persistent main layout instance (app shell)
-	mainLayout := &sharedlayouts.MainLayout{
-		MainLayoutCtx: mainLayoutCtx,
-	}
+	// Create persistent main layout instance (app shell) and its shared context
+	mainLayout, mainLayoutCtx := newMainLayout()
 
 	// Create the router engine first (it will be passed as navigation manager to renderer)
 	routerEngine := router.NewEngine(nil)
@@ -33,10 +25,20 @@ func main() {
 	// Register routes with their components and layouts
 	registerRoutes(routerEngine, mainLayout, mainLayoutCtx)
 
-	// Create AppShell to wrap the router's page rendering
+	// Reuse pre-rendered markup when the page was produced by `nojsc ssg`
+	prerendered := isPrerendered("#app")
+	if prerendered {
+		renderer.EnableHydration()
+	}
+
+	// Create AppShell to wrap the router's page rendering. A pre-rendered page is first
+	// rendered when the router resolves the initial URL, so hydration sees the full page.
 	appShell := router.NewAppShell(mainLayout)
+	appShell.SetRenderer(renderer)
 	renderer.SetCurrentComponent(appShell, "app-shell")
-	renderer.ReRender()
+	if !prerendered {
+		renderer.ReRender()
+	}
 
 	// Initialize the router with a callback to update AppShell when navigation occurs
 	err := routerEngine.Start(func(chain []runtime.Component, key string) {
@@ -50,3 +52,11 @@ func main() {
 	// Keep the Go program running
 	select {}
 }
+
+// isPrerendered reports whether the mount element already holds markup, which only
+// pages written by `nojsc ssg` do.
+func isPrerendered(mountID string) bool {
+	dom := vdom.CurrentDOM()
+	mount := dom.QuerySelector(mountID)
+	return mount != nil && dom.FirstChild(mount) != nil
+}
@@ -10,12 +10,34 @@ import (
 	"github.com/ForgeLogic/nojs/runtime"
 )
 
+// newMainLayout creates the persistent main layout instance (app shell) and its shared context.
+func newMainLayout() (*sharedlayouts.MainLayout, *context.MainLayoutCtx) {
+	mainLayoutCtx := &context.MainLayoutCtx{
+		Title: "My App",
+	}
+	mainLayout := &sharedlayouts.MainLayout{
+		MainLayoutCtx: mainLayoutCtx,
+	}
+	return mainLayout, mainLayoutCtx
+}
+
 func registerRoutes(routerEngine *router.Engine, mainLayout *sharedlayouts.MainLayout, mainLayoutCtx *context.MainLayoutCtx) {
 	_ = mainLayoutCtx // reserved for future use
 
+	routerEngine.RegisterRoutes(appRoutes(mainLayout))
+}
+
+// StaticRoutes returns the route table for `nojsc ssg`, which pre-renders every page to HTML.
+func StaticRoutes() []router.Route {
+	mainLayout, _ := newMainLayout()
+	return appRoutes(mainLayout)
+}
+
+// appRoutes is the single route table shared by the browser router and static generation.
+func appRoutes(mainLayout *sharedlayouts.MainLayout) []router.Route {
 	ml := func(p map[string]string) runtime.Component { return mainLayout }
 
-	routerEngine.RegisterRoutes([]router.Route{
+	return []router.Route{
 		{
 			Path: "/",
 			Chain: []router.ComponentMetadata{
@@ -72,5 +94,5 @@ func registerRoutes(routerEngine *router.Engine, mainLayout *sharedlayouts.MainL
 				{Factory: func(p map[string]string) runtime.Component { return &pages.RouterParamsPage{ID: p["id"]} }, TypeID: RouterParamsPage_TypeID},
 			},
 		},
-	})
+	}
 }
@@ -4,20 +4,29 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"os"
 
 	compiler "github.com/ForgeLogic/nojs-compiler"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "ssg" {
+		if err := runSSG(os.Args[2:]); err != nil {
+			log.Fatalf("Static site generation failed: %v", err)
+		}
+		return
+	}
+
 	inDir := flag.String("in", ".", "The source directory to scan for *.gt.html files.")
 	devMode := flag.Bool("dev", false, "Enable development mode (warnings, verbose errors, panic on lifecycle failures)")
+	cssBundle := flag.String("css", "", "The file to write the scoped CSS of all *.gt.css files to (default: components.generated.css in the source directory, written only if a component has a stylesheet).")
 	flag.Parse()
 
 	fmt.Printf("Starting compilation...\nSource directory: %s\n", *inDir)
 	if *devMode {
 		fmt.Printf("Development mode: ENABLED\n")
 	}
-	err := compiler.Compile(*inDir, *devMode)
+	err := compiler.CompileWithCSSBundle(*inDir, *devMode, *cssBundle)
 	if err != nil {
 		log.Fatalf("Compilation failed: %v", err)
 	}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// ssgHookFile is the name the init hook gets in the app's main package. The file only
+// exists in a temporary directory and is added to the package with go run -overlay, so
+// an interrupted run never leaves it in the user's source tree.
+const ssgHookFile = "zz_nojs_ssg.generated.go"
+
+// ssgHookTemplate is an init hook compiled only with the nojs_ssg build tag. It runs
+// before the app's main(), renders every route and exits, so the app keeps a single
+// route table for the browser and for static generation.
+var ssgHookTemplate = template.Must(template.New("ssg").Parse(`// Code generated by nojsc ssg. DO NOT EDIT.
+
+//go:build (js || wasm) && nojs_ssg
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	router "github.com/ForgeLogic/nojs-router"
+)
+
+func init() {
+	shell, err := os.ReadFile({{printf "%q" .ShellPath}})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "nojsc ssg:", err)
+		os.Exit(1)
+	}
+
+	files, err := router.GenerateStaticSite({{.RoutesFunc}}(), router.StaticSiteOptions{
+		OutDir:       {{printf "%q" .OutDir}},
+		Shell:        string(shell),
+		MountID:      {{printf "%q" .MountID}},
+		BasePath:     {{printf "%q" .BasePath}},
+		FallbackRoot: {{printf "%q" .FallbackRoot}},
+		Params:       {{.Params}},
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "nojsc ssg:", err)
+		os.Exit(1)
+	}
+	for _, f := range files {
+		fmt.Println("nojsc ssg: wrote", f)
+	}
+	os.Exit(0)
+}
+`))
+
+// ssgHookData feeds ssgHookTemplate.
+type ssgHookData struct {
+	ShellPath    string
+	OutDir       string
+	MountID      string
+	BasePath     string
+	FallbackRoot string
+	RoutesFunc   string
+	Params       string
+}
+
+// runSSG implements `nojsc ssg`: static site generation for a routed nojs app.
+//
+// The app's route table only builds for js/wasm, so the app is run once under Node
+// (via Go's go_js_wasm_exec) with a generated init hook that renders every route with
+// router.GenerateStaticSite and exits before main() starts the browser runtime.
+func runSSG(args []string) error {
+	fs := flag.NewFlagSet("ssg", flag.ExitOnError)
+	appDir := fs.String("app", ".", "Directory of the app's main package.")
+	outDir := fs.String("out", "./dist", "Output directory for the generated HTML files.")
+	shellPath := fs.String("shell", "", "Page template containing the empty mount element (e.g. ./app/wwwroot/index.html).")
+	mountID := fs.String("mount", "app", "Id of the mount element in the page template.")
+	basePath := fs.String("base", "", "URL prefix the site is served under (same value as Engine.SetBasePath).")
+	fallbackRoot := fs.String("fallback-root", "", "Site root whose 404.html the host serves, when above -out (e.g. for GitHub Pages); the app's fallback for URLs under -base is merged into it.")
+	paramsPath := fs.String("params", "", `JSON file with parameter values per route, e.g. {"/blog/{year}": [{"year": "2026"}]}.`)
+	routesFunc := fs.String("routes", "StaticRoutes", "Function in the main package returning the []router.Route table.")
+	tags := fs.String("tags", "", "Additional comma-separated build tags (e.g. dev).")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: nojsc ssg -app=<dir> -shell=<index.html> [-out=<dir>] [-base=<path>] [-fallback-root=<dir>] [-params=<file.json>]\n\n")
+		fmt.Fprintf(fs.Output(), "Pre-renders every route of the app to <out>/<route>/index.html plus 404.html.\n")
+		fmt.Fprintf(fs.Output(), "The app's main package must define: func StaticRoutes() []router.Route (see -routes)\n\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *shellPath == "" {
+		fs.Usage()
+		return fmt.Errorf("-shell is required")
+	}
+
+	params := "nil"
+	if *paramsPath != "" {
+		data, err := os.ReadFile(*paramsPath)
+		if err != nil {
+			return err
+		}
+		var values map[string][]map[string]string
+		if err := json.Unmarshal(data, &values); err != nil {
+			return fmt.Errorf("invalid params file %s: %w", *paramsPath, err)
+		}
+		params = fmt.Sprintf("%#v", values)
+	}
+
+	absShell, err := filepath.Abs(*shellPath)
+	if err != nil {
+		return err
+	}
+	absOut, err := filepath.Abs(*outDir)
+	if err != nil {
+		return err
+	}
+
+	absFallback := ""
+	if *fallbackRoot != "" {
+		if absFallback, err = filepath.Abs(*fallbackRoot); err != nil {
+			return err
+		}
+	}
+
+	execPath, err := wasmExecPath()
+	if err != nil {
+		return err
+	}
+
+	absApp, err := filepath.Abs(*appDir)
+	if err != nil {
+		return err
+	}
+	tmpDir, err := os.MkdirTemp("", "nojsc-ssg-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	hookPath := filepath.Join(tmpDir, ssgHookFile)
+	hook, err := os.Create(hookPath)
+	if err != nil {
+		return err
+	}
+
+	err = ssgHookTemplate.Execute(hook, ssgHookData{
+		ShellPath:    absShell,
+		OutDir:       absOut,
+		MountID:      *mountID,
+		BasePath:     *basePath,
+		FallbackRoot: absFallback,
+		RoutesFunc:   *routesFunc,
+		Params:       params,
+	})
+	if closeErr := hook.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	overlay, err := json.Marshal(map[string]map[string]string{
+		"Replace": {filepath.Join(absApp, ssgHookFile): hookPath},
+	})
+	if err != nil {
+		return err
+	}
+	overlayPath := filepath.Join(tmpDir, "overlay.json")
+	if err := os.WriteFile(overlayPath, overlay, 0o644); err != nil {
+		return err
+	}
+
+	buildTags := "nojs_ssg"
+	if *tags != "" {
+		buildTags += "," + *tags
+	}
+
+	cmd := exec.Command("go", "run", "-tags="+buildTags, "-overlay="+overlayPath, "-exec="+execPath, ".")
+	cmd.Dir = *appDir
+	cmd.Env = append(os.Environ(), "GOOS=js", "GOARCH=wasm")
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("running the app under Node failed: %w", err)
+	}
+	return nil
+}
+
+// wasmExecPath locates go_js_wasm_exec in the Go installation and checks that Node is available.
+func wasmExecPath() (string, error) {
+	if _, err := exec.LookPath("node"); err != nil {
+		return "", fmt.Errorf("nojsc ssg runs the app under Node.js, but node was not found in PATH")
+	}
+
+	out, err := exec.Command("go", "env", "GOROOT").Output()
+	if err != nil {
+		return "", fmt.Errorf("cannot determine GOROOT: %w", err)
+	}
+	goroot := strings.TrimSpace(string(out))
+	for _, dir := range []string{"lib/wasm", "misc/wasm"} {
+		path := filepath.Join(goroot, dir, "go_js_wasm_exec")
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("go_js_wasm_exec not found in %s", goroot)
+}
@@ -4,10 +4,10 @@ import (
 	"fmt"
 	"go/format"
 	"os"
+	"path"
 	"path/filepath"
+	"sort"
 	"strings"
-
-	"golang.org/x/net/html"
 )
 
 // compileComponentTemplate reads a .gt.html template, parses it, generates Go code,
@@ -25,28 +25,42 @@ func compileComponentTemplate(comp componentInfo, componentMap map[string]compon
 		return err // Error message already includes template path and details
 	}
 
+	// Preprocess switch blocks with validation
+	htmlString, err = preprocessSwitch(htmlString, comp.Path)
+	if err != nil {
+		return err // Error message already includes template path and details
+	}
+
 	// Preprocess for-loop blocks with validation
 	htmlString, err = preprocessFor(htmlString, comp.Path)
 	if err != nil {
 		return err // Error message already includes template path and details
 	}
 
-	doc, err := html.Parse(strings.NewReader(htmlString))
+	// Preprocess named slot blocks with validation
+	htmlString, err = preprocessSlots(htmlString, comp.Path)
 	if err != nil {
-		return fmt.Errorf("failed to parse HTML: %w", err)
+		return err // Error message already includes template path and details
 	}
-	bodyNode := findBody(doc)
-	if bodyNode == nil {
-		return fmt.Errorf("could not find <body> tag")
+
+	// Preprocess scoped slot blocks (<row let:user>) with validation
+	htmlString, err = preprocessScopedSlots(htmlString, comp.Path)
+	if err != nil {
+		return err // Error message already includes template path and details
 	}
 
-	rootElement := findFirstElementChild(bodyNode)
-	if rootElement == nil {
-		return fmt.Errorf("no element found inside <body> tag to compile")
+	// Placeholders are parsed as <template> elements so tables and selects keep them
+	htmlString = placeholderTemplates(htmlString)
+	doc, err := parseTemplate(htmlString)
+	if err != nil {
+		return fmt.Errorf("failed to parse HTML: %w", err)
 	}
+	restorePlaceholders(doc)
 
-	// Collect components used from other packages
-	usedPackages := collectUsedComponents(rootElement, componentMap, comp)
+	roots := findRootNodes(doc)
+	if len(roots) == 0 {
+		return fmt.Errorf("no element found in template %s to compile", comp.Path)
+	}
 
 	// Initialize template-wide component counter so every RenderChild key is unique
 	// regardless of where in the tree the component appears. Using sibling-position
@@ -55,18 +69,44 @@ func compileComponentTemplate(comp componentInfo, componentMap map[string]compon
 	// of their respective parent divs all get "RouterLink_3").
 	opts.ComponentCounter = make(map[string]int)
 
-	// Generate code for a single root node
-	generatedCode := generateNodeCode(rootElement, "c", componentMap, comp, htmlString, opts, nil)
+	// Template expressions are type-checked against the component's Go package
+	checker, err := newExprChecker(comp, "c", string(htmlContent))
+	if err != nil {
+		return fmt.Errorf("cannot type-check template expressions: %w", err)
+	}
+	comp.Expr = checker
+
+	// A single root node renders as it is. Several root nodes, or a directive at the root
+	// ({@if IsVisible}...), which renders a list of nodes, render as a fragment.
+	generatedCode := generateRootCode(doc, "c", componentMap, comp, htmlString, opts, nil)
 
 	// Generate the ApplyProps method body
 	applyPropsBody := generateApplyPropsBody(comp)
 
-	// Build additional imports for cross-package components
+	// Add packages referenced by template expressions (e.g. {strings.ToUpper(Name)}), by
+	// generated code and by child components from other packages
 	var additionalImports strings.Builder
-	if len(usedPackages) > 0 {
-		additionalImports.WriteString("\n")
-		for _, importPath := range usedPackages {
+	exprImports := checker.usedImports()
+	exprImportNames := make([]string, 0, len(exprImports))
+	for name := range exprImports {
+		exprImportNames = append(exprImportNames, name)
+	}
+	sort.Strings(exprImportNames)
+	for _, name := range exprImportNames {
+		importPath := exprImports[name]
+		if existing, ok := generatedFileImports[name]; ok {
+			if existing != importPath {
+				return fmt.Errorf("template expressions use package '%s' (%s), which conflicts with the generated import of %s", name, importPath, existing)
+			}
+			continue
+		}
+		if additionalImports.Len() == 0 {
+			additionalImports.WriteString("\n")
+		}
+		if name == path.Base(importPath) {
 			fmt.Fprintf(&additionalImports, "\t\"%s\"\n", importPath)
+		} else {
+			fmt.Fprintf(&additionalImports, "\t%s \"%s\"\n", name, importPath)
 		}
 	}
 
@@ -88,8 +128,8 @@ import (
 
 // ApplyProps copies props from source to the receiver, preserving internal state.
 // This method is generated automatically by the compiler.
-func (c *%[1]s) ApplyProps(source runtime.Component) {
-	src, ok := source.(*%[1]s)
+func (c *%[6]s) ApplyProps(source runtime.Component) {
+	src, ok := source.(*%[6]s)
 	if !ok {
 		// Type mismatch - this should never happen in normal operation
 		return
@@ -99,7 +139,7 @@ func (c *%[1]s) ApplyProps(source runtime.Component) {
 }
 
 // Render generates the VNode tree for the %[1]s component.
-func (c *%[1]s) Render(r runtime.Renderer) *vdom.VNode {
+func (c *%[6]s) Render(r runtime.Renderer) *vdom.VNode {
 	_ = strconv.Itoa // Suppress unused import error if no props are converted
 	_ = fmt.Sprintf  // Suppress unused import error if no bindings are used
 	_ = console.Log  // Suppress unused import error if no loops use dev warnings
@@ -109,7 +149,13 @@ func (c *%[1]s) Render(r runtime.Renderer) *vdom.VNode {
 }
 `
 
-	source := fmt.Sprintf(template, comp.PascalName, comp.PackageName, generatedCode, applyPropsBody, additionalImports.String())
+	// The methods of a generic component name its type parameters: (c *Picker[T])
+	receiverType := comp.PascalName
+	if len(comp.Schema.TypeParams) > 0 {
+		receiverType += "[" + strings.Join(comp.Schema.TypeParams, ", ") + "]"
+	}
+
+	source := fmt.Sprintf(template, comp.PascalName, comp.PackageName, generatedCode, applyPropsBody, additionalImports.String(), receiverType)
 
 	// Format the generated source code
 	formattedSource, err := format.Source([]byte(source))
@@ -125,18 +171,38 @@ func (c *%[1]s) Render(r runtime.Renderer) *vdom.VNode {
 	return os.WriteFile(outFilePath, formattedSource, 0644)
 }
 
+// generatedFileImports are the packages every generated file imports, by name.
+var generatedFileImports = map[string]string{
+	"fmt":     "fmt",
+	"strconv": "strconv",
+	"console": "github.com/ForgeLogic/nojs/console",
+	"events":  "github.com/ForgeLogic/nojs/events",
+	"runtime": "github.com/ForgeLogic/nojs/runtime",
+	"vdom":    "github.com/ForgeLogic/nojs/vdom",
+}
+
 // generateApplyPropsBody generates the body of the ApplyProps method.
 // It creates assignment statements to copy all props from source to receiver.
 func generateApplyPropsBody(comp componentInfo) string {
-	if len(comp.Schema.Props) == 0 && comp.Schema.Slot == nil {
+	if len(comp.Schema.Props) == 0 && len(comp.Schema.Events) == 0 && len(comp.Schema.Slots) == 0 && len(comp.Schema.ScopedSlots) == 0 {
 		return "\t// No props to copy"
 	}
 
 	var assignments []string
 
-	// Copy regular props (sorted by name for consistent output)
-	propNames := make([]string, 0, len(comp.Schema.Props))
-	for propName := range comp.Schema.Props {
+	// Copy regular props, event handlers and scoped slots (sorted by name for consistent output)
+	fields := make(map[string]propertyDescriptor, len(comp.Schema.Props)+len(comp.Schema.Events)+len(comp.Schema.ScopedSlots))
+	for propName, prop := range comp.Schema.Props {
+		fields[propName] = prop
+	}
+	for eventName, event := range comp.Schema.Events {
+		fields[eventName] = event
+	}
+	for slotName, slot := range comp.Schema.ScopedSlots {
+		fields[slotName] = slot
+	}
+	propNames := make([]string, 0, len(fields))
+	for propName := range fields {
 		propNames = append(propNames, propName)
 	}
 	// Sort for deterministic output
@@ -149,15 +215,15 @@ func generateApplyPropsBody(comp componentInfo) string {
 	}
 
 	for _, propName := range propNames {
-		prop := comp.Schema.Props[propName]
+		prop := fields[propName]
 		assignments = append(assignments,
 			fmt.Sprintf("\tc.%s = src.%s", prop.Name, prop.Name))
 	}
 
-	// Copy slot content if exists
-	if comp.Schema.Slot != nil {
+	// Copy slot content
+	for _, slot := range comp.Schema.Slots {
 		assignments = append(assignments,
-			fmt.Sprintf("\tc.%s = src.%s", comp.Schema.Slot.Name, comp.Schema.Slot.Name))
+			fmt.Sprintf("\tc.%s = src.%s", slot.Name, slot.Name))
 	}
 
 	return strings.Join(assignments, "\n")
@@ -2,8 +2,13 @@ package compiler
 
 import (
 	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
 	"os"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -12,79 +17,83 @@ import (
 )
 
 // generateTernaryExpression generates Go code for a ternary conditional expression.
-// Supports negation operator: if negated is true, inverts the condition.
-func generateTernaryExpression(negated bool, condition, trueVal, falseVal, receiver string, propDesc propertyDescriptor) string {
-	if negated {
-		// Swap true and false values for negation
-		trueVal, falseVal = falseVal, trueVal
-	}
+// condCode is the type-checked Go code of the bool condition (negation is part of it).
+func generateTernaryExpression(condCode, trueVal, falseVal string) string {
 	return fmt.Sprintf(`func() string {
-		if %s.%s {
+		if %s {
 			return %s
 		}
 		return %s
-	}()`, receiver, propDesc.Name, strconv.Quote(trueVal), strconv.Quote(falseVal))
+	}()`, condCode, strconv.Quote(trueVal), strconv.Quote(falseVal))
 }
 
 // generateAttributesMap is a helper to create the Go map literal for an element's attributes.
-func generateAttributesMap(n *html.Node, receiver string, currentComp componentInfo, htmlSource string) string {
+// loopCtx can be nil if not inside a loop. bind is the element's compiled @bind directive, or nil.
+func generateAttributesMap(n *html.Node, receiver string, currentComp componentInfo, htmlSource string, loopCtx *loopContext, bind *bindCode) string {
 	var attrs, eventHandlers []string
+	if bind != nil {
+		attrs = append(attrs, bind.Attrs...)
+		eventHandlers = append(eventHandlers, bind.Handler)
+	}
 	for _, a := range n.Attr {
+		if a.Key == "@bind" || a.Key == "@bind:event" {
+			continue // Compiled by compileBind
+		}
 		if after, ok := strings.CutPrefix(a.Key, "@"); ok {
-			eventName := after
+			// @onclick.prevent.stop: the event name is followed by optional dotted modifiers
+			eventName, modifierList, _ := strings.Cut(after, ".")
 			handlerName := a.Val
-			lineNumber := findEventLineNumber(n, eventName, htmlSource)
-
-			// Validate event handler signature (compile-time type safety!)
-			method := validateEventHandler(eventName, handlerName, n.Data, currentComp, currentComp.Path, lineNumber, htmlSource)
+			lineNumber := findEventLineNumber(n, after, htmlSource)
 
-			// Get the event signature to determine if we need an adapter
-			// Note: using full import path since 'events' is also a local variable name
-			eventSig := events.GetEventSignature(eventName)
-
-			// Generate the event handler code
-			handlerRef := fmt.Sprintf(`%s.%s`, receiver, handlerName)
+			var handlerCode string
+			if strings.Contains(handlerName, "(") {
+				// @onclick="Remove(item.ID)": a method call with arguments
+				handlerCode = compileEventCall(after, eventName, handlerName, schemaTagName(n), currentComp, htmlSource, lineNumber, loopCtx)
+			} else {
+				// Validate event handler signature (compile-time type safety!)
+				method := validateEventHandler(eventName, handlerName, schemaTagName(n), modifierList != "", currentComp, currentComp.Path, lineNumber, htmlSource)
+
+				// Get the event signature to determine if we need an adapter
+				// Note: using full import path since 'events' is also a local variable name
+				eventSig := events.GetEventSignature(eventName)
+
+				// Generate the event handler code
+				handlerRef := fmt.Sprintf(`%s.%s`, receiver, handlerName)
+
+				// Determine which adapter to use based on event type and method signature
+				if eventName == "onclick" {
+					// onclick supports both func() and func(ClickEventArgs)
+					if len(method.Params) == 0 {
+						// func() - use no-arg adapter
+						handlerCode = fmt.Sprintf(`events.AdaptNoArgEvent(%s)`, handlerRef)
+					} else if len(method.Params) == 1 && method.Params[0].Type == "events.ClickEventArgs" {
+						// func(ClickEventArgs) - use click adapter
+						handlerCode = fmt.Sprintf(`events.AdaptClickEvent(%s)`, handlerRef)
+					}
+				} else if eventSig.RequiresArgs && len(method.Params) == 1 {
+					// Event requires arguments - use the appropriate adapter
+					handlerCode = fmt.Sprintf(`%s(%s)`, eventAdapter(eventSig.ArgsType), handlerRef)
+				} else {
+					// Event requires no arguments - use the no-arg adapter
+					handlerCode = fmt.Sprintf(`events.AdaptNoArgEvent(%s)`, handlerRef)
+				}
+			}
 
 			// Convert @eventname to camelCase for JavaScript (e.g., "onclick" -> "onClick")
 			jsEventName := "on" + strings.ToUpper(eventName[2:3]) + eventName[3:]
 
-			// Determine which adapter to use based on event type and method signature
-			if eventName == "onclick" {
-				// onclick supports both func() and func(ClickEventArgs)
-				if len(method.Params) == 0 {
-					// func() - use no-arg adapter
-					eventHandlers = append(eventHandlers, fmt.Sprintf(`"%s": events.AdaptNoArgEvent(%s)`, jsEventName, handlerRef))
-				} else if len(method.Params) == 1 && method.Params[0].Type == "events.ClickEventArgs" {
-					// func(ClickEventArgs) - use click adapter
-					eventHandlers = append(eventHandlers, fmt.Sprintf(`"%s": events.AdaptClickEvent(%s)`, jsEventName, handlerRef))
-				}
-			} else if eventSig.RequiresArgs {
-				// Event requires arguments - use the appropriate adapter
-				var adapterFunc string
-				switch eventSig.ArgsType {
-				case "events.ChangeEventArgs":
-					adapterFunc = "events.AdaptChangeEvent"
-				case "events.KeyboardEventArgs":
-					adapterFunc = "events.AdaptKeyboardEvent"
-				case "events.MouseEventArgs":
-					adapterFunc = "events.AdaptMouseEvent"
-				case "events.FocusEventArgs":
-					adapterFunc = "events.AdaptFocusEvent"
-				case "events.FormEventArgs":
-					adapterFunc = "events.AdaptFormEvent"
-				default:
-					fmt.Fprintf(os.Stderr, "Internal Error: Unknown event args type '%s'\n", eventSig.ArgsType)
-					os.Exit(1)
-				}
-				eventHandlers = append(eventHandlers, fmt.Sprintf(`"%s": %s(%s)`, jsEventName, adapterFunc, handlerRef))
-			} else {
-				// Event requires no arguments - use the no-arg adapter
-				eventHandlers = append(eventHandlers, fmt.Sprintf(`"%s": events.AdaptNoArgEvent(%s)`, jsEventName, handlerRef))
+			// Event modifiers wrap the adapted handler
+			if modifierList != "" {
+				handlerCode = applyEventModifiers(handlerCode, eventName, strings.Split(modifierList, "."), currentComp, htmlSource, lineNumber)
 			}
-
-			// Mark that method is used (prevents unused warnings)
-			_ = method
+			eventHandlers = append(eventHandlers, fmt.Sprintf(`"%s": %s`, jsEventName, handlerCode))
 		} else {
+			// Namespaced attributes keep their prefix (xlink:href); the vdom sets them with setAttributeNS
+			key := a.Key
+			if a.Namespace != "" {
+				key = a.Namespace + ":" + a.Key
+			}
+
 			// Check for inline conditional expressions in attribute values
 			attrValue := a.Val
 			lineNum := estimateLineNumber(htmlSource, fmt.Sprintf(`%s="%s"`, a.Key, attrValue))
@@ -106,144 +115,242 @@ func generateAttributesMap(n *html.Node, receiver string, currentComp componentI
 				}
 			}
 
-			// Pattern 1: Check for boolean shorthand syntax for boolean attributes
-			// This must come BEFORE general data binding to handle boolean attributes correctly
-			if match := booleanShorthandRegex.FindStringSubmatch(attrValue); match != nil && isBooleanAttribute(a.Key) {
-				negated := match[1] == "!"
-				condition := match[2]
-
-				// Validate condition is a boolean field
-				propDesc := validateBooleanCondition(condition, currentComp, currentComp.Path, lineNum, htmlSource)
-
-				// Generate conditional code: if negated, invert the condition
-				if negated {
-					attrs = append(attrs, fmt.Sprintf(`"%s": !%s.%s`, a.Key, receiver, propDesc.Name))
-				} else {
-					attrs = append(attrs, fmt.Sprintf(`"%s": %s.%s`, a.Key, receiver, propDesc.Name))
+			segments := scanBindings(attrValue)
+			if hasBindings(segments) {
+				checker := currentComp.Expr
+
+				// Pattern 1: The whole value is one binding (e.g. href="{Href}", disabled="{!IsValid}").
+				// The value keeps its Go type; boolean attributes require a bool expression.
+				if len(segments) == 1 {
+					code, typ := checker.compileBinding(segments[0].Text, lineNum, loopCtx)
+					if isBooleanAttribute(schemaTagName(n), a.Key) && !isBoolType(typ) {
+						checker.fail(checker.locate(segments[0].Text, lineNum), 1, 1,
+							fmt.Sprintf("boolean attribute '%s' needs a bool expression, found type '%s'", a.Key, checker.typeString(typ)), loopCtx)
+					}
+					attrs = append(attrs, fmt.Sprintf(`"%s": %s`, key, code))
+					continue
 				}
+
+				// Pattern 2: Bindings and ternaries mixed with text (e.g. class="btn {Active ? 'on' : 'off'}")
+				attrs = append(attrs, fmt.Sprintf(`"%s": %s`, key, checker.compileInterpolation(attrValue, lineNum, loopCtx)))
 				continue
 			}
 
-			// Pattern 2: Ternary expressions in attribute values
-			if ternaryExprRegex.MatchString(attrValue) {
-				// Replace all ternary expressions in the value
-				result := attrValue
-				ternaryMatches := ternaryExprRegex.FindAllStringSubmatch(attrValue, -1)
-
-				for _, match := range ternaryMatches {
-					fullMatch := match[0]
-					negated := match[1] == "!"
-					condition := match[2]
-					trueVal := match[3]
-					falseVal := match[4]
-
-					// Validate condition is a boolean field
-					propDesc := validateBooleanCondition(condition, currentComp, currentComp.Path, lineNum, htmlSource)
-
-					// Generate ternary expression
-					ternaryCode := generateTernaryExpression(negated, condition, trueVal, falseVal, receiver, propDesc)
-
-					// If the attribute value is only the ternary expression
-					if result == fullMatch {
-						attrs = append(attrs, fmt.Sprintf(`"%s": %s`, a.Key, ternaryCode))
-						result = ""
-						break
-					}
+			// Pattern 3: Regular static attribute
+			attrs = append(attrs, fmt.Sprintf(`"%s": "%s"`, key, a.Val))
+		}
+	}
 
-					// Otherwise, replace in the string (for concatenation)
-					result = strings.Replace(result, fullMatch, "%s", 1)
-				}
+	// Elements of a component with a stylesheet carry its scope attribute, which its scoped
+	// selectors require
+	if currentComp.ScopeAttr != "" {
+		attrs = append(attrs, fmt.Sprintf(`"%s": ""`, currentComp.ScopeAttr))
+	}
 
-				// If there were other parts, wrap in fmt.Sprintf
-				if result != "" {
-					var args []string
-					for _, match := range ternaryMatches {
-						negated := match[1] == "!"
-						condition := match[2]
-						trueVal := match[3]
-						falseVal := match[4]
-						propDesc := validateBooleanCondition(condition, currentComp, currentComp.Path, lineNum, htmlSource)
-						args = append(args, generateTernaryExpression(negated, condition, trueVal, falseVal, receiver, propDesc))
-					}
-					attrs = append(attrs, fmt.Sprintf(`"%s": fmt.Sprintf(%s, %s)`, a.Key, strconv.Quote(result), strings.Join(args, ", ")))
-				}
-				continue
-			}
+	if len(attrs) == 0 && len(eventHandlers) == 0 {
+		return "nil"
+	}
+	allProps := append(attrs, eventHandlers...)
+	return fmt.Sprintf("map[string]any{%s}", strings.Join(allProps, ", "))
+}
 
-			// Pattern 3: Regular data binding in attribute values (e.g., {FieldName})
-			// This handles simple property interpolation for non-boolean attributes
-			matches := dataBindingRegex.FindAllStringSubmatch(attrValue, -1)
-			if len(matches) > 0 {
-				// Check if the entire value is a single data binding (e.g., href='{Href}')
-				if len(matches) == 1 && matches[0][0] == attrValue {
-					fieldName := matches[0][1]
-
-					// Validate that the field exists (check both Props and State)
-					propDesc, exists := currentComp.Schema.Props[strings.ToLower(fieldName)]
-					if !exists {
-						propDesc, exists = currentComp.Schema.State[strings.ToLower(fieldName)]
-					}
-					if !exists {
-						allFields := append(getAvailableFieldNames(currentComp.Schema.Props), getAvailableFieldNames(currentComp.Schema.State)...)
-						availableFields := strings.Join(allFields, ", ")
-						contextLines := getContextLines(htmlSource, lineNum, 2)
-						fmt.Fprintf(os.Stderr, "Compilation Error in %s:%d: Property '%s' not found in component struct. Available fields: [%s]\n%s",
-							currentComp.Path, lineNum, fieldName, availableFields, contextLines)
-						os.Exit(1)
-					}
+// eventAdapter returns the events adapter function for handlers taking argsType.
+func eventAdapter(argsType string) string {
+	switch argsType {
+	case "events.ClickEventArgs":
+		return "events.AdaptClickEvent"
+	case "events.ChangeEventArgs":
+		return "events.AdaptChangeEvent"
+	case "events.KeyboardEventArgs":
+		return "events.AdaptKeyboardEvent"
+	case "events.MouseEventArgs":
+		return "events.AdaptMouseEvent"
+	case "events.FocusEventArgs":
+		return "events.AdaptFocusEvent"
+	case "events.FormEventArgs":
+		return "events.AdaptFormEvent"
+	case "events.PointerEventArgs":
+		return "events.AdaptPointerEvent"
+	case "events.TouchEventArgs":
+		return "events.AdaptTouchEvent"
+	case "events.WheelEventArgs":
+		return "events.AdaptWheelEvent"
+	case "events.ScrollEventArgs":
+		return "events.AdaptScrollEvent"
+	case "events.DragEventArgs":
+		return "events.AdaptDragEvent"
+	case "events.ClipboardEventArgs":
+		return "events.AdaptClipboardEvent"
+	case "events.CompositionEventArgs":
+		return "events.AdaptCompositionEvent"
+	case "events.AnimationEventArgs":
+		return "events.AdaptAnimationEvent"
+	case "events.TransitionEventArgs":
+		return "events.AdaptTransitionEvent"
+	}
+	fmt.Fprintf(os.Stderr, "Internal Error: Unknown event args type '%s'\n", argsType)
+	os.Exit(1)
+	return ""
+}
 
-					// Generate direct field reference
-					attrs = append(attrs, fmt.Sprintf(`"%s": %s.%s`, a.Key, receiver, propDesc.Name))
-					continue
-				}
+// compileEventCall compiles an @event attribute whose value calls a component method with
+// arguments, such as @onclick="Remove(item.ID)" inside a {@for} row. The call is type-checked
+// like a template expression, so arguments may use loop variables and component fields and
+// must match the method's parameters. The event args are in scope as e and can be passed on
+// (@onclick="Rename(item.ID, e)"). The call compiles to a closure for the event's adapter.
+func compileEventCall(attrKey, eventName, call, tagName string, currentComp componentInfo, htmlSource string, lineNumber int, loopCtx *loopContext) string {
+	eventSig := validateEventName(eventName, tagName, currentComp.Path, lineNumber, htmlSource)
+	checker := currentComp.Expr
+	src := checker.locateIn(`@`+attrKey+`="`, call, `"`, lineNumber)
+
+	// Syntax errors are reported by typeCheck below
+	if parsed, err := parser.ParseExpr(call); err == nil {
+		callExpr, isCall := parsed.(*ast.CallExpr)
+		var fun *ast.Ident
+		if isCall {
+			fun, _ = callExpr.Fun.(*ast.Ident)
+		}
+		if fun == nil {
+			checker.fail(src, 1, 1, fmt.Sprintf("an event handler must be a method name or a call of one, e.g. @%s=\"Remove(item.ID)\"", eventName), loopCtx)
+		}
+		if _, isMethod := currentComp.Schema.Methods[fun.Name]; !isMethod {
+			checker.fail(src, 1, 1, fmt.Sprintf("handler method '%s' not found on component '%s'. Available methods: %s",
+				fun.Name, currentComp.PascalName, getAvailableMethodNames(currentComp.Schema.Methods)), loopCtx)
+		}
+	}
 
-				// Multiple bindings or mixed content (e.g., '{Base}/{Path}')
-				formatString := dataBindingRegex.ReplaceAllString(attrValue, "%v")
-				var args []string
-				for _, match := range matches {
-					fieldName := match[1]
+	// The event args are in scope as e, unless a loop variable of that name shadows them
+	var locals []*types.Var
+	argsVar := eventArgsVar(checker, eventSig.ArgsType)
+	if argsVar != nil {
+		locals = append(locals, argsVar)
+	}
+	code, _, info := checker.typeCheck(src, loopCtx, locals)
 
-					// Validate that the field exists (check both Props and State)
-					propDesc, exists := currentComp.Schema.Props[strings.ToLower(fieldName)]
-					if !exists {
-						propDesc, exists = currentComp.Schema.State[strings.ToLower(fieldName)]
-					}
-					if !exists {
-						allFields := append(getAvailableFieldNames(currentComp.Schema.Props), getAvailableFieldNames(currentComp.Schema.State)...)
-						availableFields := strings.Join(allFields, ", ")
-						contextLines := getContextLines(htmlSource, lineNum, 2)
-						fmt.Fprintf(os.Stderr, "Compilation Error in %s:%d: Property '%s' not found in component struct. Available fields: [%s]\n%s",
-							currentComp.Path, lineNum, fieldName, availableFields, contextLines)
-						os.Exit(1)
-					}
+	for _, obj := range info.Uses {
+		if argsVar != nil && obj == argsVar {
+			return fmt.Sprintf("%s(func(e %s) { %s })", eventAdapter(eventSig.ArgsType), eventSig.ArgsType, code)
+		}
+	}
+	return fmt.Sprintf("events.AdaptNoArgEvent(func() { %s })", code)
+}
 
-					args = append(args, fmt.Sprintf("%s.%s", receiver, propDesc.Name))
-				}
-				attrs = append(attrs, fmt.Sprintf(`"%s": fmt.Sprintf(%s, %s)`, a.Key, strconv.Quote(formatString), strings.Join(args, ", ")))
-				continue
-			}
+// eventArgsVar returns a variable e of the events package type named by argsType (e.g.
+// "events.ClickEventArgs"), or nil if the events package could not be loaded.
+func eventArgsVar(checker *exprChecker, argsType string) *types.Var {
+	pkg := loadedPackage(generatedFileImports["events"])
+	if pkg == nil {
+		return nil
+	}
+	obj, ok := pkg.Scope().Lookup(strings.TrimPrefix(argsType, "events.")).(*types.TypeName)
+	if !ok {
+		return nil
+	}
+	return types.NewVar(token.NoPos, checker.pkg.Types, "e", obj.Type())
+}
+
+// modifierKeyArgs are the non-keyboard event args types whose events report held modifier keys.
+var modifierKeyArgs = map[string]bool{
+	"events.ClickEventArgs":   true,
+	"events.MouseEventArgs":   true,
+	"events.PointerEventArgs": true,
+	"events.TouchEventArgs":   true,
+	"events.WheelEventArgs":   true,
+	"events.DragEventArgs":    true,
+}
 
-			// Pattern 4: Regular static attribute
-			attrs = append(attrs, fmt.Sprintf(`"%s": "%s"`, a.Key, a.Val))
+// applyEventModifiers wraps the adapted handler code of an @event attribute with its dotted
+// modifiers. Filters and default-action modifiers (.prevent, .stop, .self, key filters such as
+// .enter or .ctrl.s) become an events.ApplyModifiers wrapper; listener options (.capture,
+// .passive, .once) become a vdom.EventListener.
+func applyEventModifiers(handlerCode, eventName string, modifiers []string, currentComp componentInfo, htmlSource string, lineNumber int) string {
+	eventSig := events.GetEventSignature(eventName)
+	isKeyboard := eventSig.ArgsType == "events.KeyboardEventArgs"
+	hasModifierKeys := isKeyboard || modifierKeyArgs[eventSig.ArgsType]
+
+	var filters, options []string
+	seen := make(map[string]bool)
+	key := ""
+	for _, m := range modifiers {
+		if seen[m] {
+			templateError(currentComp, htmlSource, lineNumber, fmt.Sprintf("Duplicate event modifier '.%s' on '@%s'.", m, eventName))
 		}
+		seen[m] = true
+
+		switch m {
+		case "prevent":
+			filters = append(filters, "PreventDefault: true")
+		case "stop":
+			filters = append(filters, "StopPropagation: true")
+		case "self":
+			filters = append(filters, "Self: true")
+		case "capture", "passive", "once":
+			options = append(options, strings.ToUpper(m[:1])+m[1:]+": true")
+		case "ctrl", "shift", "alt", "meta":
+			if !hasModifierKeys {
+				templateError(currentComp, htmlSource, lineNumber, fmt.Sprintf("Modifier '.%s' needs a keyboard or mouse event; '@%s' has no modifier key state.", m, eventName))
+			}
+			filters = append(filters, strings.ToUpper(m[:1])+m[1:]+": true")
+		default:
+			keyValue, isNamedKey := events.KeyModifiers[m]
+			if !isNamedKey && !isKeyCharacter(m) {
+				templateError(currentComp, htmlSource, lineNumber, fmt.Sprintf("Unknown event modifier '.%s' on '@%s'.\n"+
+					"Supported modifiers: .prevent, .stop, .self, .once, .capture, .passive, .ctrl, .shift, .alt, .meta\n"+
+					"Key filters (keyboard events only): .enter, .escape, .tab, .space, .up, .down, .left, .right, .delete, .backspace, .home, .end, .pageup, .pagedown, or a single letter or digit", m, eventName))
+			}
+			if !isKeyboard {
+				templateError(currentComp, htmlSource, lineNumber, fmt.Sprintf("Key filter '.%s' is only supported on keyboard events (@onkeydown, @onkeyup, @onkeypress), not '@%s'.", m, eventName))
+			}
+			if key != "" {
+				templateError(currentComp, htmlSource, lineNumber, fmt.Sprintf("'@%s' has more than one key filter ('.%s' and '.%s').", eventName, key, m))
+			}
+			key = m
+			if !isNamedKey {
+				keyValue = m
+			}
+			filters = append(filters, "Key: "+strconv.Quote(keyValue))
+		}
+	}
+	if seen["passive"] && seen["prevent"] {
+		templateError(currentComp, htmlSource, lineNumber, fmt.Sprintf("'.passive' and '.prevent' cannot be combined on '@%s': a passive listener cannot prevent the default action.", eventName))
 	}
 
-	if len(attrs) == 0 && len(eventHandlers) == 0 {
-		return "nil"
+	if len(filters) > 0 {
+		handlerCode = fmt.Sprintf("events.ApplyModifiers(%s, events.Modifiers{%s})", handlerCode, strings.Join(filters, ", "))
 	}
-	allProps := append(attrs, eventHandlers...)
-	return fmt.Sprintf("map[string]any{%s}", strings.Join(allProps, ", "))
+	if len(options) > 0 {
+		handlerCode = fmt.Sprintf("vdom.EventListener{Handler: %s, %s}", handlerCode, strings.Join(options, ", "))
+	}
+	return handlerCode
+}
+
+// isKeyCharacter reports whether a modifier is a single letter or digit key filter (@onkeydown.ctrl.s).
+func isKeyCharacter(m string) bool {
+	return len(m) == 1 && (m[0] >= 'a' && m[0] <= 'z' || m[0] >= '0' && m[0] <= '9')
 }
 
 // generateStructLiteral creates the { Field: value, ... } string.
 // If the component has a content slot, it collects child nodes and includes them in the struct literal.
+// A generic component's literal starts with its type arguments: [string]{ Field: value, ... }.
 func generateStructLiteral(n *html.Node, compInfo componentInfo, receiver string, componentMap map[string]componentInfo, currentComp componentInfo, htmlSource string, templatePath string, opts compileOptions, loopCtx *loopContext) string {
 	var props []string
 
 	// Extract the original attribute names from the HTML source
 	originalAttrs, lineNumber := extractOriginalAttributesWithLineNumber(n, compInfo.LowercaseName, htmlSource)
 
+	typeArgs := instantiateComponent(n, &compInfo, currentComp, htmlSource, lineNumber, loopCtx)
+
 	for _, attr := range n.Attr {
+		// @onclose="HandleClose" binds a handler to one of the child's events
+		if strings.HasPrefix(attr.Key, "@") {
+			props = append(props, compileComponentEvent(attr.Key, attr.Val, compInfo, receiver, currentComp, htmlSource, lineNumber, loopCtx))
+			continue
+		}
+		// type:T="string" is a type argument of a generic component (instantiateComponent)
+		if strings.HasPrefix(attr.Key, "type:") {
+			continue
+		}
+
 		// Get the original casing from the source
 		originalKey := attr.Key
 		if origName, found := originalAttrs[attr.Key]; found {
@@ -256,7 +363,7 @@ func generateStructLiteral(n *html.Node, compInfo componentInfo, receiver string
 			lookupKey := strings.ToLower(originalKey)
 
 			if propDesc, ok := compInfo.Schema.Props[lookupKey]; ok {
-				valueStr := convertPropValue(attr.Val, propDesc.GoType, receiver, currentComp, htmlSource, lineNumber, loopCtx)
+				valueStr := convertPropValue(attr.Val, propDesc.GoType, componentFieldType(compInfo, propDesc.Name), receiver, currentComp, htmlSource, lineNumber, loopCtx)
 				props = append(props, fmt.Sprintf("%s: %s", propDesc.Name, valueStr))
 			} else {
 				// Attribute starts with capital letter but doesn't match any exported field
@@ -268,28 +375,93 @@ func generateStructLiteral(n *html.Node, compInfo componentInfo, receiver string
 			}
 		} else if propDesc, ok := compInfo.Schema.Props[attr.Key]; ok {
 			// Lowercase attribute that happens to match a field
-			valueStr := convertPropValue(attr.Val, propDesc.GoType, receiver, currentComp, htmlSource, lineNumber, loopCtx)
+			valueStr := convertPropValue(attr.Val, propDesc.GoType, componentFieldType(compInfo, propDesc.Name), receiver, currentComp, htmlSource, lineNumber, loopCtx)
 			props = append(props, fmt.Sprintf("%s: %s", propDesc.Name, valueStr))
 		}
 	}
 
-	// Handle content slot if component has one
-	if compInfo.Schema.Slot != nil {
-		slotContent := collectSlotChildren(n, receiver, componentMap, currentComp, compInfo.PascalName, templatePath, htmlSource, opts, loopCtx)
-		if slotContent == "" {
-			// Empty slot: compile to nil
-			props = append(props, fmt.Sprintf("%s: nil", compInfo.Schema.Slot.Name))
-		} else {
-			// Has children: compile to VNode slice
-			props = append(props, fmt.Sprintf("%s: %s", compInfo.Schema.Slot.Name, slotContent))
+	// Handle content slots if the component has any
+	if len(compInfo.Schema.Slots) > 0 {
+		slotContent := collectSlotChildren(n, compInfo, receiver, componentMap, currentComp, htmlSource, lineNumber, opts, loopCtx)
+		for _, slot := range compInfo.Schema.Slots {
+			code, filled := slotContent[slot.Name]
+			if !filled {
+				// Unfilled slot: compile to nil. An empty <slot name="..."></slot> leaves it empty on purpose.
+				templateWarning(currentComp, htmlSource, lineNumber, fmt.Sprintf("Slot '%s' of component '%s' is not filled.", slot.Name, compInfo.PascalName))
+				code = "nil"
+			}
+			props = append(props, fmt.Sprintf("%s: %s", slot.Name, code))
+		}
+	}
+
+	// Scoped slots are filled by <name let:v> blocks, which render once per value
+	if len(compInfo.Schema.ScopedSlots) > 0 {
+		filled := make(map[string]bool)
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type != html.ElementNode || c.Data != "go-let" {
+				continue
+			}
+			name := getAttr(c, "data-slot")
+			slot, ok := compInfo.Schema.ScopedSlots[name]
+			if !ok {
+				templateError(currentComp, htmlSource, lineNumber, fmt.Sprintf("Component '%s' has no scoped slot named '%s'. Available scoped slots: [%s]",
+					compInfo.PascalName, name, strings.Join(getAvailableFieldNames(compInfo.Schema.ScopedSlots), ", ")))
+			}
+			if filled[slot.Name] {
+				templateError(currentComp, htmlSource, lineNumber, fmt.Sprintf("Scoped slot '%s' of component '%s' is filled more than once", slot.Name, compInfo.PascalName))
+			}
+			filled[slot.Name] = true
+			code := generateScopedSlotCode(c, slot, compInfo, receiver, componentMap, currentComp, htmlSource, lineNumber, opts, loopCtx)
+			props = append(props, fmt.Sprintf("%s: %s", slot.Name, code))
+		}
+		names := getAvailableFieldNames(compInfo.Schema.ScopedSlots)
+		sort.Strings(names)
+		for _, name := range names {
+			if !filled[name] {
+				// Unfilled scoped slot: the field stays nil
+				templateWarning(currentComp, htmlSource, lineNumber, fmt.Sprintf("Scoped slot '%s' of component '%s' is not filled.", name, compInfo.PascalName))
+			}
 		}
+	} else if c := findScopedSlotBlock(n); c != nil {
+		templateError(currentComp, htmlSource, lineNumber, fmt.Sprintf("Component '%s' has no scoped slots; <%s let:%s> cannot fill one",
+			compInfo.PascalName, getAttr(c, "data-slot"), getAttr(c, "data-var")))
 	}
 
 	if len(props) == 0 {
-		return "{}"
+		return typeArgs + "{}"
+	}
+
+	return fmt.Sprintf("%s{%s}", typeArgs, strings.Join(props, ", "))
+}
+
+// generateScopedSlotCode generates the closure that fills a scoped slot from a
+// <name let:v> block. The child calls it with a value, which the block's content sees as
+// v; components inside the block are keyed by that value.
+func generateScopedSlotCode(n *html.Node, slot propertyDescriptor, compInfo componentInfo, receiver string, componentMap map[string]componentInfo, currentComp componentInfo, htmlSource string, lineNumber int, opts compileOptions, loopCtx *loopContext) string {
+	varName := getAttr(n, "data-var")
+	sig, ok := componentFieldType(compInfo, slot.Name).(*types.Signature)
+	if !ok {
+		templateError(currentComp, htmlSource, lineNumber, fmt.Sprintf("Cannot determine the value type of scoped slot '%s' on component '%s'", slot.Name, compInfo.PascalName))
 	}
+	valueType := sig.Params().At(0).Type()
 
-	return fmt.Sprintf("{%s}", strings.Join(props, ", "))
+	slotCtx := &loopContext{ValueVar: varName, ValueType: valueType, Parent: loopCtx}
+	root := generateRootCode(n, receiver, componentMap, currentComp, htmlSource, opts, slotCtx)
+	if root == "" {
+		root = "nil"
+	}
+	return fmt.Sprintf("func(%[1]s %[2]s) *vdom.VNode {\n%[1]s_key := fmt.Sprintf(\"%[3]s:%%v\", %[1]s)\n_ = %[1]s_key\nreturn %[4]s\n}",
+		varName, currentComp.Expr.typeCode(valueType), slot.Name, root)
+}
+
+// findScopedSlotBlock returns the first <name let:v> block among the children of n, or nil.
+func findScopedSlotBlock(n *html.Node) *html.Node {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && c.Data == "go-let" {
+			return c
+		}
+	}
+	return nil
 }
 
 // extractOriginalAttributesWithLineNumber extracts the original attribute names and line number from the HTML source.
@@ -331,98 +503,42 @@ func extractOriginalAttributesWithLineNumber(n *html.Node, componentName, htmlSo
 }
 
 // convertPropValue generates the Go code to convert a string to the target type.
+// A value that is a single {…} binding is a type-checked Go expression and must be assignable
+// to targetType (nil when the child's package could not be type-checked).
 // It handles data binding expressions in attribute values, respecting loop context.
-func convertPropValue(value, goType string, receiver string, currentComp componentInfo, htmlSource string, lineNumber int, loopCtx *loopContext) string {
+func convertPropValue(value, goType string, targetType types.Type, receiver string, currentComp componentInfo, htmlSource string, lineNumber int, loopCtx *loopContext) string {
 	// Debug: uncomment to see what values are being converted
 	// fmt.Fprintf(os.Stderr, "[convertPropValue] value=%q goType=%q\n", value, goType)
 
-	// First, check if value is wrapped in braces {}: if so, extract and handle as expression
-	if strings.HasPrefix(value, "{") && strings.HasSuffix(value, "}") {
-		// Extract the Go code from within braces
-		goCode := strings.TrimSuffix(strings.TrimPrefix(value, "{"), "}")
+	// First, check if the whole value is one {…} binding: if so, compile it as a Go expression
+	if segments := scanBindings(value); len(segments) == 1 && segments[0].IsBinding {
+		checker := currentComp.Expr
+		code, typ := checker.compileBinding(segments[0].Text, lineNumber, loopCtx)
 
-		// For boolean literals, use as-is
-		if goCode == "true" || goCode == "false" {
-			return goCode
+		// Untyped constants ({true}, {42}) are left to the Go compiler's conversion rules
+		if targetType != nil && !isUntypedType(typ) && !types.AssignableTo(typ, targetType) {
+			checker.fail(checker.locate(segments[0].Text, lineNumber), 1, 1,
+				fmt.Sprintf("cannot use value of type '%s' as prop of type '%s'", checker.typeString(typ), checker.typeString(targetType)), loopCtx)
 		}
+		return code
+	}
 
-		// For qualified names (e.g., modal.Information), use as-is
-		if strings.Contains(goCode, ".") && !strings.Contains(goCode, "(") {
-			return goCode
-		}
-
-		// For simple identifiers (component fields or loop variables)
-		if !strings.Contains(goCode, " ") && !strings.Contains(goCode, "(") {
-			// Check if this is a loop variable
-			if loopCtx != nil && (goCode == loopCtx.ValueVar || goCode == loopCtx.IndexVar || strings.HasPrefix(goCode, loopCtx.ValueVar+".")) {
-				return goCode
-			}
-
-			// Check if it's a component field (props or state)
-			propDesc, inProps := currentComp.Schema.Props[strings.ToLower(goCode)]
-			if !inProps {
-				propDesc, inProps = currentComp.Schema.State[strings.ToLower(goCode)]
-			}
-			if inProps {
-				// It's a component field - add receiver prefix
-				return fmt.Sprintf("%s.%s", receiver, propDesc.Name)
-			}
+	// Text mixed with bindings is only a string; any other prop must be bound as one {…} value
+	if hasBindings(scanBindings(value)) {
+		if goType != "string" {
+			templateError(currentComp, htmlSource, lineNumber,
+				fmt.Sprintf("Value %q mixes text and bindings, which is only allowed for string props; bind the whole value of the '%s' prop as one {…} expression", value, goType))
 		}
-
-		// For everything else (e.g., method names, complex expressions), use as-is
-		return goCode
+		return generateTextExpression(value, receiver, currentComp, htmlSource, lineNumber, loopCtx)
 	}
 
 	switch goType {
 	case "string":
-		// Check if the value contains data binding expressions
-		if dataBindingRegex.MatchString(value) {
-			// Use generateTextExpression to handle bindings (including loop variables)
-			return generateTextExpression(value, receiver, currentComp, htmlSource, lineNumber, loopCtx)
-		}
 		return strconv.Quote(value)
 	case "int":
-		// Check if the value contains data binding expressions (e.g., {UserId})
-		if dataBindingRegex.MatchString(value) {
-			// Extract the field name from the binding
-			matches := dataBindingRegex.FindStringSubmatch(value)
-			if len(matches) > 1 {
-				fieldName := matches[1]
-				// Check if this is a loop variable
-				if loopCtx != nil && fieldName == loopCtx.ValueVar {
-					// Direct reference to loop value variable
-					return fieldName
-				} else if loopCtx != nil && strings.HasPrefix(fieldName, loopCtx.ValueVar+".") {
-					// Reference to a field of the loop value (e.g., user.ID)
-					return fieldName
-				} else {
-					// Reference to component field
-					return fmt.Sprintf("%s.%s", receiver, fieldName)
-				}
-			}
-		}
 		// Literal integer value
 		return fmt.Sprintf("func() int { i, _ := strconv.Atoi(\"%s\"); return i }()", value)
 	case "bool":
-		// Check if the value contains data binding expressions (e.g., {IsActive})
-		if dataBindingRegex.MatchString(value) {
-			// Extract the field name from the binding
-			matches := dataBindingRegex.FindStringSubmatch(value)
-			if len(matches) > 1 {
-				fieldName := matches[1]
-				// Check if this is a loop variable
-				if loopCtx != nil && fieldName == loopCtx.ValueVar {
-					// Direct reference to loop value variable
-					return fieldName
-				} else if loopCtx != nil && strings.HasPrefix(fieldName, loopCtx.ValueVar+".") {
-					// Reference to a field of the loop value
-					return fieldName
-				} else {
-					// Reference to component field
-					return fmt.Sprintf("%s.%s", receiver, fieldName)
-				}
-			}
-		}
 		// Literal boolean value
 		return fmt.Sprintf("func() bool { b, _ := strconv.ParseBool(\"%s\"); return b }()", value)
 	default:
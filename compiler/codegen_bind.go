@@ -0,0 +1,248 @@
+package compiler
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/types"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// bindCode is the generated code for an element's @bind directive.
+type bindCode struct {
+	Content string   // Go expression for the element's value (input, textarea, select); "" for checkboxes
+	Attrs   []string // Extra attribute map entries, e.g. "checked": c.Enabled
+	Handler string   // Event map entry that writes user input back to the bound field
+}
+
+// compileBind compiles the @bind="Expr" directive of an <input>, <textarea> or <select>, or
+// returns nil if the element has none. The bound expression must be assignable; its type
+// decides how the DOM value is formatted and parsed back:
+//   - string kinds are copied as is
+//   - integer and float kinds go through strconv; input that does not parse is ignored
+//   - bool binds a checkbox's checked state
+//   - types whose pointer implements encoding.TextUnmarshaler are parsed with UnmarshalText
+//
+// Text inputs and textareas update on "oninput", checkboxes and selects on "onchange";
+// @bind:event="onchange" or "oninput" overrides the default.
+func compileBind(n *html.Node, receiver string, currentComp componentInfo, htmlSource string, loopCtx *loopContext) *bindCode {
+	bindExpr, hasBind := "", false
+	eventOverride := ""
+	for _, a := range n.Attr {
+		switch a.Key {
+		case "@bind":
+			bindExpr, hasBind = a.Val, true
+		case "@bind:event":
+			eventOverride = a.Val
+		}
+	}
+	if !hasBind {
+		if eventOverride != "" {
+			templateError(currentComp, htmlSource, findEventLineNumber(n, "bind:event", htmlSource), "'@bind:event' requires a '@bind' directive on the same element.")
+		}
+		return nil
+	}
+	lineNumber := findEventLineNumber(n, "bind", htmlSource)
+
+	tagName := n.Data
+	inputType := strings.ToLower(getAttr(n, "type"))
+	if tagName != "input" && tagName != "textarea" && tagName != "select" {
+		templateError(currentComp, htmlSource, lineNumber, fmt.Sprintf("'@bind' is not supported on <%s>. Supported elements: <input>, <textarea>, <select>", tagName))
+	}
+	if tagName == "input" && (inputType == "radio" || inputType == "file") {
+		templateError(currentComp, htmlSource, lineNumber, fmt.Sprintf("'@bind' is not supported on <input type=\"%s\">; use an event handler instead", inputType))
+	}
+	isCheckbox := tagName == "input" && inputType == "checkbox"
+
+	// The event that writes the value back
+	eventName := "oninput"
+	if tagName == "select" || isCheckbox {
+		eventName = "onchange"
+	}
+	if eventOverride != "" {
+		if eventOverride != "oninput" && eventOverride != "onchange" {
+			templateError(currentComp, htmlSource, lineNumber, fmt.Sprintf("'@bind:event' must be \"oninput\" or \"onchange\", found \"%s\"", eventOverride))
+		}
+		eventName = eventOverride
+	}
+	for _, a := range n.Attr {
+		conflict := a.Key == "@"+eventName ||
+			(a.Key == "value" && !isCheckbox) ||
+			(a.Key == "checked" && isCheckbox)
+		if conflict {
+			templateError(currentComp, htmlSource, lineNumber, fmt.Sprintf("'%s' cannot be combined with '@bind' on the same element; @bind already sets it", a.Key))
+		}
+	}
+
+	checker := currentComp.Expr
+	src := checker.locateIn(`@bind="`, bindExpr, `"`, lineNumber)
+	target, tv := checker.checkValue(src, loopCtx)
+	if !tv.Assignable() {
+		checker.fail(src, 1, 1, fmt.Sprintf("cannot bind to %s: @bind needs an assignable field or variable", strings.TrimSpace(bindExpr)), loopCtx)
+	}
+	if loopVar := boundLoopVariable(bindExpr, loopCtx); loopVar != "" {
+		checker.fail(src, 1, 1, fmt.Sprintf("cannot bind to %s: '%s' is a copy of the current element; range over pointers or bind through the index (e.g. Items[i].Field)", strings.TrimSpace(bindExpr), loopVar), loopCtx)
+	}
+	typ := tv.Type
+	typeName := checker.typeString(typ)
+	// Values of a named type (type Level int) are converted explicitly
+	_, unnamed := types.Unalias(typ).(*types.Basic)
+	convert := func(value string) string {
+		if unnamed {
+			return value
+		}
+		return fmt.Sprintf("%s(%s)", checker.typeCode(typ), value)
+	}
+	jsEventName := "on" + strings.ToUpper(eventName[2:3]) + eventName[3:]
+
+	if isCheckbox {
+		if !isBoolType(typ) {
+			checker.fail(src, 1, 1, fmt.Sprintf("a checkbox binds to a bool, found type '%s'", typeName), loopCtx)
+		}
+		checked := target
+		if !unnamed {
+			checked = fmt.Sprintf("bool(%s)", target)
+		}
+		return &bindCode{
+			Attrs: []string{fmt.Sprintf(`"checked": %s`, checked)},
+			Handler: fmt.Sprintf(`"%s": events.AdaptBindChecked(func(value bool) {
+				%s = %s
+				%s.StateHasChanged()
+			})`, jsEventName, target, convert("value"), receiver),
+		}
+	}
+
+	// Format the field for display and parse the DOM value back into it
+	var content, parse string
+	basic, _ := typ.Underlying().(*types.Basic)
+	switch {
+	case hasTextMethod(types.NewPointer(typ), "UnmarshalText", true):
+		if hasTextMethod(typ, "MarshalText", false) {
+			content = fmt.Sprintf("func() string { text, _ := %s.MarshalText(); return string(text) }()", target)
+		} else {
+			content = fmt.Sprintf(`fmt.Sprintf("%%v", %s)`, target)
+		}
+		parse = fmt.Sprintf(`var parsed %s
+				if err := parsed.UnmarshalText([]byte(value)); err != nil {
+					return
+				}
+				%s = parsed`, checker.typeCode(typ), target)
+	case basic != nil && basic.Info()&types.IsString != 0:
+		content = target
+		if !unnamed {
+			content = fmt.Sprintf("string(%s)", target)
+		}
+		parse = fmt.Sprintf("%s = %s", target, convert("value"))
+	case basic != nil && basic.Info()&types.IsInteger != 0:
+		parseFunc, formatFunc, wide := "ParseInt", "FormatInt", "int64"
+		if basic.Info()&types.IsUnsigned != 0 {
+			parseFunc, formatFunc, wide = "ParseUint", "FormatUint", "uint64"
+		}
+		content = fmt.Sprintf("strconv.%s(%s(%s), 10)", formatFunc, wide, target)
+		parse = fmt.Sprintf(`parsed, err := strconv.%s(%s.TrimSpace(value), 10, %d)
+				if err != nil {
+					return
+				}
+				%s = %s`, parseFunc, checker.requireImport("strings"), bitSize(basic), target, parsedValue(basic, unnamed, convert))
+	case basic != nil && basic.Info()&types.IsFloat != 0:
+		content = fmt.Sprintf("strconv.FormatFloat(float64(%s), 'g', -1, %d)", target, bitSize(basic))
+		parse = fmt.Sprintf(`parsed, err := strconv.ParseFloat(%s.TrimSpace(value), %d)
+				if err != nil {
+					return
+				}
+				%s = %s`, checker.requireImport("strings"), bitSize(basic), target, parsedValue(basic, unnamed, convert))
+	case basic != nil && basic.Info()&types.IsBoolean != 0:
+		checker.fail(src, 1, 1, fmt.Sprintf("a bool binds to a checkbox: <input type=\"checkbox\" @bind=\"%s\">", strings.TrimSpace(bindExpr)), loopCtx)
+	default:
+		checker.fail(src, 1, 1, fmt.Sprintf("@bind does not support type '%s'; bind a string, number, bool, or a type implementing encoding.TextUnmarshaler", typeName), loopCtx)
+	}
+
+	return &bindCode{
+		Content: content,
+		Handler: fmt.Sprintf(`"%s": events.AdaptBindValue(func(value string) {
+				%s
+				%s.StateHasChanged()
+			})`, jsEventName, parse, receiver),
+	}
+}
+
+// boundLoopVariable returns the loop variable that expr assigns to directly (item) or through a
+// field of a non-pointer element (item.Name), or "" if expr writes through to component state.
+func boundLoopVariable(expr string, loopCtx *loopContext) string {
+	parsed, err := parser.ParseExpr(expr)
+	if err != nil {
+		return ""
+	}
+	viaField := false
+	if sel, ok := parsed.(*ast.SelectorExpr); ok {
+		parsed, viaField = sel.X, true
+	}
+	id, ok := parsed.(*ast.Ident)
+	if !ok {
+		return ""
+	}
+	for l := loopCtx; l != nil; l = l.Parent {
+		for _, v := range []struct {
+			name string
+			typ  types.Type
+		}{{l.IndexVar, l.IndexType}, {l.ValueVar, l.ValueType}} {
+			if v.name != id.Name {
+				continue
+			}
+			if _, isPointer := types.Unalias(v.typ).Underlying().(*types.Pointer); viaField && isPointer {
+				return ""
+			}
+			return v.name
+		}
+	}
+	return ""
+}
+
+// hasTextMethod reports whether t has the encoding.TextMarshaler (MarshalText() ([]byte, error))
+// or, if unmarshal is set, encoding.TextUnmarshaler (UnmarshalText([]byte) error) method.
+func hasTextMethod(t types.Type, name string, unmarshal bool) bool {
+	obj, _, _ := types.LookupFieldOrMethod(t, true, nil, name)
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return false
+	}
+	sig := fn.Type().(*types.Signature)
+	params, results := sig.Params(), sig.Results()
+	isBytes := func(t types.Type) bool {
+		slice, ok := t.Underlying().(*types.Slice)
+		return ok && types.Identical(slice.Elem(), types.Typ[types.Byte])
+	}
+	isError := func(t types.Type) bool {
+		return types.Identical(t, types.Universe.Lookup("error").Type())
+	}
+	if unmarshal {
+		return params.Len() == 1 && isBytes(params.At(0).Type()) && results.Len() == 1 && isError(results.At(0).Type())
+	}
+	return params.Len() == 0 && results.Len() == 2 && isBytes(results.At(0).Type()) && isError(results.At(1).Type())
+}
+
+// parsedValue converts the result of strconv's 64-bit parse functions to the bound type.
+func parsedValue(basic *types.Basic, unnamed bool, convert func(string) string) string {
+	if unnamed {
+		return fmt.Sprintf("%s(parsed)", basic.Name())
+	}
+	return convert("parsed")
+}
+
+// bitSize returns the size in bits of a sized numeric kind, for strconv's bitSize argument.
+// int, uint and uintptr report 0, which strconv treats as the platform int size.
+func bitSize(basic *types.Basic) int {
+	switch basic.Kind() {
+	case types.Int8, types.Uint8:
+		return 8
+	case types.Int16, types.Uint16:
+		return 16
+	case types.Int32, types.Uint32, types.Float32:
+		return 32
+	case types.Int64, types.Uint64, types.Float64:
+		return 64
+	}
+	return 0
+}
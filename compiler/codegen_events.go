@@ -0,0 +1,138 @@
+package compiler
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+)
+
+// compileComponentEvent compiles an @onname="Handler" attribute on a component tag into the
+// struct literal entry that binds a method of the current component to the child's event
+// field (an exported field tagged nojs:"event"). The field is either a func type, which the
+// method value must be assignable to, or a runtime.EventCallback[T], which takes a func(T)
+// or func() handler and re-renders the current component after it runs.
+//
+// Like @event attributes on HTML elements, the value may also be a call with arguments,
+// @onselect="Pick(i, e)", where e is the event's payload.
+func compileComponentEvent(attrKey, value string, compInfo componentInfo, receiver string, currentComp componentInfo, htmlSource string, lineNumber int, loopCtx *loopContext) string {
+	eventName := strings.TrimPrefix(attrKey, "@")
+	if strings.Contains(eventName, ".") {
+		templateError(currentComp, htmlSource, lineNumber, fmt.Sprintf("Event modifiers are not supported on component events ('%s' on <%s>); they only apply to DOM events.", attrKey, compInfo.PascalName))
+	}
+	event, ok := compInfo.Schema.Events[eventName]
+	if !ok {
+		msg := fmt.Sprintf("Component '%s' has no event '%s'. Available events: [%s]", compInfo.PascalName, attrKey, strings.Join(componentEventNames(compInfo), ", "))
+		if prop, isProp := compInfo.Schema.Props[eventName]; isProp {
+			msg += fmt.Sprintf("\n'%s' is a prop: tag it `nojs:\"event\"` to bind it with '%s', or set it as %s=\"...\"", prop.Name, attrKey, prop.Name)
+		}
+		templateError(currentComp, htmlSource, lineNumber, msg)
+	}
+
+	checker := currentComp.Expr
+	fieldType := componentFieldType(compInfo, event.Name)
+	payload, isCallback := eventCallbackArg(fieldType, event.GoType)
+	expected := event.GoType
+	if fieldType != nil {
+		expected = checker.typeString(fieldType)
+	}
+
+	var handler string
+	if !strings.Contains(value, "(") {
+		// @onclose="HandleClose": a method of the current component
+		methodName := strings.TrimSpace(value)
+		if _, exists := currentComp.Schema.Methods[methodName]; !exists {
+			templateError(currentComp, htmlSource, lineNumber, fmt.Sprintf("Handler method '%s' for '%s' on <%s> not found on component '%s'. Available methods: %s",
+				methodName, attrKey, compInfo.PascalName, currentComp.PascalName, getAvailableMethodNames(currentComp.Schema.Methods)))
+		}
+		methodType := checker.members[methodName]
+		handler = fmt.Sprintf("%s.%s", receiver, methodName)
+
+		switch {
+		case fieldType == nil:
+			// The child's package could not be type-checked; leave the check to the Go compiler
+		case !isCallback:
+			if !types.AssignableTo(methodType, fieldType) {
+				templateError(currentComp, htmlSource, lineNumber, fmt.Sprintf("Handler '%s' has type '%s', but event '%s' of <%s> expects '%s'",
+					methodName, checker.typeString(methodType), attrKey, compInfo.PascalName, expected))
+			}
+		case types.AssignableTo(methodType, types.NewSignatureType(nil, nil, nil, types.NewTuple(types.NewParam(0, nil, "", payload)), nil, false)):
+			// func(T)
+		case types.AssignableTo(methodType, types.NewSignatureType(nil, nil, nil, nil, nil, false)):
+			// func(): ignore the payload
+			handler = fmt.Sprintf("func(%s) { %s.%s() }", checker.typeCode(payload), receiver, methodName)
+		default:
+			templateError(currentComp, htmlSource, lineNumber, fmt.Sprintf("Handler '%s' has type '%s', but event '%s' of <%s> (%s) expects 'func(%s)' or 'func()'",
+				methodName, checker.typeString(methodType), attrKey, compInfo.PascalName, expected, checker.typeString(payload)))
+		}
+	} else {
+		// @onselect="Pick(i, e)": a call compiled into a closure taking the payload as e
+		if fieldType == nil {
+			templateError(currentComp, htmlSource, lineNumber, fmt.Sprintf("Cannot compile the handler call '%s': the type of event '%s' of <%s> is unknown. Bind a method name instead.", value, attrKey, compInfo.PascalName))
+		}
+		var params []types.Type
+		if isCallback {
+			params = append(params, payload)
+		} else {
+			sig := fieldType.Underlying().(*types.Signature)
+			if sig.Results().Len() > 0 || sig.Params().Len() > 1 || sig.Variadic() {
+				templateError(currentComp, htmlSource, lineNumber, fmt.Sprintf("Event '%s' of <%s> has type '%s'; handler calls need an event of type func() or func(T). Bind a method name instead.",
+					attrKey, compInfo.PascalName, expected))
+			}
+			if sig.Params().Len() == 1 {
+				params = append(params, sig.Params().At(0).Type())
+			}
+		}
+
+		src := checker.locateIn(attrKey+`="`, value, `"`, lineNumber)
+		var locals []*types.Var
+		if len(params) == 1 {
+			locals = append(locals, types.NewVar(token.NoPos, checker.pkg.Types, "e", params[0]))
+		}
+		code, _, info := checker.typeCheck(src, loopCtx, locals)
+
+		param := ""
+		if len(params) == 1 {
+			param = checker.typeCode(params[0])
+			for _, obj := range info.Uses {
+				if obj == locals[0] {
+					param = "e " + param
+				}
+			}
+		}
+		handler = fmt.Sprintf("func(%s) { %s }", param, code)
+	}
+
+	if isCallback {
+		handler = fmt.Sprintf("runtime.NewEventCallback(%s, %s)", receiver, handler)
+	}
+	return fmt.Sprintf("%s: %s", event.Name, handler)
+}
+
+// eventCallbackArg returns T if an event field is a runtime.EventCallback[T]. When the field's
+// type is unknown (nil), goType from the component's source decides and T is nil.
+func eventCallbackArg(fieldType types.Type, goType string) (types.Type, bool) {
+	if fieldType == nil {
+		return nil, strings.Contains(goType, "EventCallback[")
+	}
+	named, ok := types.Unalias(fieldType).(*types.Named)
+	if !ok || named.TypeArgs().Len() != 1 {
+		return nil, false
+	}
+	obj := named.Obj()
+	if obj.Pkg() == nil || obj.Pkg().Path() != generatedFileImports["runtime"] || obj.Name() != "EventCallback" {
+		return nil, false
+	}
+	return named.TypeArgs().At(0), true
+}
+
+// componentEventNames returns the @onname attributes a component accepts, sorted.
+func componentEventNames(comp componentInfo) []string {
+	names := make([]string, 0, len(comp.Schema.Events))
+	for lower := range comp.Schema.Events {
+		names = append(names, "@"+lower)
+	}
+	sort.Strings(names)
+	return names
+}
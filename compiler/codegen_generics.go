@@ -0,0 +1,175 @@
+package compiler
+
+import (
+	"fmt"
+	"go/types"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// instantiateComponent instantiates a generic child component for one component tag and
+// returns its type arguments as Go code ("[string]"), or "" for a component that is not
+// generic. A type argument is written on the tag (type:T="string") or inferred from the props
+// bound to a {…} value, as Go infers the type arguments of a function call. The instance is
+// stored in compInfo.Instance so prop, event and slot types are those of the instance.
+func instantiateComponent(n *html.Node, compInfo *componentInfo, currentComp componentInfo, htmlSource string, lineNumber int, loopCtx *loopContext) string {
+	checker := currentComp.Expr
+	generic := componentType(*compInfo)
+	if generic == nil || generic.TypeParams().Len() == 0 {
+		for _, attr := range n.Attr {
+			if name, ok := strings.CutPrefix(attr.Key, "type:"); ok {
+				templateError(currentComp, htmlSource, lineNumber, fmt.Sprintf("Component '%s' has no type parameters; remove type:%s", compInfo.PascalName, name))
+			}
+		}
+		return ""
+	}
+
+	params := generic.TypeParams()
+	args := make([]types.Type, params.Len())
+
+	// Type arguments written on the tag
+	for _, attr := range n.Attr {
+		name, ok := strings.CutPrefix(attr.Key, "type:")
+		if !ok {
+			continue
+		}
+		index := -1
+		for i := 0; i < params.Len(); i++ {
+			if strings.EqualFold(params.At(i).Obj().Name(), name) {
+				index = i
+			}
+		}
+		if index < 0 {
+			// The HTML parser lowercases attribute names; show the one written in the template
+			if m := regexp.MustCompile(`(?i)\btype:(` + regexp.QuoteMeta(name) + `)\s*=`).FindStringSubmatch(htmlSource); m != nil {
+				name = m[1]
+			}
+			templateError(currentComp, htmlSource, lineNumber, fmt.Sprintf("Component '%s' has no type parameter '%s'. Type parameters: [%s]",
+				compInfo.PascalName, name, strings.Join(compInfo.Schema.TypeParams, ", ")))
+		}
+		args[index] = checker.compileType(attr.Val, lineNumber, loopCtx)
+	}
+
+	// Type arguments inferred from the props bound to {…} values. Like Go, untyped constants
+	// ({2}) only decide a type parameter that no typed value decided, using their default type.
+	type boundProp struct {
+		name               string
+		fieldType, argType types.Type
+	}
+	var typed, untyped []boundProp
+	for _, attr := range n.Attr {
+		prop, ok := compInfo.Schema.Props[attr.Key]
+		if !ok {
+			continue
+		}
+		segments := scanBindings(attr.Val)
+		if len(segments) != 1 || !segments[0].IsBinding {
+			continue
+		}
+		_, argType := checker.compileBinding(segments[0].Text, lineNumber, loopCtx)
+		bound := boundProp{prop.Name, componentFieldType(*compInfo, prop.Name), argType}
+		if isUntypedType(argType) {
+			untyped = append(untyped, bound)
+		} else {
+			typed = append(typed, bound)
+		}
+	}
+	for _, bound := range append(typed, untyped...) {
+		if tp, ok := bound.fieldType.(*types.TypeParam); ok && isUntypedType(bound.argType) && args[tp.Index()] != nil {
+			continue // Checked against the inferred type when the prop is converted
+		}
+		if err := checker.unifyTypeParams(bound.fieldType, types.Default(bound.argType), params, args); err != nil {
+			templateError(currentComp, htmlSource, lineNumber, fmt.Sprintf("Cannot infer the type arguments of component '%s' from prop '%s': %v", compInfo.PascalName, bound.name, err))
+		}
+	}
+
+	codes := make([]string, len(args))
+	for i, arg := range args {
+		if arg == nil {
+			name := params.At(i).Obj().Name()
+			templateError(currentComp, htmlSource, lineNumber, fmt.Sprintf("Cannot infer type parameter '%s' of component '%s'; bind a prop that uses it or write it on the tag: type:%s=\"...\"",
+				name, compInfo.PascalName, name))
+		}
+		codes[i] = checker.typeCode(arg)
+	}
+
+	instance, err := types.Instantiate(nil, generic, args, true)
+	if err != nil {
+		templateError(currentComp, htmlSource, lineNumber, fmt.Sprintf("Cannot instantiate component '%s' with [%s]: %v", compInfo.PascalName, strings.Join(codes, ", "), err))
+	}
+	compInfo.Instance = instance.(*types.Named)
+	return "[" + strings.Join(codes, ", ") + "]"
+}
+
+// unifyTypeParams matches the type of a generic component's field against the type of the
+// value bound to it, recording the type argument of every type parameter the field type
+// uses. It fails when a type parameter would stand for two different types.
+func (e *exprChecker) unifyTypeParams(param, arg types.Type, params *types.TypeParamList, args []types.Type) error {
+	unify := func(p, a types.Type) error {
+		return e.unifyTypeParams(p, a, params, args)
+	}
+	switch p := param.(type) {
+	case *types.TypeParam:
+		for i := 0; i < params.Len(); i++ {
+			if params.At(i) != p {
+				continue
+			}
+			if args[i] == nil {
+				args[i] = arg
+			} else if !types.Identical(args[i], arg) {
+				return fmt.Errorf("type parameter %s is both '%s' and '%s'", p.Obj().Name(), e.typeString(args[i]), e.typeString(arg))
+			}
+		}
+	case *types.Named:
+		a, ok := arg.(*types.Named)
+		if !ok || a.Origin() != p.Origin() || a.TypeArgs().Len() != p.TypeArgs().Len() {
+			return nil
+		}
+		for i := 0; i < p.TypeArgs().Len(); i++ {
+			if err := unify(p.TypeArgs().At(i), a.TypeArgs().At(i)); err != nil {
+				return err
+			}
+		}
+	case *types.Pointer:
+		if a, ok := arg.Underlying().(*types.Pointer); ok {
+			return unify(p.Elem(), a.Elem())
+		}
+	case *types.Slice:
+		if a, ok := arg.Underlying().(*types.Slice); ok {
+			return unify(p.Elem(), a.Elem())
+		}
+	case *types.Array:
+		if a, ok := arg.Underlying().(*types.Array); ok {
+			return unify(p.Elem(), a.Elem())
+		}
+	case *types.Chan:
+		if a, ok := arg.Underlying().(*types.Chan); ok {
+			return unify(p.Elem(), a.Elem())
+		}
+	case *types.Map:
+		if a, ok := arg.Underlying().(*types.Map); ok {
+			if err := unify(p.Key(), a.Key()); err != nil {
+				return err
+			}
+			return unify(p.Elem(), a.Elem())
+		}
+	case *types.Signature:
+		a, ok := arg.Underlying().(*types.Signature)
+		if !ok || a.Params().Len() != p.Params().Len() || a.Results().Len() != p.Results().Len() {
+			return nil
+		}
+		for i := 0; i < p.Params().Len(); i++ {
+			if err := unify(p.Params().At(i).Type(), a.Params().At(i).Type()); err != nil {
+				return err
+			}
+		}
+		for i := 0; i < p.Results().Len(); i++ {
+			if err := unify(p.Results().At(i).Type(), a.Results().At(i).Type()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -2,133 +2,89 @@ package compiler
 
 import (
 	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/types"
 	"os"
-	"path/filepath"
+	"slices"
 	"strings"
 
 	"golang.org/x/net/html"
 )
 
 // generateForLoopCode generates Go for...range loop code for list rendering.
-func generateForLoopCode(n *html.Node, receiver string, componentMap map[string]componentInfo, currentComp componentInfo, htmlSource string, opts compileOptions) string {
+// parentLoop is the enclosing loop context, or nil for a top-level loop.
+//
+// The range target is a type-checked Go expression: a slice, array, string, map (iterated in
+// sorted key order so renders are deterministic), integer, or iter.Seq / iter.Seq2 function.
+func generateForLoopCode(n *html.Node, receiver string, componentMap map[string]componentInfo, currentComp componentInfo, htmlSource string, opts compileOptions, parentLoop *loopContext) string {
 	// Extract loop variables from data attributes
-	indexVar := ""
-	valueVar := ""
-	rangeExpr := ""
-	trackByExpr := ""
-
-	for _, attr := range n.Attr {
-		switch attr.Key {
-		case "data-index":
-			indexVar = attr.Val
-		case "data-value":
-			valueVar = attr.Val
-		case "data-range":
-			rangeExpr = attr.Val
-		case "data-trackby":
-			trackByExpr = attr.Val
-		}
-	}
+	indexVar := getAttr(n, "data-index")
+	valueVar := getAttr(n, "data-value")
+	rangeExpr := getAttr(n, "data-range")
+	trackByExpr := getAttr(n, "data-trackby")
 
 	// Validate that we have the required attributes
-	if valueVar == "" || rangeExpr == "" || trackByExpr == "" {
+	if valueVar == "" || rangeExpr == "" {
 		fmt.Fprintf(os.Stderr, "Compilation Error in %s: Invalid {@for} directive - missing required attributes.\n", currentComp.Path)
 		os.Exit(1)
 	}
 
-	// Validate that the range expression exists on the component
-	propDesc, exists := currentComp.Schema.Props[strings.ToLower(rangeExpr)]
-	if !exists {
-		// Also check state fields
-		propDesc, exists = currentComp.Schema.State[strings.ToLower(rangeExpr)]
-	}
-	if !exists {
-		allFields := append(getAvailableFieldNames(currentComp.Schema.Props), getAvailableFieldNames(currentComp.Schema.State)...)
-		availableFields := strings.Join(allFields, ", ")
-		fmt.Fprintf(os.Stderr, "Compilation Error in %s: Field '%s' not found on component '%s'. Available fields: [%s]\n",
-			currentComp.Path, rangeExpr, currentComp.PascalName, availableFields)
-		os.Exit(1)
-	}
+	checker := currentComp.Expr
+	rangeSrc := checker.locateIn("range ", rangeExpr, "", directiveLine(n))
+	rangeCode, rangeType := checker.check(rangeSrc, parentLoop)
 
-	// Validate that the field is a slice type
-	if !strings.HasPrefix(propDesc.GoType, "[]") {
-		fmt.Fprintf(os.Stderr, "Compilation Error in %s: Field '%s' must be a slice or array type for {@for} directive, found type '%s'.\n",
-			currentComp.Path, rangeExpr, propDesc.GoType)
+	// Determine the loop variable types from the range target, as the Go spec does
+	form := classifyRange(rangeType)
+	twoVars := indexVar != ""
+	switch {
+	case form.kind == rangeUnsupported:
+		checker.fail(rangeSrc, 1, 1, fmt.Sprintf("cannot range over %s (type '%s'); {@for} supports slices, arrays, strings, maps, integers and iter.Seq/iter.Seq2 functions",
+			strings.TrimSpace(rangeExpr), checker.typeString(rangeType)), parentLoop)
+	case twoVars && form.second == nil:
+		checker.fail(rangeSrc, 1, 1, fmt.Sprintf("range over %s permits only one iteration variable: {@for %s := range %s}",
+			checker.typeString(rangeType), valueVar, strings.TrimSpace(rangeExpr)), parentLoop)
+	case !twoVars && form.second != nil:
+		fmt.Fprintf(os.Stderr, "Compilation Error in %s:%d: Invalid {@for} syntax: ranging over '%s' requires both index and value variables.\n"+
+			"  Correct syntax: {@for index, value := range %s trackBy value.Field}\n"+
+			"  To ignore the index, use underscore: {@for _, value := range %s trackBy value.Field}\n",
+			currentComp.Path, rangeSrc.Line, checker.typeString(rangeType), strings.TrimSpace(rangeExpr), strings.TrimSpace(rangeExpr))
 		os.Exit(1)
 	}
 
-	// Validate trackBy expression
-	// Supports two formats:
-	// 1. Bare variable: "id" (for primitive types like string, int)
-	// 2. Dot-notation: "user.ID" (for struct fields)
-	trackByParts := strings.Split(trackByExpr, ".")
-
-	var trackByVar, trackByField string
-
-	if len(trackByParts) == 1 {
-		// Bare variable format: trackBy id
-		trackByVar = trackByParts[0]
+	// Create loop context for child nodes. The variable types let expressions in the
+	// loop body (e.g. {i + 1} or {item.Name}) be type-checked.
+	loopCtx := &loopContext{
+		IndexVar: indexVar,
+		ValueVar: valueVar,
+		Parent:   parentLoop,
+	}
+	if twoVars {
+		loopCtx.IndexType, loopCtx.ValueType = form.first, form.second
+	} else {
+		loopCtx.ValueType = form.first
+	}
 
-		// Verify the variable matches the loop value variable
-		if trackByVar != valueVar {
-			fmt.Fprintf(os.Stderr, "Compilation Error in %s: trackBy variable '%s' must match the loop value variable '%s'.\n"+
-				"  For bare variables, use: trackBy %s\n"+
-				"  For struct fields, use: trackBy %s.FieldName\n",
-				currentComp.Path, trackByVar, valueVar, valueVar, valueVar)
+	// Validate trackBy expression: a comparable Go expression over the loop variables.
+	// An integer range is keyed by the integer itself when trackBy is omitted.
+	keyCode := valueVar
+	if trackByExpr == "" {
+		if form.kind != rangeInt {
+			fmt.Fprintf(os.Stderr, "Compilation Error in %s:%d: {@for} over '%s' requires a trackBy clause.\n"+
+				"  Example: {@for %s := range %s trackBy %s}\n",
+				currentComp.Path, rangeSrc.Line, strings.TrimSpace(rangeExpr), strings.Join(nonEmpty(indexVar, valueVar), ", "), strings.TrimSpace(rangeExpr), valueVar)
 			os.Exit(1)
 		}
-	} else if len(trackByParts) >= 2 {
-		// Dot-notation format: trackBy user.ID (or nested: user.Profile.ID)
-		trackByVar = trackByParts[0]
-		trackByField = strings.Join(trackByParts[1:], ".") // Handle nested fields like user.Profile.ID
-
-		// Verify the variable matches the loop value variable
-		if trackByVar != valueVar {
-			fmt.Fprintf(os.Stderr, "Compilation Error in %s: trackBy variable '%s' must match the loop value variable '%s'.\n"+
-				"  For bare variables, use: trackBy %s\n"+
-				"  For struct fields, use: trackBy %s.FieldName\n",
-				currentComp.Path, trackByVar, valueVar, valueVar, valueVar)
-			os.Exit(1)
+	} else {
+		trackBySrc := checker.locateIn("trackBy ", trackByExpr, "", directiveLine(n))
+		var keyType types.Type
+		keyCode, keyType = checker.check(trackBySrc, loopCtx)
+		if !types.Comparable(keyType) {
+			checker.fail(trackBySrc, 1, 1, fmt.Sprintf("trackBy key must be comparable, found type '%s'", checker.typeString(keyType)), loopCtx)
 		}
-
-		// Extract element type from slice type: "[]User" -> "User"
-		elementType := strings.TrimPrefix(propDesc.GoType, "[]")
-
-		// Validate that the trackBy field exists on the element type
-		// We need to inspect the element type's struct definition
-		goFilePath := filepath.Join(filepath.Dir(currentComp.Path), strings.ToLower(currentComp.PascalName)+".go")
-		elementSchema, err := inspectStructInFile(goFilePath, elementType)
-		if err != nil {
-			// If we can't find the struct in the component file, it might be defined elsewhere
-			// For now, we'll skip validation with a warning
-			fmt.Fprintf(os.Stderr, "Warning in %s: Could not validate trackBy field '%s' on type '%s': %v\n",
-				currentComp.Path, trackByField, elementType, err)
-		} else {
-			// Check if the trackBy field exists on the element type (case-insensitive lookup)
-			// For nested fields, only validate the first part
-			firstField := strings.Split(trackByField, ".")[0]
-			propDescField, exists := elementSchema.Props[strings.ToLower(firstField)]
-			if !exists {
-				availableFields := strings.Join(getAvailableFieldNames(elementSchema.Props), ", ")
-				fmt.Fprintf(os.Stderr, "Compilation Error in %s: trackBy identifier '%s' not found on type '%s'.\nAvailable fields: [%s]\n",
-					currentComp.Path, trackByField, elementType, availableFields)
-				os.Exit(1)
-			}
-
-			// Verify exact case match - the field name in the template must match the actual struct field
-			if propDescField.Name != firstField {
-				availableFields := strings.Join(getAvailableFieldNames(elementSchema.Props), ", ")
-				fmt.Fprintf(os.Stderr, "Compilation Error in %s: trackBy identifier '%s' not found on type '%s'.\nAvailable fields: [%s]\n",
-					currentComp.Path, trackByField, elementType, availableFields)
-				os.Exit(1)
-			}
+		if !referencesIdent(trackByExpr, indexVar, valueVar) {
+			checker.fail(trackBySrc, 1, 1, fmt.Sprintf("trackBy key must depend on the loop variables (%s)", strings.Join(nonEmpty(strings.TrimPrefix(indexVar, "_"), valueVar), ", ")), loopCtx)
 		}
-	} else {
-		fmt.Fprintf(os.Stderr, "Compilation Error in %s: trackBy expression '%s' must be in one of these formats:\n"+
-			"  - Bare variable: trackBy %s (for primitive types)\n"+
-			"  - Struct field: trackBy %s.FieldName (for struct types)\n",
-			currentComp.Path, trackByExpr, valueVar, valueVar)
-		os.Exit(1)
 	}
 
 	// Generate the loop body - collect child VNodes
@@ -138,59 +94,222 @@ func generateForLoopCode(n *html.Node, receiver string, componentMap map[string]
 	code.WriteString("func() []*vdom.VNode {\n")
 	fmt.Fprintf(&code, "\tvar %s_nodes []*vdom.VNode\n", valueVar)
 
-	// Add development warning if enabled
-	if opts.DevMode {
-		code.WriteString("\t// Development warning for empty slice\n")
-		fmt.Fprintf(&code, "\tif len(%s.%s) == 0 {\n", receiver, propDesc.Name)
-		fmt.Fprintf(&code, "\t\tconsole.Warn(\"[@for] Rendering empty list for '%s' in %s. Consider using {@if} to handle empty state.\")\n",
-			propDesc.Name, currentComp.PascalName)
+	// Find the optional {@empty} branch
+	var emptyNode *html.Node
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && c.Data == "go-empty" {
+			emptyNode = c
+		}
+	}
+
+	// The range expression is evaluated once per render, whatever reads it
+	rangeVar := valueVar + "_range"
+	fmt.Fprintf(&code, "\t%s := %s\n", rangeVar, rangeCode)
+
+	// Add development warning if enabled (a loop with an {@empty} branch handles the empty case itself)
+	if opts.DevMode && form.hasLen && emptyNode == nil {
+		code.WriteString("\t// Development warning for empty collection\n")
+		fmt.Fprintf(&code, "\tif len(%s) == 0 {\n", rangeVar)
+		fmt.Fprintf(&code, "\t\tconsole.Warn(\"[@for] Rendering empty list for '%s' in %s. Consider adding an {@empty} branch.\")\n",
+			strings.ReplaceAll(strings.TrimSpace(rangeExpr), `"`, `\"`), currentComp.PascalName)
 		code.WriteString("\t}\n\n")
 	}
 
+	// Track whether the loop ran at all, for the {@empty} branch
+	emptyVar := valueVar + "_empty"
+	if emptyNode != nil {
+		fmt.Fprintf(&code, "\t%s := true\n", emptyVar)
+	}
+
 	// Generate the for loop
-	fmt.Fprintf(&code, "\tfor %s, %s := range %s.%s {\n", indexVar, valueVar, receiver, propDesc.Name)
+	switch {
+	case form.kind == rangeMap:
+		// Maps are iterated in sorted key order so every render produces the same child order
+		mapsPkg, slicesPkg := checker.requireImport("maps"), checker.requireImport("slices")
+		keyVar := indexVar
+		if keyVar == "_" {
+			keyVar = valueVar + "_mapkey"
+		}
+		fmt.Fprintf(&code, "\tfor _, %s := range %s.Sorted(%s.Keys(%s)) {\n", keyVar, slicesPkg, mapsPkg, rangeVar)
+		if valueVar != "_" {
+			fmt.Fprintf(&code, "\t\t%s := %s[%s]\n", valueVar, rangeVar, keyVar)
+		}
+	case twoVars:
+		fmt.Fprintf(&code, "\tfor %s, %s := range %s {\n", indexVar, valueVar, rangeVar)
+	default:
+		fmt.Fprintf(&code, "\tfor %s := range %s {\n", valueVar, rangeVar)
+	}
 
-	// Create loop context for child nodes
-	loopCtx := &loopContext{
-		IndexVar: indexVar,
-		ValueVar: valueVar,
+	// The trackBy key is stamped on every VNode the iteration produces so the
+	// VDOM reconciler can match list items by identity instead of position.
+	keyVarName := fmt.Sprintf("%s_key", valueVar)
+	fmt.Fprintf(&code, "\t\t%s := %s\n", keyVarName, keyCode)
+	if emptyNode != nil {
+		fmt.Fprintf(&code, "\t\t%s = false\n", emptyVar)
 	}
 
-	// Generate code for each child node in the loop body
+	// Generate code for each child node in the loop body; the {@empty} branch is rendered after the loop
+	childCodes := generateChildCodes(n, emptyNode, receiver, componentMap, currentComp, htmlSource, opts, loopCtx)
+
 	// Use a counter to ensure unique variable names for each child element
-	childCounter := 0
-	for c := n.FirstChild; c != nil; c = c.NextSibling {
-		if c.Type == html.ElementNode || (c.Type == html.TextNode && strings.TrimSpace(c.Data) != "") {
-			childCode := generateNodeCode(c, receiver, componentMap, currentComp, htmlSource, opts, loopCtx)
-			if childCode != "" {
-				childVarName := fmt.Sprintf("%s_child_%d", valueVar, childCounter)
-				fmt.Fprintf(&code, "\t\t%s := %s\n", childVarName, childCode)
-				fmt.Fprintf(&code, "\t\tif %s != nil {\n", childVarName)
-				fmt.Fprintf(&code, "\t\t\t%s_nodes = append(%s_nodes, %s)\n", valueVar, valueVar, childVarName)
-				code.WriteString("\t\t}\n")
-				childCounter++
-			}
+	for childCounter, child := range childCodes {
+		childVarName := fmt.Sprintf("%s_child_%d", valueVar, childCounter)
+		fmt.Fprintf(&code, "\t\t%s := %s\n", childVarName, child.Code)
+		if child.List {
+			// A nested directive renders any number of nodes. Each is keyed by the trackBy
+			// key, its position in the body and its own key (a nested loop's trackBy key) or
+			// its position in the directive's output. The key goes on a copy: slot content is
+			// kept across renders and may appear in every iteration, so its own key must not change.
+			fmt.Fprintf(&code, "\t\tfor i, node := range %s {\n", childVarName)
+			code.WriteString("\t\t\tif node == nil {\n\t\t\t\tcontinue\n\t\t\t}\n")
+			code.WriteString("\t\t\tkeyed := *node\n")
+			code.WriteString("\t\t\tif keyed.Key == nil {\n\t\t\t\tkeyed.Key = i\n\t\t\t}\n")
+			fmt.Fprintf(&code, "\t\t\tkeyed.Key = [3]any{%s, %d, keyed.Key}\n", keyVarName, childCounter)
+			fmt.Fprintf(&code, "\t\t\t%s_nodes = append(%s_nodes, &keyed)\n", valueVar, valueVar)
+			code.WriteString("\t\t}\n")
+			continue
 		}
+		fmt.Fprintf(&code, "\t\tif %s != nil {\n", childVarName)
+		if len(childCodes) == 1 {
+			fmt.Fprintf(&code, "\t\t\t%s.Key = %s\n", childVarName, keyVarName)
+		} else {
+			// Several siblings per iteration: pair the trackBy key with the sibling
+			// position so each node still has a unique key.
+			fmt.Fprintf(&code, "\t\t\t%s.Key = [2]any{%s, %d}\n", childVarName, keyVarName, childCounter)
+		}
+		fmt.Fprintf(&code, "\t\t\t%s_nodes = append(%s_nodes, %s)\n", valueVar, valueVar, childVarName)
+		code.WriteString("\t\t}\n")
+	}
+	if len(childCodes) == 0 {
+		fmt.Fprintf(&code, "\t\t_ = %s\n", keyVarName)
 	}
 
 	code.WriteString("\t}\n")
+
+	// {@empty} branch: rendered when the loop had no iterations. Its nodes get keys of their
+	// own so the keyed reconciler never matches them with list items. As in the loop body,
+	// the key goes on a copy, since the branch may render slot content the caller owns.
+	if emptyNode != nil {
+		emptyCodes := generateChildCodes(emptyNode, nil, receiver, componentMap, currentComp, htmlSource, opts, parentLoop)
+		if len(emptyCodes) > 0 {
+			fmt.Fprintf(&code, "\tif %s {\n", emptyVar)
+			fmt.Fprintf(&code, "\t\tfor i, node := range %s {\n", joinNodeCodes(emptyCodes))
+			code.WriteString("\t\t\tif node != nil {\n")
+			code.WriteString("\t\t\t\tkeyed := *node\n")
+			code.WriteString("\t\t\t\tkeyed.Key = [2]any{\"@empty\", i}\n")
+			fmt.Fprintf(&code, "\t\t\t\t%s_nodes = append(%s_nodes, &keyed)\n", valueVar, valueVar)
+			code.WriteString("\t\t\t}\n\t\t}\n")
+			code.WriteString("\t}\n")
+		} else {
+			fmt.Fprintf(&code, "\t_ = %s\n", emptyVar)
+		}
+	}
+
 	fmt.Fprintf(&code, "\treturn %s_nodes\n", valueVar)
 	code.WriteString("}()")
 
 	return code.String()
 }
 
-// extractTrackByFromParent walks up the node tree to find a go-for parent and extracts its trackBy expression.
-func extractTrackByFromParent(n *html.Node) string {
-	for p := n.Parent; p != nil; p = p.Parent {
-		if p.Type == html.ElementNode && p.Data == "go-for" {
-			// Found the parent loop node, extract trackBy
-			for _, attr := range p.Attr {
-				if attr.Key == "data-trackby" {
-					return attr.Val
-				}
+// rangeKind classifies the target of a {@for} range.
+type rangeKind int
+
+const (
+	rangeUnsupported rangeKind = iota
+	rangeIndexed               // Slice, array, pointer to array or string
+	rangeMap
+	rangeInt
+	rangeFunc // iter.Seq / iter.Seq2 style push iterator
+)
+
+// rangeForm describes the iteration variables a range target produces.
+type rangeForm struct {
+	kind   rangeKind
+	first  types.Type // Type of the first iteration variable
+	second types.Type // Type of the second iteration variable, or nil if only one is permitted
+	hasLen bool       // Whether len() applies (used for the dev-mode empty warning)
+}
+
+// classifyRange determines how a value of type t is ranged over, following the Go spec.
+func classifyRange(t types.Type) rangeForm {
+	if t == nil {
+		return rangeForm{}
+	}
+	intType := types.Typ[types.Int]
+	switch under := t.Underlying().(type) {
+	case *types.Slice:
+		return rangeForm{kind: rangeIndexed, first: intType, second: under.Elem(), hasLen: true}
+	case *types.Array:
+		return rangeForm{kind: rangeIndexed, first: intType, second: under.Elem(), hasLen: true}
+	case *types.Pointer:
+		if arr, ok := under.Elem().Underlying().(*types.Array); ok {
+			return rangeForm{kind: rangeIndexed, first: intType, second: arr.Elem(), hasLen: true}
+		}
+	case *types.Map:
+		if !isOrderedType(under.Key()) {
+			return rangeForm{}
+		}
+		return rangeForm{kind: rangeMap, first: under.Key(), second: under.Elem(), hasLen: true}
+	case *types.Basic:
+		switch {
+		case under.Info()&types.IsString != 0:
+			return rangeForm{kind: rangeIndexed, first: intType, second: types.Universe.Lookup("rune").Type(), hasLen: true}
+		case under.Info()&types.IsInteger != 0:
+			// An untyped constant such as 10 ranges as int
+			if under.Info()&types.IsUntyped != 0 {
+				return rangeForm{kind: rangeInt, first: intType}
 			}
+			return rangeForm{kind: rangeInt, first: t}
+		}
+	case *types.Signature:
+		// func(yield func(V) bool) or func(yield func(K, V) bool)
+		if under.Params().Len() != 1 || under.Results().Len() != 0 {
+			return rangeForm{}
+		}
+		yield, ok := under.Params().At(0).Type().Underlying().(*types.Signature)
+		if !ok || yield.Results().Len() != 1 || !isBoolType(yield.Results().At(0).Type()) {
+			return rangeForm{}
+		}
+		switch yield.Params().Len() {
+		case 1:
+			return rangeForm{kind: rangeFunc, first: yield.Params().At(0).Type()}
+		case 2:
+			return rangeForm{kind: rangeFunc, first: yield.Params().At(0).Type(), second: yield.Params().At(1).Type()}
+		}
+	}
+	return rangeForm{}
+}
+
+// isOrderedType reports whether t supports < (integers, floats and strings), as required to
+// sort map keys with slices.Sorted.
+func isOrderedType(t types.Type) bool {
+	basic, ok := t.Underlying().(*types.Basic)
+	return ok && basic.Info()&types.IsOrdered != 0
+}
+
+// referencesIdent reports whether the Go expression expr mentions any of the given identifiers.
+func referencesIdent(expr string, names ...string) bool {
+	parsed, err := parser.ParseExpr(expr)
+	if err != nil {
+		return false
+	}
+	found := false
+	ast.Inspect(parsed, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok && id.Name != "_" && slices.Contains(names, id.Name) {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// nonEmpty returns the non-empty strings among values.
+func nonEmpty(values ...string) []string {
+	var result []string
+	for _, v := range values {
+		if v != "" {
+			result = append(result, v)
 		}
 	}
-	return ""
+	return result
 }
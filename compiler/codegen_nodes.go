@@ -14,7 +14,7 @@ import (
 // loopCtx can be nil if not inside a loop.
 func generateNodeCode(n *html.Node, receiver string, componentMap map[string]componentInfo, currentComp componentInfo, htmlSource string, opts compileOptions, loopCtx *loopContext) string {
 	if n.Type == html.TextNode {
-		content := strings.TrimSpace(n.Data)
+		content := renderedText(n, componentMap)
 		if content == "" {
 			return ""
 		}
@@ -39,9 +39,27 @@ func generateNodeCode(n *html.Node, receiver string, componentMap map[string]com
 			return ""
 		}
 
+		// 0.25. Handle switch placeholder nodes
+		if tagName == "go-switch" {
+			return generateSwitchCode(n, receiver, componentMap, currentComp, htmlSource, opts, loopCtx)
+		}
+		if tagName == "go-case" || tagName == "go-default" {
+			// These are handled within go-switch processing
+			return ""
+		}
+
 		// 0.5. Handle for-loop placeholder nodes
 		if tagName == "go-for" {
-			return generateForLoopCode(n, receiver, componentMap, currentComp, htmlSource, opts)
+			return generateForLoopCode(n, receiver, componentMap, currentComp, htmlSource, opts, loopCtx)
+		}
+		if tagName == "go-empty" {
+			// Handled within go-for processing
+			return ""
+		}
+		if tagName == "go-let" {
+			lineNumber := estimateLineNumber(htmlSource, fmt.Sprintf("let:%s", getAttr(n, "data-var")))
+			templateError(currentComp, htmlSource, lineNumber, fmt.Sprintf("<%s let:%s> fills a scoped slot and must be a direct child of a component tag",
+				getAttr(n, "data-slot"), getAttr(n, "data-var")))
 		}
 
 		// 1. Handle Custom Components
@@ -51,18 +69,9 @@ func generateNodeCode(n *html.Node, receiver string, componentMap map[string]com
 			// Generate key: if inside a loop, include trackBy value for uniqueness
 			var key string
 			if loopCtx != nil {
-				// Inside a loop: use trackBy expression to ensure unique keys
-				// Extract trackBy from the parent go-for node
-				trackByExpr := extractTrackByFromParent(n)
-				if trackByExpr != "" {
-					// Use the trackBy value in the key
-					key = fmt.Sprintf(`%s_" + fmt.Sprintf("%%v", %s) + "`, compInfo.PascalName, trackByExpr)
-				} else {
-					// Fallback: use a template-wide counter so keys are unique across the whole template
-					count := opts.ComponentCounter[compInfo.PascalName]
-					opts.ComponentCounter[compInfo.PascalName]++
-					key = fmt.Sprintf("%s_%d", compInfo.PascalName, count)
-				}
+				// Inside a loop: use the innermost loop's trackBy key (the <value>_key variable
+				// declared by generateForLoopCode) to ensure unique keys
+				key = fmt.Sprintf(`%s_" + fmt.Sprintf("%%v", %s_key) + "`, compInfo.PascalName, loopCtx.ValueVar)
 			} else {
 				// Not in a loop: use a template-wide counter so keys are unique across the whole template
 				// (sibling-position would give the same key to components at the same depth in different
@@ -74,9 +83,9 @@ func generateNodeCode(n *html.Node, receiver string, componentMap map[string]com
 
 			// Determine if we need a qualified name (cross-package reference)
 			var componentRef string
-			if compInfo.PackageName != currentComp.PackageName {
-				// Cross-package: use qualified name
-				componentRef = fmt.Sprintf("%s.%s", compInfo.PackageName, compInfo.PascalName)
+			if compInfo.ImportPath != currentComp.ImportPath {
+				// Cross-package: use qualified name, under an alias if another package has the same name
+				componentRef = fmt.Sprintf("%s.%s", currentComp.Expr.importName(compInfo.ImportPath, compInfo.PackageName), compInfo.PascalName)
 			} else {
 				// Same package: use unqualified name
 				componentRef = compInfo.PascalName
@@ -96,251 +105,57 @@ func generateNodeCode(n *html.Node, receiver string, componentMap map[string]com
 		}
 
 		// 2. Handle Standard HTML Elements
-		var childrenCode []string
-		hasForLoop := false
-		hasSlotSpread := false
+		validateElementAttributes(n, currentComp, htmlSource)
+		var childrenCode []nodeCode
+		// Text that spreads a slot ({BodyContent}) or renders a node ({Row(item)}) becomes
+		// children, so the element is no longer text-only.
+		spreads := make(map[*html.Node]nodeCode)
+		if tagName != "textarea" {
+			for c := n.FirstChild; c != nil; c = c.NextSibling {
+				if spread, ok := slotSpreadCode(c, receiver, currentComp, htmlSource, loopCtx); ok {
+					spreads[c] = spread
+				}
+			}
+		}
+		// Text-only content (including bindings) becomes the node's Content, keeping its
+		// whitespace. Form controls carry their value in Content instead; a <textarea> holds
+		// only text, which is its initial value unless @bind supplies one.
+		textOnly := tagName == "textarea" || (tagName != "input" && tagName != "select" && !hasElementChildren(n) && len(spreads) == 0)
 		for c := n.FirstChild; c != nil; c = c.NextSibling {
-			// Check if this child is a go-for node
-			if c.Type == html.ElementNode && c.Data == "go-for" {
-				hasForLoop = true
+			if spread, ok := spreads[c]; ok {
+				childrenCode = append(childrenCode, spread)
+				continue
 			}
-			// Check if this is a slot spread (text node with {SlotField})
-			if c.Type == html.TextNode {
-				trimmed := strings.TrimSpace(c.Data)
-				if matches := dataBindingRegex.FindStringSubmatch(trimmed); len(matches) > 0 {
-					fieldName := matches[1]
-
-					// Check if this references the slot field
-					if currentComp.Schema.Slot != nil && strings.ToLower(fieldName) == currentComp.Schema.Slot.LowercaseName {
-						// This is a slot spread
-						hasSlotSpread = true
-
-						// Generate dev warning if enabled
-						if opts.DevMode {
-							warningCode := fmt.Sprintf("func() []*vdom.VNode {\nif len(%s.%s) == 0 {\nconsole.Warn(\"[Slot] Rendering empty content slot '%s' in component '%s'. Parent provided no content.\")\n}\nreturn %s.%s\n}()...",
-								receiver, currentComp.Schema.Slot.Name, currentComp.Schema.Slot.Name, currentComp.PascalName, receiver, currentComp.Schema.Slot.Name)
-							childrenCode = append(childrenCode, warningCode)
-						} else {
-							// No dev warning: just spread the slot
-							childrenCode = append(childrenCode, fmt.Sprintf("%s.%s...", receiver, currentComp.Schema.Slot.Name))
-						}
-						continue
-					}
-
-					// Also check regular props and state for backward compatibility
-					propDesc, ok := currentComp.Schema.Props[strings.ToLower(fieldName)]
-					if !ok {
-						propDesc, ok = currentComp.Schema.State[strings.ToLower(fieldName)]
-					}
-					if ok {
-						if propDesc.GoType == "[]*vdom.VNode" {
-							// This shouldn't happen anymore since []*vdom.VNode fields are slots
-							// But keep this as fallback
-							hasSlotSpread = true
-
-							if opts.DevMode {
-								warningCode := fmt.Sprintf("func() []*vdom.VNode {\nif len(%s.%s) == 0 {\nconsole.Warn(\"[Slot] Rendering empty content slot '%s' in component '%s'. Parent provided no content.\")\n}\nreturn %s.%s\n}()...",
-									receiver, propDesc.Name, propDesc.Name, currentComp.PascalName, receiver, propDesc.Name)
-								childrenCode = append(childrenCode, warningCode)
-							} else {
-								childrenCode = append(childrenCode, fmt.Sprintf("%s.%s...", receiver, propDesc.Name))
-							}
-							continue
-						}
-					}
-				}
+			if textOnly {
+				continue // Compiled into Content below
 			}
 			childCode := generateNodeCode(c, receiver, componentMap, currentComp, htmlSource, opts, loopCtx)
 			if childCode != "" {
-				childrenCode = append(childrenCode, childCode)
+				childrenCode = append(childrenCode, nodeCode{Code: childCode, List: isNodeList(c)})
 			}
 		}
 
-		var childrenStr string
-		if hasForLoop || hasSlotSpread {
-			// When we have a for loop or slot spread, we need to build children differently
-			// Generate code that collects all children into a slice
-			childrenStr = "func() []*vdom.VNode {\nvar allChildren []*vdom.VNode\n"
-			for _, code := range childrenCode {
-				// Check if this looks like a for loop return (starts with "func")
-				if strings.HasPrefix(strings.TrimSpace(code), "func()") {
-					// For loop or slot with dev warning returns []*vdom.VNode, need spread operator
-					if !strings.HasSuffix(code, "...") {
-						childrenStr += fmt.Sprintf("allChildren = append(allChildren, %s...)\n", code)
-					} else {
-						childrenStr += fmt.Sprintf("allChildren = append(allChildren, %s)\n", code)
-					}
-				} else if strings.HasSuffix(code, "...") {
-					// Already has spread operator (e.g., c.SlotField...)
-					childrenStr += fmt.Sprintf("allChildren = append(allChildren, %s)\n", code)
-				} else {
-					// Regular single VNode
-					childrenStr += fmt.Sprintf("allChildren = append(allChildren, %s)\n", code)
-				}
-			}
-			childrenStr += "return allChildren\n}()..."
-		} else {
-			childrenStr = strings.Join(childrenCode, ", ")
-		}
-
-		attrsMapStr := generateAttributesMap(n, receiver, currentComp, htmlSource)
-
-		switch tagName {
-		case "div":
-			return fmt.Sprintf("vdom.Div(%s, %s)", attrsMapStr, childrenStr)
-		case "ul", "ol":
-			// Handle spread operator for ul/ol elements
-			if hasForLoop || hasSlotSpread {
-				// childrenStr ends with "...", need to wrap in an IIFE that returns a slice
-				return fmt.Sprintf("vdom.NewVNode(%s, %s, %s, \"\")", strconv.Quote(tagName), attrsMapStr, strings.TrimSuffix(childrenStr, "..."))
-			} else {
-				// Regular children list
-				if childrenStr == "" {
-					return fmt.Sprintf("vdom.NewVNode(%s, %s, nil, \"\")", strconv.Quote(tagName), attrsMapStr)
-				}
-				return fmt.Sprintf("vdom.NewVNode(%s, %s, []*vdom.VNode{%s}, \"\")", strconv.Quote(tagName), attrsMapStr, childrenStr)
-			}
-		case "p", "button", "li", "h1", "h2", "h3", "h4", "h5", "h6":
-			textContent := ""
-			// Concatenate all text nodes within the element to handle multi-line text
-			var textBuilder strings.Builder
-			hasElementChildren := false
-			for c := n.FirstChild; c != nil; c = c.NextSibling {
-				switch c.Type {
-				case html.TextNode:
-					textBuilder.WriteString(c.Data)
-				case html.ElementNode:
-					hasElementChildren = true
-				}
-			}
-			fullText := textBuilder.String()
-			if fullText != "" {
-				// Handle data binding and inline conditionals in the text content
-				// Estimate line number by searching for the text in the HTML source
-				lineNum := estimateLineNumber(htmlSource, fullText)
-				textContent = generateTextExpression(fullText, receiver, currentComp, htmlSource, lineNum, loopCtx)
-			} else {
-				textContent = `""` // Default to empty string if no text node
-			}
-
-			// The VDOM helpers expect a string, so we pass the generated expression
-			switch tagName {
-			case "p":
-				// If there are child elements (e.g. <span>), render as a full VNode with children
-				// so that inline elements are not silently dropped.
-				if hasElementChildren {
-					if childrenStr == "" {
-						return fmt.Sprintf("vdom.NewVNode(\"p\", %s, nil, \"\")", attrsMapStr)
-					}
-					return fmt.Sprintf("vdom.NewVNode(\"p\", %s, []*vdom.VNode{%s}, \"\")", attrsMapStr, childrenStr)
-				}
-				return fmt.Sprintf("vdom.Paragraph(%s, %s)", textContent, attrsMapStr)
-			case "button":
-				// Always use children for button content (childrenStr already contains vdom.Text(...)
-				// for any plain-text children). Passing textContent as well would create redundant
-				// dual-storage (both Content and Children set), which breaks patch updates.
-				if childrenStr == "" {
-					return fmt.Sprintf("vdom.Button(\"\", %s)", attrsMapStr)
-				}
-				return fmt.Sprintf("vdom.Button(\"\", %s, %s)", attrsMapStr, childrenStr)
-			case "li":
-				// For li elements, check if there are child components/elements (not just text)
-				hasElementChildren := false
-				for c := n.FirstChild; c != nil; c = c.NextSibling {
-					if c.Type == html.ElementNode {
-						hasElementChildren = true
-						break
-					}
-				}
+		// @bind supplies the value (or checked state) and the handler that writes input back
+		bind := compileBind(n, receiver, currentComp, htmlSource, loopCtx)
+		attrsMapStr := generateAttributesMap(n, receiver, currentComp, htmlSource, loopCtx, bind)
 
-				if !hasElementChildren {
-					// Only text content - render with text parameter
-					return fmt.Sprintf("vdom.NewVNode(%s, %s, nil, %s)", strconv.Quote(tagName), attrsMapStr, textContent)
-				}
-
-				// Has component or element children - render them properly
-				if hasForLoop || hasSlotSpread {
-					return fmt.Sprintf("vdom.NewVNode(%s, %s, %s, \"\")", strconv.Quote(tagName), attrsMapStr, strings.TrimSuffix(childrenStr, "..."))
-				} else {
-					return fmt.Sprintf("vdom.NewVNode(%s, %s, []*vdom.VNode{%s}, \"\")", strconv.Quote(tagName), attrsMapStr, childrenStr)
-				}
-			default:
-				// For h1-h6, use NewVNode directly with text content
-				return fmt.Sprintf("vdom.NewVNode(%s, %s, nil, %s)", strconv.Quote(tagName), attrsMapStr, textContent)
+		// Every element is built the same way, with its text or its children
+		content, children := `""`, "nil"
+		switch {
+		case bind != nil && bind.Content != "":
+			content = bind.Content
+		case textOnly:
+			if text := textContent(n); strings.TrimSpace(text) != "" {
+				content = generateTextExpression(text, receiver, currentComp, htmlSource, estimateLineNumber(htmlSource, text), loopCtx)
 			}
-		case "input":
-			// Handle input element
-			return fmt.Sprintf("vdom.NewVNode(%s, %s, nil, \"\")", strconv.Quote(tagName), attrsMapStr)
-		case "select":
-			// Handle select element with option children
-			if hasForLoop || hasSlotSpread {
-				return fmt.Sprintf("vdom.NewVNode(%s, %s, %s, \"\")", strconv.Quote(tagName), attrsMapStr, strings.TrimSuffix(childrenStr, "..."))
-			} else {
-				if childrenStr == "" {
-					return fmt.Sprintf("vdom.NewVNode(%s, %s, nil, \"\")", strconv.Quote(tagName), attrsMapStr)
-				}
-				return fmt.Sprintf("vdom.NewVNode(%s, %s, []*vdom.VNode{%s}, \"\")", strconv.Quote(tagName), attrsMapStr, childrenStr)
-			}
-		case "option":
-			// Handle option element
-			textContent := ""
-			var textBuilder strings.Builder
-			for c := n.FirstChild; c != nil; c = c.NextSibling {
-				if c.Type == html.TextNode {
-					textBuilder.WriteString(c.Data)
-				}
-			}
-			fullText := textBuilder.String()
-			if fullText != "" {
-				lineNum := estimateLineNumber(htmlSource, fullText)
-				textContent = generateTextExpression(fullText, receiver, currentComp, htmlSource, lineNum, loopCtx)
-			} else {
-				textContent = `""`
-			}
-			return fmt.Sprintf("vdom.NewVNode(%s, %s, nil, %s)", strconv.Quote(tagName), attrsMapStr, textContent)
-		case "textarea":
-			// Handle textarea element
-			return fmt.Sprintf("vdom.NewVNode(%s, %s, nil, \"\")", strconv.Quote(tagName), attrsMapStr)
-		case "form":
-			// Handle form element with children
-			if hasForLoop || hasSlotSpread {
-				return fmt.Sprintf("vdom.NewVNode(%s, %s, %s, \"\")", strconv.Quote(tagName), attrsMapStr, strings.TrimSuffix(childrenStr, "..."))
-			} else {
-				if childrenStr == "" {
-					return fmt.Sprintf("vdom.NewVNode(%s, %s, nil, \"\")", strconv.Quote(tagName), attrsMapStr)
-				}
-				return fmt.Sprintf("vdom.NewVNode(%s, %s, []*vdom.VNode{%s}, \"\")", strconv.Quote(tagName), attrsMapStr, childrenStr)
-			}
-		case "nav", "a", "span", "section", "article", "header", "footer", "main", "aside":
-			// Handle semantic HTML5 elements and inline elements with children
-			if hasForLoop || hasSlotSpread {
-				return fmt.Sprintf("vdom.NewVNode(%s, %s, %s, \"\")", strconv.Quote(tagName), attrsMapStr, strings.TrimSuffix(childrenStr, "..."))
-			} else {
-				if childrenStr == "" {
-					// Check if there's text content - concatenate all text nodes
-					textContent := ""
-					var textBuilder strings.Builder
-					for c := n.FirstChild; c != nil; c = c.NextSibling {
-						if c.Type == html.TextNode {
-							textBuilder.WriteString(c.Data)
-						}
-					}
-					fullText := textBuilder.String()
-					if fullText != "" {
-						lineNum := estimateLineNumber(htmlSource, fullText)
-						textContent = generateTextExpression(fullText, receiver, currentComp, htmlSource, lineNum, loopCtx)
-						return fmt.Sprintf("vdom.NewVNode(%s, %s, nil, %s)", strconv.Quote(tagName), attrsMapStr, textContent)
-					}
-					return fmt.Sprintf("vdom.NewVNode(%s, %s, nil, \"\")", strconv.Quote(tagName), attrsMapStr)
-				}
-				return fmt.Sprintf("vdom.NewVNode(%s, %s, []*vdom.VNode{%s}, \"\")", strconv.Quote(tagName), attrsMapStr, childrenStr)
-			}
-		case "img", "br", "hr", "wbr":
-			// Void elements — no children or text content
-			return fmt.Sprintf("vdom.NewVNode(%s, %s, nil, \"\")", strconv.Quote(tagName), attrsMapStr)
-		default:
-			return `vdom.Div(nil)` // Default to an empty div for unknown tags
 		}
+		if tagName != "textarea" {
+			children = joinNodeCodes(childrenCode)
+		}
+		if ns := vdomNamespace(n); ns != "" {
+			return fmt.Sprintf("vdom.NewVNodeNS(%s, %s, %s, %s, %s)", ns, strconv.Quote(tagName), attrsMapStr, children, content)
+		}
+		return fmt.Sprintf("vdom.NewVNode(%s, %s, %s, %s)", strconv.Quote(tagName), attrsMapStr, children, content)
 	}
 
 	return ""
@@ -381,3 +196,182 @@ func findOriginalTagName(n *html.Node, lowercasedName string, htmlSource string)
 	// Fallback: return the lowercased name
 	return lowercasedName
 }
+
+// vdomNamespace returns the vdom constant for the namespace of an SVG or MathML element, or
+// "" for HTML. The HTML parser tracks the namespace: everything inside <svg> or <math> is
+// foreign content, except the children of <foreignObject>, which are HTML again. It also
+// restores the case of SVG tag and attribute names (foreignObject, viewBox).
+func vdomNamespace(n *html.Node) string {
+	switch n.Namespace {
+	case "svg":
+		return "vdom.NamespaceSVG"
+	case "math":
+		return "vdom.NamespaceMathML"
+	}
+	return ""
+}
+
+// schemaTagName returns the name an element is looked up by in the HTML schema. SVG and
+// MathML elements are qualified ("svg:a") so they never match the HTML element of the
+// same name and are treated like unknown elements.
+func schemaTagName(n *html.Node) string {
+	if n.Namespace != "" {
+		return n.Namespace + ":" + n.Data
+	}
+	return n.Data
+}
+
+// nodeCode is the generated code of one node in a list of siblings.
+type nodeCode struct {
+	Code string
+	List bool // Code is a []*vdom.VNode whose nodes are spliced into the list (a directive or a slot)
+}
+
+// isNodeList reports whether the code generated for n is a []*vdom.VNode rather than a single
+// *vdom.VNode. Directives render any number of sibling nodes, which are spliced into the
+// parent's children without a wrapper element.
+func isNodeList(n *html.Node) bool {
+	if n.Type != html.ElementNode {
+		return false
+	}
+	switch n.Data {
+	case "go-conditional", "go-switch", "go-for":
+		return true
+	}
+	return false
+}
+
+// generateChildCodes generates the code of every child of n that renders something, in
+// order. skip is a child handled separately (the {@empty} branch of a loop), or nil.
+func generateChildCodes(n, skip *html.Node, receiver string, componentMap map[string]componentInfo, currentComp componentInfo, htmlSource string, opts compileOptions, loopCtx *loopContext) []nodeCode {
+	var codes []nodeCode
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c == skip {
+			continue
+		}
+		if spread, ok := slotSpreadCode(c, receiver, currentComp, htmlSource, loopCtx); ok {
+			codes = append(codes, spread)
+			continue
+		}
+		if code := generateNodeCode(c, receiver, componentMap, currentComp, htmlSource, opts, loopCtx); code != "" {
+			codes = append(codes, nodeCode{Code: code, List: isNodeList(c)})
+		}
+	}
+	return codes
+}
+
+// slotSpreadCode returns the code of a text node that renders nodes rather than text: a
+// binding of one of the component's content slots ({BodyContent}), whose nodes are spread
+// into the parent's children, or a binding of type *vdom.VNode such as a call to a scoped
+// slot ({Row(item)}). ok is false for any other node.
+func slotSpreadCode(n *html.Node, receiver string, currentComp componentInfo, htmlSource string, loopCtx *loopContext) (nodeCode, bool) {
+	if n.Type != html.TextNode || strings.TrimSpace(n.Data) == "" {
+		return nodeCode{}, false
+	}
+	trimmed := strings.TrimSpace(n.Data)
+	if matches := dataBindingRegex.FindStringSubmatch(trimmed); len(matches) > 0 {
+		fieldName := matches[1]
+
+		// Check if this references one of the slot fields, or a []*vdom.VNode prop or state
+		// field for backward compatibility
+		field, ok := findSlot(currentComp.Schema, fieldName)
+		if !ok {
+			field, ok = currentComp.Schema.Props[strings.ToLower(fieldName)]
+			if !ok {
+				field, ok = currentComp.Schema.State[strings.ToLower(fieldName)]
+			}
+			ok = ok && field.GoType == "[]*vdom.VNode"
+		}
+		if ok {
+			// An unfilled slot is reported by the dev renderer when the component is created
+			return nodeCode{Code: fmt.Sprintf("%s.%s", receiver, field.Name), List: true}, true
+		}
+	}
+
+	lineNum := estimateTextNodeLineNumber(htmlSource, n.Data)
+	if code, ok := currentComp.Expr.compileNodeBinding(trimmed, lineNum, loopCtx); ok {
+		return nodeCode{Code: code}, true
+	}
+	return nodeCode{}, false
+}
+
+// generateRootCode generates the code of the nodes inside n as a single node: the node
+// itself when there is one, or a fragment holding them all.
+func generateRootCode(n *html.Node, receiver string, componentMap map[string]componentInfo, currentComp componentInfo, htmlSource string, opts compileOptions, loopCtx *loopContext) string {
+	codes := generateChildCodes(n, nil, receiver, componentMap, currentComp, htmlSource, opts, loopCtx)
+	if len(codes) == 0 {
+		return ""
+	}
+	if len(codes) == 1 && !codes[0].List {
+		return codes[0].Code
+	}
+	return fragmentCode(codes)
+}
+
+// joinNodeCodes returns a []*vdom.VNode expression holding the nodes of codes in order,
+// splicing in the nodes of lists.
+func joinNodeCodes(codes []nodeCode) string {
+	if len(codes) == 0 {
+		return "nil"
+	}
+	if len(codes) == 1 && codes[0].List {
+		return codes[0].Code
+	}
+	var single []string
+	for _, c := range codes {
+		if c.List {
+			single = nil
+			break
+		}
+		single = append(single, c.Code)
+	}
+	if single != nil {
+		return fmt.Sprintf("[]*vdom.VNode{%s}", strings.Join(single, ", "))
+	}
+
+	var code strings.Builder
+	code.WriteString("func() []*vdom.VNode {\nvar allChildren []*vdom.VNode\n")
+	for _, c := range codes {
+		if c.List {
+			fmt.Fprintf(&code, "allChildren = append(allChildren, %s...)\n", c.Code)
+		} else {
+			fmt.Fprintf(&code, "allChildren = append(allChildren, %s)\n", c.Code)
+		}
+	}
+	code.WriteString("return allChildren\n}()")
+	return code.String()
+}
+
+// fragmentCode returns a vdom.Fragment expression holding the nodes of codes in order.
+func fragmentCode(codes []nodeCode) string {
+	nodes := make([]string, 0, len(codes))
+	for _, c := range codes {
+		if c.List {
+			return fmt.Sprintf("vdom.Fragment(%s...)", joinNodeCodes(codes))
+		}
+		nodes = append(nodes, c.Code)
+	}
+	return fmt.Sprintf("vdom.Fragment(%s)", strings.Join(nodes, ", "))
+}
+
+// hasElementChildren reports whether n has element children, including the placeholder
+// elements of directives ({@if}, {@for}, ...).
+func hasElementChildren(n *html.Node) bool {
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode {
+			return true
+		}
+	}
+	return false
+}
+
+// textContent concatenates the text node children of n.
+func textContent(n *html.Node) string {
+	var sb strings.Builder
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.TextNode {
+			sb.WriteString(c.Data)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,117 @@
+package compiler
+
+import (
+	"fmt"
+	"go/constant"
+	"go/types"
+	"os"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// switchCase is one compiled {@case} or {@default} branch.
+type switchCase struct {
+	Values []string // Go code of the case values; empty for {@default}
+	Node   *html.Node
+}
+
+// generateSwitchCode generates a Go switch statement for a <go-switch> placeholder. Like
+// {@if}, it returns a []*vdom.VNode holding every node of the matching branch.
+// The switch expression and every case value are type-checked: case values must be
+// assignable to the switch expression's type.
+func generateSwitchCode(n *html.Node, receiver string, componentMap map[string]componentInfo, currentComp componentInfo, htmlSource string, opts compileOptions, loopCtx *loopContext) string {
+	checker := currentComp.Expr
+
+	switchSrc := checker.locateIn("{@switch ", getAttr(n, "data-expr"), "}", directiveLine(n))
+	switchCode, switchTV := checker.checkValue(switchSrc, loopCtx)
+	switchType := switchTV.Type
+	if !types.Comparable(switchType) {
+		checker.fail(switchSrc, 1, 1, fmt.Sprintf("cannot switch on a value of type '%s' (not comparable)", checker.typeString(switchType)), loopCtx)
+	}
+
+	var cases []switchCase
+	hasDefault := false
+	seen := make(map[string]string) // Constant case value -> case expression, for duplicates
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type != html.ElementNode {
+			continue
+		}
+		switch c.Data {
+		case "go-default":
+			hasDefault = true
+			cases = append(cases, switchCase{Node: c})
+		case "go-case":
+			caseSrc := checker.locateIn("{@case ", getAttr(c, "data-values"), "}", directiveLine(c))
+			var values []string
+			for _, valueSrc := range splitExprList(caseSrc) {
+				valueCode, valueTV := checker.checkValue(valueSrc, loopCtx)
+				if !types.AssignableTo(valueTV.Type, switchType) {
+					checker.fail(valueSrc, 1, 1, fmt.Sprintf("case value of type '%s' is not assignable to the switch type '%s'",
+						checker.typeString(valueTV.Type), checker.typeString(switchType)), loopCtx)
+				}
+				if valueTV.Value != nil {
+					key := valueTV.Value.ExactString()
+					if previous, dup := seen[key]; dup {
+						checker.fail(valueSrc, 1, 1, fmt.Sprintf("duplicate case %s in {@switch} (same value as %s)", valueSrc.Text, previous), loopCtx)
+					}
+					seen[key] = valueSrc.Text
+				}
+				values = append(values, valueCode)
+			}
+			cases = append(cases, switchCase{Values: values, Node: c})
+		}
+	}
+
+	if !hasDefault {
+		warnMissingEnumCases(switchType, seen, switchSrc, currentComp)
+	}
+
+	var code strings.Builder
+	code.WriteString("func() []*vdom.VNode {\n")
+	fmt.Fprintf(&code, "switch %s {\n", switchCode)
+	for _, sc := range cases {
+		if sc.Values == nil {
+			code.WriteString("default:\n")
+		} else {
+			fmt.Fprintf(&code, "case %s:\n", strings.Join(sc.Values, ", "))
+		}
+		writeBranchReturn(&code, sc.Node, receiver, componentMap, currentComp, htmlSource, opts, loopCtx)
+	}
+	code.WriteString("}\n")
+
+	// A switch with a default branch returns from every path
+	if !hasDefault {
+		code.WriteString("return nil\n")
+	}
+	code.WriteString("}()")
+	return code.String()
+}
+
+// warnMissingEnumCases prints a warning when a {@switch} without {@default} over a named
+// type does not cover every constant of that type declared in the type's package.
+func warnMissingEnumCases(switchType types.Type, covered map[string]string, src exprSource, comp componentInfo) {
+	named, ok := types.Unalias(switchType).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return
+	}
+	if _, isBasic := named.Underlying().(*types.Basic); !isBasic {
+		return
+	}
+
+	scope := named.Obj().Pkg().Scope()
+	var missing []string
+	for _, name := range scope.Names() {
+		c, ok := scope.Lookup(name).(*types.Const)
+		if !ok || !types.Identical(c.Type(), switchType) || c.Val().Kind() == constant.Unknown {
+			continue
+		}
+		if _, isCovered := covered[c.Val().ExactString()]; !isCovered {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		fmt.Fprintf(os.Stderr, "Warning in %s:%d:%d: {@switch} over '%s' has no {@default} and is missing cases: %s\n",
+			comp.Path, src.Line, src.Col, named.Obj().Name(), strings.Join(missing, ", "))
+	}
+}
@@ -3,14 +3,104 @@ package compiler
 import (
 	"fmt"
 	"os"
-	"path/filepath"
-	"strconv"
+	"regexp"
 	"strings"
 
 	"golang.org/x/net/html"
 )
 
+// htmlWhitespace matches a run of the characters HTML treats as whitespace.
+var htmlWhitespace = regexp.MustCompile(`[ \t\n\f\r]+`)
+
+// blockElements are the elements that start a new line, so whitespace next to them is
+// template indentation rather than a space between words.
+var blockElements = map[string]bool{
+	"address": true, "article": true, "aside": true, "blockquote": true, "body": true, "caption": true,
+	"col": true, "colgroup": true, "dd": true, "details": true, "dialog": true, "div": true, "dl": true,
+	"dt": true, "fieldset": true, "figcaption": true, "figure": true, "footer": true, "form": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true, "head": true, "header": true,
+	"hgroup": true, "hr": true, "html": true, "legend": true, "li": true, "link": true, "main": true,
+	"menu": true, "meta": true, "nav": true, "ol": true, "optgroup": true, "option": true, "p": true,
+	"pre": true, "script": true, "search": true, "section": true, "slot": true, "style": true,
+	"summary": true, "table": true, "tbody": true, "td": true, "template": true, "tfoot": true,
+	"th": true, "thead": true, "title": true, "tr": true, "ul": true,
+}
+
+// renderedText returns the text a text node renders, or "" if it renders nothing.
+//
+// Runs of whitespace collapse to a single space, as the browser displays them, so words
+// around inline elements stay apart: <p>Hello <strong>world</strong> again</p>. Whitespace
+// at the start or end of the parent is dropped, and a node holding only whitespace is
+// dropped unless it separates two inline siblings (<b>a</b> <i>b</i>): next to a block-level
+// element, a component or a directive it is template indentation. Bindings keep their
+// text, and text inside <pre> is kept as written.
+func renderedText(n *html.Node, componentMap map[string]componentInfo) string {
+	for p := n.Parent; p != nil; p = p.Parent {
+		if p.Type == html.ElementNode && p.Data == "pre" {
+			return n.Data
+		}
+	}
+
+	if strings.Trim(n.Data, " \t\n\f\r") == "" {
+		if isInlineSibling(n, -1, componentMap) && isInlineSibling(n, 1, componentMap) {
+			return " "
+		}
+		return ""
+	}
+
+	var sb strings.Builder
+	for _, seg := range scanBindings(n.Data) {
+		if seg.IsBinding {
+			sb.WriteString("{" + seg.Text + "}")
+		} else {
+			sb.WriteString(htmlWhitespace.ReplaceAllString(seg.Text, " "))
+		}
+	}
+	text := sb.String()
+	if sibling(n, -1) == nil {
+		text = strings.TrimLeft(text, " ")
+	}
+	if sibling(n, 1) == nil {
+		text = strings.TrimRight(text, " ")
+	}
+	return text
+}
+
+// sibling returns the previous (dir -1) or next (dir 1) sibling of n, skipping comments.
+func sibling(n *html.Node, dir int) *html.Node {
+	next := func(c *html.Node) *html.Node {
+		if dir < 0 {
+			return c.PrevSibling
+		}
+		return c.NextSibling
+	}
+	c := next(n)
+	for c != nil && c.Type == html.CommentNode {
+		c = next(c)
+	}
+	return c
+}
+
+// isInlineSibling reports whether the sibling of n in direction dir is text or an inline
+// HTML element, one that flows with the text around it.
+func isInlineSibling(n *html.Node, dir int, componentMap map[string]componentInfo) bool {
+	c := sibling(n, dir)
+	switch {
+	case c == nil:
+		return false
+	case c.Type == html.TextNode:
+		return true
+	case c.Type != html.ElementNode:
+		return false
+	}
+	// SVG and MathML elements are laid out by their own rules, not as words in a line
+	_, isComponent := componentMap[c.Data]
+	return !isComponent && c.Namespace == "" && !blockElements[c.Data] && !strings.HasPrefix(c.Data, "go-")
+}
+
 // generateTextExpression handles data binding in text nodes.
+// Each {…} binding is a Go expression ({Count}, {len(Items)}, {FormatPrice(p.Cents)}) or a
+// ternary whose branches are single-quoted strings ({IsSaving ? 'Saving...' : 'Save'}).
 // loopCtx can be nil if not inside a loop.
 func generateTextExpression(text string, receiver string, currentComp componentInfo, htmlSource string, lineNumber int, loopCtx *loopContext) string {
 	// Check for malformed ternary expressions (opening { with ternary pattern but no closing })
@@ -32,177 +122,8 @@ func generateTextExpression(text string, receiver string, currentComp componentI
 		}
 	}
 
-	// Check for ternary expressions first
-	ternaryMatches := ternaryExprRegex.FindAllStringSubmatch(text, -1)
-
-	if len(ternaryMatches) > 0 {
-		// Handle ternary expressions
-		result := text
-
-		for _, match := range ternaryMatches {
-			fullMatch := match[0]
-			negated := match[1] == "!"
-			condition := match[2]
-			trueVal := match[3]
-			falseVal := match[4]
-
-			// Validate condition is a boolean field
-			propDesc := validateBooleanCondition(condition, currentComp, currentComp.Path, lineNumber, htmlSource)
-
-			// Generate ternary expression
-			ternaryCode := generateTernaryExpression(negated, condition, trueVal, falseVal, receiver, propDesc)
-
-			// If the text contains only the ternary expression, return it directly
-			if result == fullMatch {
-				return ternaryCode
-			}
-
-			// Otherwise, replace the match with a placeholder for fmt.Sprintf
-			result = strings.Replace(result, fullMatch, "%s", 1)
-		}
-
-		// If there are other parts of the text, wrap in fmt.Sprintf
-		var args []string
-		for _, match := range ternaryMatches {
-			negated := match[1] == "!"
-			condition := match[2]
-			trueVal := match[3]
-			falseVal := match[4]
-			propDesc := validateBooleanCondition(condition, currentComp, currentComp.Path, lineNumber, htmlSource)
-			args = append(args, generateTernaryExpression(negated, condition, trueVal, falseVal, receiver, propDesc))
-		}
-
-		return fmt.Sprintf(`fmt.Sprintf(%s, %s)`, strconv.Quote(result), strings.Join(args, ", "))
-	}
-
-	// Original data binding logic
-	matches := dataBindingRegex.FindAllStringSubmatch(text, -1)
-
-	if len(matches) == 0 {
-		return strconv.Quote(text) // It's just a static string
-	}
-
-	formatString := dataBindingRegex.ReplaceAllString(text, "%v")
-	var args []string
-
-	for _, match := range matches {
-		fieldName := match[1]
-
-		// Check if this is a loop variable first
-		if loopCtx != nil {
-			if fieldName == loopCtx.IndexVar {
-				// Reference loop index variable
-				args = append(args, fieldName)
-				continue
-			}
-			if fieldName == loopCtx.ValueVar {
-				// Reference loop value variable
-				args = append(args, fieldName)
-				continue
-			}
-			// Check if it's a field access on the loop value variable (e.g., user.Name)
-			if strings.Contains(fieldName, ".") {
-				parts := strings.SplitN(fieldName, ".", 2)
-				varName := parts[0]
-				if varName == loopCtx.ValueVar {
-					// This is a field access on the loop value variable
-					// Just use it as-is (e.g., user.Name)
-					args = append(args, fieldName)
-					continue
-				}
-			}
-		}
-
-		// Check if this is a nested field access (e.g., Ctx.Title)
-		if strings.Contains(fieldName, ".") {
-			rootField := strings.ToLower(strings.SplitN(fieldName, ".", 2)[0])
-
-			// Check if root field exists on component
-			if _, inProps := currentComp.Schema.Props[rootField]; inProps {
-				// Resolve the nested field type
-				componentDir := filepath.Dir(currentComp.Path)
-				_, err := resolveNestedFieldType(fieldName, currentComp, componentDir)
-				if err != nil {
-					// Try to get available fields on the nested type for better error message
-					nestedFields := getAvailableNestedFields(fieldName, currentComp, componentDir)
-					allFields := append(getAvailableFieldNames(currentComp.Schema.Props), getAvailableFieldNames(currentComp.Schema.State)...)
-
-					var msg string
-					if len(nestedFields) > 0 {
-						msg = fmt.Sprintf("Compilation Error in %s: Field '%s' not resolvable on component '%s'. %v\n\nAvailable component fields: [%s]\nAvailable fields on %s: [%s]\n",
-							currentComp.Path, fieldName, currentComp.PascalName, err, strings.Join(allFields, ", "),
-							strings.SplitN(fieldName, ".", 2)[0], strings.Join(nestedFields, ", "))
-					} else {
-						msg = fmt.Sprintf("Compilation Error in %s: Field '%s' not resolvable on component '%s'. %v\nAvailable fields: [%s]\n",
-							currentComp.Path, fieldName, currentComp.PascalName, err, strings.Join(allFields, ", "))
-					}
-					fmt.Fprint(os.Stderr, msg)
-					os.Exit(1)
-				}
-				// Use nested field access as-is
-				args = append(args, fmt.Sprintf("%s.%s", receiver, fieldName))
-				continue
-			} else if _, inState := currentComp.Schema.State[rootField]; inState {
-				// Resolve the nested field type
-				componentDir := filepath.Dir(currentComp.Path)
-				_, err := resolveNestedFieldType(fieldName, currentComp, componentDir)
-				if err != nil {
-					// Try to get available fields on the nested type for better error message
-					nestedFields := getAvailableNestedFields(fieldName, currentComp, componentDir)
-					allFields := append(getAvailableFieldNames(currentComp.Schema.Props), getAvailableFieldNames(currentComp.Schema.State)...)
-
-					var msg string
-					if len(nestedFields) > 0 {
-						msg = fmt.Sprintf("Compilation Error in %s: Field '%s' not resolvable on component '%s'. %v\n\nAvailable component fields: [%s]\nAvailable fields on %s: [%s]\n",
-							currentComp.Path, fieldName, currentComp.PascalName, err, strings.Join(allFields, ", "),
-							strings.SplitN(fieldName, ".", 2)[0], strings.Join(nestedFields, ", "))
-					} else {
-						msg = fmt.Sprintf("Compilation Error in %s: Field '%s' not resolvable on component '%s'. %v\nAvailable fields: [%s]\n",
-							currentComp.Path, fieldName, currentComp.PascalName, err, strings.Join(allFields, ", "))
-					}
-					fmt.Fprint(os.Stderr, msg)
-					os.Exit(1)
-				}
-				// Use nested field access as-is
-				args = append(args, fmt.Sprintf("%s.%s", receiver, fieldName))
-				continue
-			}
-		}
-
-		// Type-safety check: does the field exist on the component struct (props or state)?
-		propDesc, inProps := currentComp.Schema.Props[strings.ToLower(fieldName)]
-		stateDesc, inState := currentComp.Schema.State[strings.ToLower(fieldName)]
-
-		if !inProps && !inState {
-			// If we're in a loop, provide more context in the error
-			if loopCtx != nil {
-				allFields := append(getAvailableFieldNames(currentComp.Schema.Props), getAvailableFieldNames(currentComp.Schema.State)...)
-				fmt.Fprintf(os.Stderr, "Compilation Error in %s: Field '%s' not found.\n"+
-					"  - Not a loop variable (loop has: %s, %s)\n"+
-					"  - Not a component field (available: %s)\n"+
-					"  - For loop item fields, use: %s.FieldName\n",
-					currentComp.Path, fieldName,
-					loopCtx.IndexVar, loopCtx.ValueVar,
-					strings.Join(allFields, ", "),
-					loopCtx.ValueVar)
-			} else {
-				fmt.Fprintf(os.Stderr, "Compilation Error in %s: Field '%s' not found on component '%s' for data binding.\n",
-					currentComp.Path, fieldName, currentComp.PascalName)
-			}
-			os.Exit(1)
-		}
-		// Use the schema's correctly-cased field name, not the raw template expression,
-		// so that e.g. {id} in the template correctly emits c.ID (not c.id).
-		var resolvedName string
-		if inProps {
-			resolvedName = propDesc.Name
-		} else {
-			resolvedName = stateDesc.Name
-		}
-		args = append(args, fmt.Sprintf("%s.%s", receiver, resolvedName))
-	}
-
-	return fmt.Sprintf(`fmt.Sprintf(%s, %s)`, strconv.Quote(formatString), strings.Join(args, ", "))
+	// Bindings are full Go expressions, type-checked against the component struct
+	return currentComp.Expr.compileInterpolation(text, lineNumber, loopCtx)
 }
 
 // generateSlotTextNodeError generates a detailed error message for unwrapped text in slot content.
@@ -255,35 +176,65 @@ func generateTextExpression(text string, receiver string, currentComp componentI
 // 	os.Exit(1)
 // }
 
-// collectSlotChildren collects child nodes for content projection and generates VNode slice code.
-// Returns empty string if no children, otherwise returns Go code for []*vdom.VNode{...}.
-// Validates that slot content does not contain unwrapped text nodes.
-func collectSlotChildren(n *html.Node, receiver string, componentMap map[string]componentInfo, currentComp componentInfo, componentName string, templatePath string, htmlSource string, opts compileOptions, loopCtx *loopContext) string {
-	var childrenCode []string
-
-	// Collect all children (elements and text nodes)
+// collectSlotChildren collects child nodes for content projection and generates the
+// []*vdom.VNode code of every slot it fills, keyed by slot field name. The children of a
+// <slot name="Footer"> element ({@slot Footer}) fill that slot; all other children fill the
+// component's first slot. A slot that is not in the map was not filled.
+func collectSlotChildren(n *html.Node, compInfo componentInfo, receiver string, componentMap map[string]componentInfo, currentComp componentInfo, htmlSource string, lineNumber int, opts compileOptions, loopCtx *loopContext) map[string]string {
+	slots := make(map[string]string)
+	var defaultCodes []nodeCode
 	for c := n.FirstChild; c != nil; c = c.NextSibling {
-		if c.Type == html.TextNode {
-			// Check if this is meaningful text (not just whitespace)
-			trimmed := strings.TrimSpace(c.Data)
-			if trimmed != "" {
-				// Convert text node to pure text VNode using vdom.Text()
-				textExpr := generateTextExpression(trimmed, receiver, currentComp, htmlSource, estimateTextNodeLineNumber(htmlSource, c.Data), loopCtx)
-				childrenCode = append(childrenCode, fmt.Sprintf(`vdom.Text(%s)`, textExpr))
+		if c.Type == html.ElementNode && c.Data == "go-let" {
+			continue // Fills a scoped slot (generateStructLiteral)
+		}
+		if c.Type == html.ElementNode && c.Data == "slot" {
+			name := getAttr(c, "name")
+			if name == "" {
+				templateError(currentComp, htmlSource, lineNumber, fmt.Sprintf("A <slot> block inside component '%s' needs the name of the slot it fills, e.g. <slot name=\"%s\">",
+					compInfo.PascalName, compInfo.Schema.Slots[0].Name))
 			}
-			// Skip whitespace-only text nodes
+			slot, ok := findSlot(compInfo.Schema, name)
+			if !ok {
+				templateError(currentComp, htmlSource, lineNumber, fmt.Sprintf("Component '%s' has no slot named '%s'. Available slots: [%s]",
+					compInfo.PascalName, name, strings.Join(getSlotNames(compInfo.Schema), ", ")))
+			}
+			if _, filled := slots[slot.Name]; filled {
+				templateError(currentComp, htmlSource, lineNumber, fmt.Sprintf("Slot '%s' of component '%s' is filled more than once", slot.Name, compInfo.PascalName))
+			}
+			// Elements, text and directives; directives splice in every node they render
+			slots[slot.Name] = slotCode(generateChildCodes(c, nil, receiver, componentMap, currentComp, htmlSource, opts, loopCtx))
 			continue
 		}
-
-		childCode := generateNodeCode(c, receiver, componentMap, currentComp, htmlSource, opts, loopCtx)
-		if childCode != "" {
-			childrenCode = append(childrenCode, childCode)
+		if spread, ok := slotSpreadCode(c, receiver, currentComp, htmlSource, loopCtx); ok {
+			defaultCodes = append(defaultCodes, spread)
+			continue
+		}
+		if code := generateNodeCode(c, receiver, componentMap, currentComp, htmlSource, opts, loopCtx); code != "" {
+			defaultCodes = append(defaultCodes, nodeCode{Code: code, List: isNodeList(c)})
 		}
 	}
 
-	if len(childrenCode) == 0 {
-		return "" // No children, will compile to nil
+	if len(defaultCodes) > 0 {
+		defaultSlot := compInfo.Schema.Slots[0]
+		if _, filled := slots[defaultSlot.Name]; filled {
+			templateError(currentComp, htmlSource, lineNumber, fmt.Sprintf("Slot '%s' of component '%s' is filled by a <slot name=\"%s\"> block and by content outside it; move the content into the block",
+				defaultSlot.Name, compInfo.PascalName, defaultSlot.Name))
+		}
+		slots[defaultSlot.Name] = slotCode(defaultCodes)
 	}
+	return slots
+}
 
-	return fmt.Sprintf("[]*vdom.VNode{%s}", strings.Join(childrenCode, ", "))
+// slotCode joins the nodes that fill a slot. The slice is never nil, even when the slot is
+// filled with nothing or its directives render nothing: in dev builds the runtime warns
+// about nil slots, which only an unfilled slot compiles to.
+func slotCode(codes []nodeCode) string {
+	code := joinNodeCodes(codes)
+	switch {
+	case code == "nil":
+		return "[]*vdom.VNode{}"
+	case strings.HasPrefix(code, "[]*vdom.VNode{"):
+		return code
+	}
+	return fmt.Sprintf("append([]*vdom.VNode{}, %s...)", code)
 }
@@ -14,8 +14,17 @@ const (
 // Compile is the main entry point for the nojs AOT compiler.
 // It discovers all *.gt.html component templates under srcDir, inspects
 // their corresponding Go structs, and writes a *.generated.go file next
-// to each template.
+// to each template. The scoped CSS of components with a *.gt.css file is
+// bundled into components.generated.css in srcDir; without any, no bundle is written.
 func Compile(srcDir string, devMode bool) error {
+	return CompileWithCSSBundle(srcDir, devMode, "")
+}
+
+// CompileWithCSSBundle is Compile with the path of the CSS bundle, such as a file in the
+// directory the app is served from. A given cssBundle is always written, even when empty, so
+// the page linking it finds it. An empty cssBundle writes the default bundle in srcDir, and
+// only when a component has a stylesheet.
+func CompileWithCSSBundle(srcDir string, devMode bool, cssBundle string) error {
 	opts := compileOptions{DevMode: devMode}
 
 	// Convert srcDir to absolute path for consistent path handling
@@ -31,6 +40,11 @@ func Compile(srcDir string, devMode bool) error {
 	}
 	fmt.Printf("%c Discovered and inspected %d component templates.\n", IconSuccess, len(components))
 
+	// Type-check every component package together, so parents and children share types.
+	if err := loadComponentPackages(absSrcDir, components); err != nil {
+		return err
+	}
+
 	componentMap := make(map[string]componentInfo)
 	for _, comp := range components {
 		componentMap[comp.LowercaseName] = comp
@@ -42,5 +56,18 @@ func Compile(srcDir string, devMode bool) error {
 			return fmt.Errorf("%c failed to compile template for %s: %w", IconError, comp.PascalName, err)
 		}
 	}
+
+	// Step 3: Bundle the scoped CSS of every component, in a fixed order.
+	explicitBundle := cssBundle != ""
+	if !explicitBundle {
+		cssBundle = filepath.Join(absSrcDir, cssBundleFileName)
+	}
+	stylesheets, err := writeCSSBundle(components, cssBundle, explicitBundle)
+	if err != nil {
+		return fmt.Errorf("%c failed to write the CSS bundle: %w", IconError, err)
+	}
+	if stylesheets > 0 {
+		fmt.Printf("%c Bundled %d component stylesheets into %s.\n", IconSuccess, stylesheets, cssBundle)
+	}
 	return nil
 }
@@ -0,0 +1,258 @@
+package compiler
+
+import (
+	"fmt"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// cssBundleFileName is the default name of the CSS bundle, written in the source directory.
+const cssBundleFileName = "components.generated.css"
+
+// scopeAttribute returns the attribute that scopes a component's CSS to the elements it
+// renders: data-nojs- followed by a hash of the component's package and name, so it is the
+// same in every build.
+func scopeAttribute(importPath, pascalName string) string {
+	h := fnv.New32a()
+	h.Write([]byte(importPath + "." + pascalName))
+	return fmt.Sprintf("data-nojs-%08x", h.Sum32())
+}
+
+// writeCSSBundle scopes the CSS of every component that has a Name.gt.css file and writes it
+// to one bundle, ordered by package import path and component name. When no component has a
+// stylesheet, the bundle is only written if always is set, so a page linking an explicitly
+// requested bundle still finds it. It returns the number of stylesheets bundled.
+func writeCSSBundle(components []componentInfo, bundlePath string, always bool) (int, error) {
+	styled := make([]componentInfo, 0, len(components))
+	for _, comp := range components {
+		if comp.CSSPath != "" {
+			styled = append(styled, comp)
+		}
+	}
+	if len(styled) == 0 && !always {
+		return 0, nil
+	}
+	sort.Slice(styled, func(i, j int) bool {
+		if styled[i].ImportPath != styled[j].ImportPath {
+			return styled[i].ImportPath < styled[j].ImportPath
+		}
+		return styled[i].PascalName < styled[j].PascalName
+	})
+
+	var bundle strings.Builder
+	bundle.WriteString("/* Code generated by the nojs AOT compiler. DO NOT EDIT. */\n")
+	for _, comp := range styled {
+		src, err := os.ReadFile(comp.CSSPath)
+		if err != nil {
+			return 0, fmt.Errorf("failed to read stylesheet %s: %w", comp.CSSPath, err)
+		}
+		scoped, err := scopeCSS(string(src), comp.ScopeAttr, comp.CSSPath)
+		if err != nil {
+			return 0, err
+		}
+		fmt.Fprintf(&bundle, "\n/* %s.%s (%s) */\n%s", comp.ImportPath, comp.PascalName, filepath.Base(comp.CSSPath), scoped)
+	}
+
+	return len(styled), os.WriteFile(bundlePath, []byte(bundle.String()), 0644)
+}
+
+// scopeCSS rewrites every selector of a stylesheet so it only matches elements carrying the
+// scope attribute: ".item > a:hover" becomes ".item > a[data-nojs-…]:hover". Rules inside
+// @media, @supports, @container and @layer blocks are scoped too; other at-rules such as
+// @keyframes and @font-face are kept as they are. Comments are dropped.
+func scopeCSS(src, attr, cssPath string) (string, error) {
+	src = stripCSSComments(src)
+	var out strings.Builder
+	if err := scopeCSSRules(&out, src, 0, len(src), attr, cssPath); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+// scopeCSSRules scopes the rules in src[from:to], a whole stylesheet or the body of a
+// conditional at-rule.
+func scopeCSSRules(out *strings.Builder, src string, from, to int, attr, cssPath string) error {
+	for i := from; i < to; {
+		// The prelude runs up to the block it introduces, or to ';' for @import and @charset
+		end := scanCSS(src[:to], i, "{;}")
+		prelude := strings.TrimSpace(src[i:end])
+		if end == to {
+			if prelude != "" {
+				start := end - len(strings.TrimLeft(src[i:end], " \t\r\n"))
+				return cssError(src, cssPath, start, fmt.Sprintf("'%s' is not followed by a block", prelude))
+			}
+			return nil
+		}
+
+		switch src[end] {
+		case '}':
+			return cssError(src, cssPath, end, "unexpected '}'")
+		case ';':
+			if prelude != "" {
+				fmt.Fprintf(out, "%s;\n", prelude)
+			}
+			i = end + 1
+			continue
+		}
+
+		closing := scanCSS(src[:to], end+1, "}")
+		if closing == to {
+			return cssError(src, cssPath, end, fmt.Sprintf("the block of '%s' is not closed", prelude))
+		}
+		body := src[end+1 : closing]
+		i = closing + 1
+
+		if atRule, ok := strings.CutPrefix(prelude, "@"); ok {
+			name, _, _ := strings.Cut(strings.ToLower(atRule), " ")
+			name, _, _ = strings.Cut(name, "(")
+			switch strings.TrimSpace(name) {
+			case "media", "supports", "container", "layer":
+				fmt.Fprintf(out, "%s {\n", prelude)
+				if err := scopeCSSRules(out, src, end+1, closing, attr, cssPath); err != nil {
+					return err
+				}
+				out.WriteString("}\n")
+			default:
+				fmt.Fprintf(out, "%s {%s}\n", prelude, body)
+			}
+			continue
+		}
+
+		if prelude == "" {
+			return cssError(src, cssPath, end, "a rule has no selector")
+		}
+		var selectors []string
+		for _, selector := range splitCSSSelectors(prelude) {
+			selectors = append(selectors, scopeSelector(selector, attr))
+		}
+		fmt.Fprintf(out, "%s {%s}\n", strings.Join(selectors, ", "), body)
+	}
+	return nil
+}
+
+// scopeSelector adds the scope attribute to the last compound selector of a complex
+// selector, before its pseudo-classes and pseudo-elements.
+func scopeSelector(selector, attr string) string {
+	// The last compound starts after the last combinator outside brackets and parentheses
+	start, depth := 0, 0
+	for i := 0; i < len(selector); i++ {
+		switch c := selector[i]; c {
+		case '[', '(':
+			depth++
+		case ']', ')':
+			depth--
+		case '\\':
+			i++ // Escaped character, such as the colon of .md\:flex
+		case '"', '\'':
+			i = skipCSSString(selector, i)
+		case ' ', '\t', '\n', '\r', '>', '+', '~':
+			if depth == 0 {
+				start = i + 1
+			}
+		}
+	}
+
+	insert, depth := len(selector), 0
+	for i := start; i < len(selector); i++ {
+		switch selector[i] {
+		case '[', '(':
+			depth++
+		case ']', ')':
+			depth--
+		case '\\':
+			i++
+		case '"', '\'':
+			i = skipCSSString(selector, i)
+		case ':':
+			if depth == 0 && insert == len(selector) {
+				insert = i
+			}
+		}
+	}
+	return selector[:insert] + "[" + attr + "]" + selector[insert:]
+}
+
+// splitCSSSelectors splits a selector list at top-level commas.
+func splitCSSSelectors(prelude string) []string {
+	var selectors []string
+	start := 0
+	for {
+		end := scanCSS(prelude, start, ",")
+		if selector := strings.TrimSpace(prelude[start:end]); selector != "" {
+			selectors = append(selectors, selector)
+		}
+		if end == len(prelude) {
+			return selectors
+		}
+		start = end + 1
+	}
+}
+
+// scanCSS returns the index of the first of stops at nesting depth 0 from start, skipping
+// strings and nested brackets, braces and parentheses, or len(src).
+func scanCSS(src string, start int, stops string) int {
+	depth := 0
+	for i := start; i < len(src); i++ {
+		c := src[i]
+		if depth == 0 && strings.IndexByte(stops, c) >= 0 {
+			return i
+		}
+		switch c {
+		case '{', '(', '[':
+			depth++
+		case '}', ')', ']':
+			depth--
+		case '"', '\'':
+			i = skipCSSString(src, i)
+		}
+	}
+	return len(src)
+}
+
+// skipCSSString returns the index of the quote closing the string that starts at src[start].
+func skipCSSString(src string, start int) int {
+	for i := start + 1; i < len(src); i++ {
+		switch src[i] {
+		case '\\':
+			i++
+		case src[start]:
+			return i
+		}
+	}
+	return len(src)
+}
+
+// stripCSSComments removes /* … */ comments outside strings, keeping their line breaks so
+// errors report the right line.
+func stripCSSComments(src string) string {
+	var out strings.Builder
+	for i := 0; i < len(src); i++ {
+		switch {
+		case src[i] == '"' || src[i] == '\'':
+			end := skipCSSString(src, i)
+			if end == len(src) {
+				return out.String() + src[i:]
+			}
+			out.WriteString(src[i : end+1])
+			i = end
+		case strings.HasPrefix(src[i:], "/*"):
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				return out.String()
+			}
+			out.WriteString(strings.Repeat("\n", strings.Count(src[i:i+end+4], "\n")))
+			i += end + 3
+		default:
+			out.WriteByte(src[i])
+		}
+	}
+	return out.String()
+}
+
+// cssError reports a malformed stylesheet at the line of offset.
+func cssError(src, cssPath string, offset int, msg string) error {
+	return fmt.Errorf("stylesheet error in %s:%d: %s", cssPath, strings.Count(src[:offset], "\n")+1, msg)
+}
@@ -10,7 +10,6 @@ import (
 	"strings"
 	"unicode"
 
-	"golang.org/x/net/html"
 	"golang.org/x/tools/go/packages"
 )
 
@@ -66,17 +65,26 @@ func discoverAndInspectComponents(rootDir string) ([]componentInfo, error) {
 				schema = componentSchema{
 					Props:   make(map[string]propertyDescriptor),
 					Methods: make(map[string]methodDescriptor),
+					Events:  make(map[string]propertyDescriptor),
 				}
 			}
 
-			components = append(components, componentInfo{
+			comp := componentInfo{
 				Path:          templatePath,
 				PascalName:    pascalName,
 				LowercaseName: strings.ToLower(pascalName),
 				PackageName:   pkg.Name,    // Use the package name from the loader.
 				ImportPath:    pkg.PkgPath, // Full import path (e.g., "github.com/ForgeLogic/nojs/appcomponents")
 				Schema:        schema,
-			})
+			}
+
+			// A Name.gt.css stylesheet next to the template is scoped to the component
+			cssPath := filepath.Join(packageDir, pascalName+".gt.css")
+			if _, err := os.Stat(cssPath); err == nil {
+				comp.CSSPath = cssPath
+				comp.ScopeAttr = scopeAttribute(pkg.PkgPath, pascalName)
+			}
+			components = append(components, comp)
 
 			// Validate that component name doesn't conflict with HTML tags
 			if err := validateComponentName(pascalName, templatePath); err != nil {
@@ -92,36 +100,6 @@ func discoverAndInspectComponents(rootDir string) ([]componentInfo, error) {
 	return components, nil
 }
 
-// collectUsedComponents walks the HTML tree and collects all components used from other packages.
-// Returns a map of package name to import path.
-func collectUsedComponents(n *html.Node, componentMap map[string]componentInfo, currentComp componentInfo) map[string]string {
-	usedPackages := make(map[string]string)
-
-	var walk func(*html.Node)
-	walk = func(node *html.Node) {
-		if node.Type == html.ElementNode {
-			tagName := node.Data
-			// Check if this is a component
-			if compInfo, isComponent := componentMap[tagName]; isComponent {
-				// Check if it's from a different package
-				if compInfo.PackageName != currentComp.PackageName {
-					// Need to import this package
-					// Store mapping: package name -> full import path
-					usedPackages[compInfo.PackageName] = compInfo.ImportPath
-				}
-			}
-		}
-
-		// Recurse into children
-		for c := node.FirstChild; c != nil; c = c.NextSibling {
-			walk(c)
-		}
-	}
-
-	walk(n)
-	return usedPackages
-}
-
 // extractTypeName extracts the type name from an AST expression.
 // Handles simple types (int, string, bool), slice types ([]User), pointer types (*User), and function types.
 func extractTypeName(expr ast.Expr) string {
@@ -142,6 +120,16 @@ func extractTypeName(expr ast.Expr) string {
 		if ident, ok := t.X.(*ast.Ident); ok {
 			return ident.Name + "." + t.Sel.Name
 		}
+	case *ast.IndexExpr:
+		// Generic instantiation like "runtime.EventCallback[ModalResult]"
+		return extractTypeName(t.X) + "[" + extractTypeName(t.Index) + "]"
+	case *ast.IndexListExpr:
+		// Generic instantiation with several type arguments like "Pair[int, string]"
+		var args []string
+		for _, index := range t.Indices {
+			args = append(args, extractTypeName(index))
+		}
+		return extractTypeName(t.X) + "[" + strings.Join(args, ", ") + "]"
 	case *ast.FuncType:
 		// Function type like "func(result ModalResult)" or "func() string"
 		// We return a simplified representation that starts with "func"
@@ -221,6 +209,14 @@ func extractReturns(fields *ast.FieldList) []string {
 	return returns
 }
 
+// isScopedSlotType reports whether a field type is a scoped slot: a function taking one
+// value and returning the node to render for it, like func(item User) *vdom.VNode.
+func isScopedSlotType(expr ast.Expr) bool {
+	fn, ok := expr.(*ast.FuncType)
+	return ok && fn.Params.NumFields() == 1 && fn.Results.NumFields() == 1 &&
+		extractTypeName(fn.Results.List[0].Type) == "*vdom.VNode"
+}
+
 // inspectStructInFile is a helper that inspects a specific struct type in a Go file.
 // It returns a schema with the struct's exported fields.
 func inspectStructInFile(path, structName string) (componentSchema, error) {
@@ -268,7 +264,9 @@ func inspectGoFile(path, structName string) (componentSchema, error) {
 		Props:   make(map[string]propertyDescriptor),
 		State:   make(map[string]propertyDescriptor),
 		Methods: make(map[string]methodDescriptor),
-		Slot:    nil,
+		Events:  make(map[string]propertyDescriptor),
+
+		ScopedSlots: make(map[string]propertyDescriptor),
 	}
 	fset := token.NewFileSet()
 	node, err := parser.ParseFile(fset, path, nil, 0)
@@ -276,19 +274,25 @@ func inspectGoFile(path, structName string) (componentSchema, error) {
 		return schema, err
 	}
 
-	var slotFields []propertyDescriptor // Track all slot fields for validation
-
 	ast.Inspect(node, func(n ast.Node) bool {
 		// Inspect for struct fields (Props)
 		if typeSpec, ok := n.(*ast.TypeSpec); ok && typeSpec.Name.Name == structName {
 			if structType, ok := typeSpec.Type.(*ast.StructType); ok {
+				// Generic component: Picker[T comparable]
+				if typeSpec.TypeParams != nil {
+					for _, param := range typeSpec.TypeParams.List {
+						for _, name := range param.Names {
+							schema.TypeParams = append(schema.TypeParams, name.Name)
+						}
+					}
+				}
 				for _, field := range structType.Fields.List {
 					if len(field.Names) > 0 && field.Names[0].IsExported() {
 						fieldName := field.Names[0].Name
 						goType := extractTypeName(field.Type)
 
-						// Check if field is marked as state via struct tag
-						isState := false
+						// Check if field is marked as state or as an event via struct tag
+						isState, isEvent := false, false
 						if field.Tag != nil {
 							tag := field.Tag.Value
 							// Parse struct tag - remove surrounding backticks
@@ -299,6 +303,10 @@ func inspectGoFile(path, structName string) (componentSchema, error) {
 							if strings.Contains(tag, `nojs:"state"`) {
 								isState = true
 							}
+							// Check for nojs:"event" tag
+							if strings.Contains(tag, `nojs:"event"`) {
+								isEvent = true
+							}
 						}
 
 						propDesc := propertyDescriptor{
@@ -307,9 +315,20 @@ func inspectGoFile(path, structName string) (componentSchema, error) {
 							GoType:        goType,
 						}
 
-						// Check if this is a content slot field ([]*vdom.VNode)
-						if goType == "[]*vdom.VNode" {
-							slotFields = append(slotFields, propDesc)
+						if isEvent {
+							// Event field - bound with @onname on the component tag, not as a prop
+							if !strings.HasPrefix(goType, "func(") && !strings.Contains(goType, "EventCallback[") {
+								fmt.Fprintf(os.Stderr, "%c Compilation Error: could not inspect Go file %s: event field '%s.%s' has type '%s'. Fields tagged nojs:\"event\" must be a func type or a runtime.EventCallback[T]\n",
+									IconError, path, structName, fieldName, goType)
+								os.Exit(1)
+							}
+							schema.Events[strings.ToLower(fieldName)] = propDesc
+						} else if goType == "[]*vdom.VNode" {
+							// Content slot field ([]*vdom.VNode)
+							schema.Slots = append(schema.Slots, propDesc)
+						} else if isScopedSlotType(field.Type) {
+							// Scoped slot field (func(item T) *vdom.VNode), filled with a <name let:v> block
+							schema.ScopedSlots[strings.ToLower(fieldName)] = propDesc
 						} else if !isState {
 							// Regular prop field - only add if not marked as state
 							schema.Props[strings.ToLower(fieldName)] = propDesc
@@ -328,6 +347,13 @@ func inspectGoFile(path, structName string) (componentSchema, error) {
 			if starExpr, ok := recv.(*ast.StarExpr); ok {
 				recv = starExpr.X
 			}
+			// Methods of a generic component name its type parameters: (c *Picker[T])
+			switch generic := recv.(type) {
+			case *ast.IndexExpr:
+				recv = generic.X
+			case *ast.IndexListExpr:
+				recv = generic.X
+			}
 			if typeIdent, ok := recv.(*ast.Ident); ok && typeIdent.Name == structName {
 				if funcDecl.Name.IsExported() {
 					methodDesc := methodDescriptor{
@@ -343,21 +369,5 @@ func inspectGoFile(path, structName string) (componentSchema, error) {
 		return true
 	})
 
-	// Validate single slot constraint
-	if len(slotFields) > 1 {
-		var fieldNames []string
-		for _, sf := range slotFields {
-			fieldNames = append(fieldNames, sf.Name)
-		}
-		fmt.Fprintf(os.Stderr, "%c Compilation Error: could not inspect Go file %s: component '%s' has multiple content slot fields: [%s]. Only one []*vdom.VNode field is allowed per component\n",
-			IconError, path, structName, strings.Join(fieldNames, ", "))
-		os.Exit(1)
-	}
-
-	// Set the single slot field if found
-	if len(slotFields) == 1 {
-		schema.Slot = &slotFields[0]
-	}
-
 	return schema, nil
 }
@@ -0,0 +1,738 @@
+package compiler
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/scanner"
+	"go/token"
+	"go/types"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// templateSegment is a piece of template text: either literal text or the Go expression
+// inside a {…} binding.
+type templateSegment struct {
+	Text      string
+	IsBinding bool
+}
+
+// scanBindings splits text into literal and {expression} segments.
+// Braces inside Go string, rune and raw string literals do not end an expression and nested
+// braces (e.g. composite literals) are balanced. {@…} directives and an unclosed '{' are kept
+// as literal text.
+func scanBindings(text string) []templateSegment {
+	var segments []templateSegment
+	var literal strings.Builder
+
+	flushLiteral := func() {
+		if literal.Len() > 0 {
+			segments = append(segments, templateSegment{Text: literal.String()})
+			literal.Reset()
+		}
+	}
+
+	for i := 0; i < len(text); {
+		if text[i] != '{' {
+			literal.WriteByte(text[i])
+			i++
+			continue
+		}
+
+		end := matchingBrace(text, i)
+		if end < 0 {
+			// Unclosed brace: the rest is literal text.
+			literal.WriteString(text[i:])
+			break
+		}
+		if strings.HasPrefix(text[i+1:], "@") {
+			// Template directive such as {@for} mentioned in prose.
+			literal.WriteString(text[i : end+1])
+			i = end + 1
+			continue
+		}
+
+		flushLiteral()
+		segments = append(segments, templateSegment{Text: text[i+1 : end], IsBinding: true})
+		i = end + 1
+	}
+	flushLiteral()
+
+	return segments
+}
+
+// matchingBrace returns the index of the '}' that closes the '{' at text[open], or -1.
+func matchingBrace(text string, open int) int {
+	depth := 0
+	for i := open; i < len(text); i++ {
+		switch text[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		case '"', '\'', '`':
+			i = skipGoLiteral(text, i)
+			if i < 0 {
+				return -1
+			}
+		}
+	}
+	return -1
+}
+
+// skipGoLiteral returns the index of the closing quote of the string, rune or raw string
+// literal starting at text[start], or -1 if it is not closed.
+func skipGoLiteral(text string, start int) int {
+	quote := text[start]
+	for i := start + 1; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			if quote != '`' {
+				i++
+			}
+		case quote:
+			return i
+		}
+	}
+	return -1
+}
+
+// hasBindings reports whether any segment is a {…} binding.
+func hasBindings(segments []templateSegment) bool {
+	for _, seg := range segments {
+		if seg.IsBinding {
+			return true
+		}
+	}
+	return false
+}
+
+// ternaryBranchesRegex matches the branches of {condition ? 'value1' : 'value2'}.
+var ternaryBranchesRegex = regexp.MustCompile(`^\s*'([^']*)'\s*:\s*'([^']*)'\s*$`)
+
+// splitTernary splits a binding of the form `condition ? 'a' : 'b'`. Go has no '?' operator,
+// so a '?' outside literals and brackets always marks a template ternary.
+// It returns ok=false when the binding is not a ternary and malformed=true when it has a
+// '?' but its branches are not single-quoted strings.
+func splitTernary(expr string) (cond, trueVal, falseVal string, ok, malformed bool) {
+	depth := 0
+	for i := 0; i < len(expr); i++ {
+		switch expr[i] {
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			depth--
+		case '"', '`':
+			if i = skipGoLiteral(expr, i); i < 0 {
+				return "", "", "", false, false
+			}
+		case '\'':
+			// Inside the condition a quote starts a rune literal.
+			if i = skipGoLiteral(expr, i); i < 0 {
+				return "", "", "", false, false
+			}
+		case '?':
+			if depth != 0 {
+				continue
+			}
+			match := ternaryBranchesRegex.FindStringSubmatch(expr[i+1:])
+			if match == nil {
+				return "", "", "", false, true
+			}
+			return expr[:i], match[1], match[2], true, false
+		}
+	}
+	return "", "", "", false, false
+}
+
+// exprSource is a template expression together with its position in the template.
+type exprSource struct {
+	Text string // Expression without the surrounding braces
+	Line int    // Template line of the first character of Text
+	Col  int    // Template column (1-based, in bytes) of the first character of Text
+}
+
+// exprChecker parses template expressions with go/parser and type-checks them with go/types
+// against the component struct. Component fields and methods can be used by their bare name
+// ({Count}, {FormatPrice(p.Cents)}); the checker rewrites them to receiver selectors.
+type exprChecker struct {
+	comp     componentInfo
+	receiver string
+	source   string // Original (not preprocessed) template source, for error positions
+	pkg      *packageTypes
+	members  map[string]types.Type     // Bare names visible in templates, by member name
+	aliases  map[string]string         // Lowercase alias -> member name (e.g. {id} -> ID)
+	imports  map[string]*types.PkgName // Packages used by expressions, by local name
+	required map[string]string         // Local names of packages generated code refers to, by import path
+}
+
+// newExprChecker prepares the type environment for one component template.
+func newExprChecker(comp componentInfo, receiver, source string) (*exprChecker, error) {
+	pkgTypes, named, err := componentNamedType(comp)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &exprChecker{
+		comp:     comp,
+		receiver: receiver,
+		source:   source,
+		pkg:      pkgTypes,
+		members:  make(map[string]types.Type),
+		aliases:  make(map[string]string),
+		imports:  make(map[string]*types.PkgName),
+		required: make(map[string]string),
+	}
+
+	// A generic component is instantiated with its own type parameters, so its fields and the
+	// methods declared on (c *Picker[T]) share one T.
+	if tparams := named.TypeParams(); tparams.Len() > 0 {
+		args := make([]types.Type, tparams.Len())
+		for i := range args {
+			args[i] = tparams.At(i)
+		}
+		instance, err := types.Instantiate(nil, named, args, false)
+		if err != nil {
+			return nil, err
+		}
+		named = instance.(*types.Named)
+	}
+
+	// Fields, including those promoted from embedded structs, and the pointer method set.
+	ptr := types.NewPointer(named)
+	var names []string
+	var collectFields func(s *types.Struct, depth int)
+	collectFields = func(s *types.Struct, depth int) {
+		for i := 0; i < s.NumFields(); i++ {
+			field := s.Field(i)
+			names = append(names, field.Name())
+			if field.Embedded() && depth < 8 {
+				if inner, ok := derefType(field.Type()).Underlying().(*types.Struct); ok {
+					collectFields(inner, depth+1)
+				}
+			}
+		}
+	}
+	collectFields(named.Underlying().(*types.Struct), 0)
+	methods := types.NewMethodSet(ptr)
+	for i := 0; i < methods.Len(); i++ {
+		names = append(names, methods.At(i).Obj().Name())
+	}
+
+	for _, name := range names {
+		if _, done := e.members[name]; done || name == "_" {
+			continue
+		}
+		obj, _, _ := types.LookupFieldOrMethod(ptr, true, pkgTypes.Types, name)
+		switch member := obj.(type) {
+		case *types.Var:
+			e.members[name] = member.Type()
+		case *types.Func:
+			// A method value has the method's signature without the receiver.
+			sig := member.Type().(*types.Signature)
+			e.members[name] = types.NewSignatureType(nil, nil, nil, sig.Params(), sig.Results(), sig.Variadic())
+		}
+	}
+
+	// Templates have always matched component fields case-insensitively ({id} -> c.ID).
+	for _, fields := range []map[string]propertyDescriptor{comp.Schema.Props, comp.Schema.State} {
+		for lower, desc := range fields {
+			if _, exact := e.members[lower]; !exact && lower != desc.Name {
+				e.aliases[lower] = desc.Name
+			}
+		}
+	}
+
+	return e, nil
+}
+
+// derefType strips one level of pointer indirection.
+func derefType(t types.Type) types.Type {
+	if ptr, ok := t.Underlying().(*types.Pointer); ok {
+		return ptr.Elem()
+	}
+	return t
+}
+
+// typeString formats a type for error messages: relative to the component's package, with
+// other packages qualified by name (modal.ModalResult).
+func (e *exprChecker) typeString(t types.Type) string {
+	return types.TypeString(t, func(p *types.Package) string {
+		if p == e.pkg.Types {
+			return ""
+		}
+		return p.Name()
+	})
+}
+
+// typeCode formats a type for use in generated code, recording an import for every other
+// package it refers to.
+func (e *exprChecker) typeCode(t types.Type) string {
+	return types.TypeString(t, func(p *types.Package) string {
+		if p == e.pkg.Types {
+			return ""
+		}
+		return e.importName(p.Path(), p.Name())
+	})
+}
+
+// importName returns the local name generated code uses for the package at path, recording
+// the import. The package keeps its own name unless another package already uses it, in
+// which case it is numbered (models2).
+func (e *exprChecker) importName(path, name string) string {
+	if local, ok := e.required[path]; ok {
+		return local
+	}
+	for local, pkgName := range e.pkg.Imports {
+		if pkgName.Imported().Path() == path {
+			e.required[path] = local
+			return local
+		}
+	}
+	local := name
+	for i := 2; e.nameTaken(local, path); i++ {
+		local = fmt.Sprintf("%s%d", name, i)
+	}
+	e.required[path] = local
+	return local
+}
+
+// nameTaken reports whether name refers to something other than the package at path in the
+// generated file: another import or a package-level declaration.
+func (e *exprChecker) nameTaken(name, path string) bool {
+	if existing, ok := generatedFileImports[name]; ok {
+		return existing != path
+	}
+	if pkgName, ok := e.pkg.Imports[name]; ok {
+		return pkgName.Imported().Path() != path
+	}
+	for other, local := range e.required {
+		if local == name && other != path {
+			return true
+		}
+	}
+	return e.pkg.Types.Scope().Lookup(name) != nil
+}
+
+// usedImports returns the packages referenced by checked expressions and generated code, by local name.
+func (e *exprChecker) usedImports() map[string]string {
+	result := make(map[string]string, len(e.imports)+len(e.required))
+	for name, pkgName := range e.imports {
+		result[name] = pkgName.Imported().Path()
+	}
+	for path, name := range e.required {
+		result[name] = path
+	}
+	return result
+}
+
+// requireImport records a standard library package that generated code (rather than a
+// template expression) refers to, such as slices for sorted map iteration, and returns the
+// name to refer to it by.
+func (e *exprChecker) requireImport(path string) string {
+	return e.importName(path, path[strings.LastIndex(path, "/")+1:])
+}
+
+// locate finds the template position of the binding {expr}, searching from lineHint onwards.
+// The HTML parser decodes entities, so when the binding cannot be found verbatim the
+// position falls back to the start of lineHint.
+func (e *exprChecker) locate(expr string, lineHint int) exprSource {
+	return e.locateIn("{", expr, "}", lineHint)
+}
+
+// locateIn finds the template position of expr written between prefix and suffix (e.g. "{@if "
+// and "}" for a directive condition), searching from lineHint onwards.
+func (e *exprChecker) locateIn(prefix, expr, suffix string, lineHint int) exprSource {
+	needle := prefix + expr + suffix
+	lineStart := 0
+	if lineHint > 1 {
+		for line := 1; line < lineHint; line++ {
+			next := strings.IndexByte(e.source[lineStart:], '\n')
+			if next < 0 {
+				break
+			}
+			lineStart += next + 1
+		}
+	}
+
+	idx := strings.Index(e.source[lineStart:], needle)
+	if idx >= 0 {
+		idx += lineStart
+	} else {
+		idx = strings.Index(e.source, needle)
+	}
+	if idx < 0 {
+		return exprSource{Text: expr, Line: max(lineHint, 1), Col: 1}
+	}
+
+	exprStart := idx + len(prefix)
+	line := strings.Count(e.source[:exprStart], "\n") + 1
+	col := exprStart - (strings.LastIndex(e.source[:exprStart], "\n") + 1) + 1
+	return exprSource{Text: expr, Line: line, Col: col}
+}
+
+// position maps a line/column inside src.Text to a template line/column.
+func (src exprSource) position(line, col int) (int, int) {
+	if line <= 1 {
+		return src.Line, src.Col + col - 1
+	}
+	return src.Line + line - 1, col
+}
+
+// check parses and type-checks a single Go expression, returning the generated Go code and
+// its type. On failure it reports the error at the template line:column and exits.
+func (e *exprChecker) check(src exprSource, loopCtx *loopContext) (string, types.Type) {
+	code, tv := e.checkValue(src, loopCtx)
+	return code, tv.Type
+}
+
+// checkValue is check, also returning the constant value of constant expressions.
+func (e *exprChecker) checkValue(src exprSource, loopCtx *loopContext) (string, types.TypeAndValue) {
+	code, tv, _ := e.typeCheck(src, loopCtx, nil)
+	switch {
+	case tv.IsType():
+		e.fail(src, 1, 1, fmt.Sprintf("%s is a type, not a value", src.Text), loopCtx)
+	case tv.IsVoid():
+		e.fail(src, 1, 1, fmt.Sprintf("%s (no value) used as value", strings.TrimSpace(src.Text)), loopCtx)
+	case tv.IsNil() || !tv.IsValue():
+		e.fail(src, 1, 1, fmt.Sprintf("%s is not a value that can be rendered", strings.TrimSpace(src.Text)), loopCtx)
+	}
+	return code, tv
+}
+
+// typeCheck parses and type-checks a Go expression and rewrites component members to receiver
+// selectors. locals are declared between the component members and the loop variables, so
+// they shadow members and are shadowed by loop variables. The returned Info records which
+// objects the expression uses.
+func (e *exprChecker) typeCheck(src exprSource, loopCtx *loopContext, locals []*types.Var) (string, types.TypeAndValue, *types.Info) {
+	fset := e.pkg.Fset
+	expr, err := parser.ParseExprFrom(fset, e.comp.Path, src.Text, 0)
+	if err != nil {
+		line, col := 1, 1
+		msg := err.Error()
+		if list, ok := err.(scanner.ErrorList); ok && len(list) > 0 {
+			line, col, msg = list[0].Pos.Line, list[0].Pos.Column, list[0].Msg
+		}
+		e.fail(src, line, col, fmt.Sprintf("invalid Go expression: %s", msg), loopCtx)
+	}
+
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if lit, ok := n.(*ast.FuncLit); ok {
+			pos := fset.Position(lit.Pos())
+			e.fail(src, pos.Line, pos.Column, "function literals are not supported in template expressions", loopCtx)
+		}
+		return true
+	})
+
+	// The expression gets its own scope between the package scope and any loop scopes.
+	file := fset.File(expr.Pos())
+	base := token.Pos(file.Base())
+	end := base + token.Pos(file.Size()) + 1
+	scope := types.NewScope(e.pkg.Types.Scope(), base, end, "template expression")
+
+	memberVars := make(map[types.Object]string)
+	for name, typ := range e.members {
+		v := types.NewVar(token.NoPos, e.pkg.Types, name, typ)
+		scope.Insert(v)
+		memberVars[v] = name
+	}
+	for name, pkgName := range e.pkg.Imports {
+		if scope.Lookup(name) == nil {
+			scope.Insert(pkgName)
+		}
+	}
+	// Aliases never hide imports, package-level names or builtins such as len.
+	for alias, name := range e.aliases {
+		if _, obj := scope.LookupParent(alias, token.NoPos); obj != nil {
+			continue
+		}
+		v := types.NewVar(token.NoPos, e.pkg.Types, alias, e.members[name])
+		scope.Insert(v)
+		memberVars[v] = name
+	}
+
+	if len(locals) > 0 {
+		scope = types.NewScope(scope, base, end, "template locals")
+		for _, v := range locals {
+			scope.Insert(v)
+		}
+	}
+
+	// Loop variables shadow component members, innermost loop last.
+	var loops []*loopContext
+	for l := loopCtx; l != nil; l = l.Parent {
+		loops = append([]*loopContext{l}, loops...)
+	}
+	for _, l := range loops {
+		scope = types.NewScope(scope, base, end, "template loop")
+		for _, v := range []struct {
+			name string
+			typ  types.Type
+		}{{l.IndexVar, l.IndexType}, {l.ValueVar, l.ValueType}} {
+			if v.name == "" || v.name == "_" || v.typ == nil {
+				continue
+			}
+			scope.Insert(types.NewVar(token.NoPos, e.pkg.Types, v.name, v.typ))
+		}
+	}
+
+	info := &types.Info{
+		Types: make(map[ast.Expr]types.TypeAndValue),
+		Uses:  make(map[*ast.Ident]types.Object),
+	}
+	if err := types.CheckExpr(fset, e.pkg.Types, base, expr, info); err != nil {
+		line, col := 1, 1
+		msg := err.Error()
+		if typeErr, ok := err.(types.Error); ok {
+			pos := fset.Position(typeErr.Pos)
+			line, col, msg = pos.Line, pos.Column, typeErr.Msg
+		}
+		e.fail(src, line, col, msg, loopCtx)
+	}
+
+	tv := info.Types[expr]
+	for _, obj := range info.Uses {
+		if pkgName, ok := obj.(*types.PkgName); ok {
+			e.imports[pkgName.Name()] = pkgName
+		}
+	}
+
+	// Qualify component members with the receiver: Count -> c.Count, id -> c.ID.
+	rewritten := astutil.Apply(expr, nil, func(cur *astutil.Cursor) bool {
+		if id, ok := cur.Node().(*ast.Ident); ok {
+			if name, isMember := memberVars[info.Uses[id]]; isMember {
+				cur.Replace(&ast.SelectorExpr{X: ast.NewIdent(e.receiver), Sel: ast.NewIdent(name)})
+			}
+		}
+		return true
+	})
+
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, fset, rewritten); err != nil {
+		e.fail(src, 1, 1, err.Error(), loopCtx)
+	}
+	return buf.String(), tv, info
+}
+
+// compileBinding compiles the contents of one {…} binding. A ternary
+// {condition ? 'a' : 'b'} compiles to a string-valued function literal whose condition
+// may be any bool expression.
+func (e *exprChecker) compileBinding(expr string, lineHint int, loopCtx *loopContext) (string, types.Type) {
+	src := e.locate(expr, lineHint)
+
+	cond, trueVal, falseVal, isTernary, malformed := splitTernary(expr)
+	if malformed {
+		e.fail(src, 1, 1, "ternary expressions must have single-quoted string branches: {condition ? 'value1' : 'value2'}", loopCtx)
+	}
+	if !isTernary {
+		return e.check(src, loopCtx)
+	}
+
+	condSrc := exprSource{Text: cond, Line: src.Line, Col: src.Col}
+	condCode, condType := e.check(condSrc, loopCtx)
+	if !isBoolType(condType) {
+		e.fail(condSrc, 1, 1, fmt.Sprintf("ternary condition must be a bool expression, found type '%s'", e.typeString(condType)), loopCtx)
+	}
+	return generateTernaryExpression(condCode, trueVal, falseVal), types.Typ[types.String]
+}
+
+// compileType type-checks a Go type written in an attribute value, such as the type argument
+// of a generic component (type:T="models.User").
+func (e *exprChecker) compileType(expr string, lineHint int, loopCtx *loopContext) types.Type {
+	src := e.locateIn(`"`, expr, `"`, lineHint)
+	_, tv, _ := e.typeCheck(src, loopCtx, nil)
+	if !tv.IsType() {
+		e.fail(src, 1, 1, fmt.Sprintf("%s is not a type", expr), loopCtx)
+	}
+	return tv.Type
+}
+
+// compileCondition compiles the condition of a {@if} or {@else if} directive at lineHint, which
+// must be a bool expression over the component's members and the loop variables in scope.
+func (e *exprChecker) compileCondition(directive, cond string, lineHint int, loopCtx *loopContext) string {
+	src := e.locateIn("{@"+directive+" ", cond, "}", lineHint)
+	code, typ := e.check(src, loopCtx)
+	if !isBoolType(typ) {
+		e.fail(src, 1, 1, fmt.Sprintf("{@%s} condition must be a bool expression, found type '%s'", directive, e.typeString(typ)), loopCtx)
+	}
+	return code
+}
+
+// splitExprList splits a comma-separated list of Go expressions (e.g. the values of a
+// {@case}) at top-level commas. Each part keeps its position inside src.
+func splitExprList(src exprSource) []exprSource {
+	fset := token.NewFileSet()
+	file := fset.AddFile("", -1, len(src.Text))
+	var s scanner.Scanner
+	s.Init(file, []byte(src.Text), nil, 0)
+
+	var bounds []int
+	depth := 0
+	for {
+		pos, tok, _ := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+		switch tok {
+		case token.LPAREN, token.LBRACK, token.LBRACE:
+			depth++
+		case token.RPAREN, token.RBRACK, token.RBRACE:
+			depth--
+		case token.COMMA:
+			if depth == 0 {
+				bounds = append(bounds, file.Offset(pos))
+			}
+		}
+	}
+	bounds = append(bounds, len(src.Text))
+
+	var parts []exprSource
+	start := 0
+	for _, end := range bounds {
+		raw := src.Text[start:end]
+		offset := start + len(raw) - len(strings.TrimLeft(raw, " \t\r\n"))
+		line := strings.Count(src.Text[:offset], "\n") + 1
+		col := offset - (strings.LastIndex(src.Text[:offset], "\n") + 1) + 1
+		line, col = src.position(line, col)
+		parts = append(parts, exprSource{Text: strings.TrimSpace(raw), Line: line, Col: col})
+		start = end + 1
+	}
+	return parts
+}
+
+// compileInterpolation compiles text containing {…} bindings into a Go string expression.
+// Text without bindings becomes a string literal.
+func (e *exprChecker) compileInterpolation(text string, lineHint int, loopCtx *loopContext) string {
+	segments := scanBindings(text)
+	if !hasBindings(segments) {
+		return strconv.Quote(text)
+	}
+
+	// A lone ternary is already a string.
+	if len(segments) == 1 {
+		if _, _, _, isTernary, _ := splitTernary(segments[0].Text); isTernary {
+			code, _ := e.compileBinding(segments[0].Text, lineHint, loopCtx)
+			return code
+		}
+	}
+
+	var format strings.Builder
+	var args []string
+	for _, seg := range segments {
+		if !seg.IsBinding {
+			format.WriteString(strings.ReplaceAll(seg.Text, "%", "%%"))
+			continue
+		}
+		code, _ := e.compileBinding(seg.Text, lineHint, loopCtx)
+		format.WriteString("%v")
+		args = append(args, code)
+	}
+	return fmt.Sprintf(`fmt.Sprintf(%s, %s)`, strconv.Quote(format.String()), strings.Join(args, ", "))
+}
+
+// compileNodeBinding compiles text that is a single {…} binding of type *vdom.VNode, such as
+// a call to a scoped slot ({Row(item)}), into the node it renders. ok is false for any
+// other text, which is compiled as an interpolation instead.
+func (e *exprChecker) compileNodeBinding(text string, lineHint int, loopCtx *loopContext) (code string, ok bool) {
+	segments := scanBindings(strings.TrimSpace(text))
+	if len(segments) != 1 || !segments[0].IsBinding {
+		return "", false
+	}
+	if _, _, _, isTernary, _ := splitTernary(segments[0].Text); isTernary {
+		return "", false
+	}
+	code, typ := e.compileBinding(segments[0].Text, lineHint, loopCtx)
+	if !isVNodeType(typ) {
+		return "", false
+	}
+	return code, true
+}
+
+// isVNodeType reports whether t is *vdom.VNode.
+func isVNodeType(t types.Type) bool {
+	ptr, ok := t.(*types.Pointer)
+	if !ok {
+		return false
+	}
+	named, ok := ptr.Elem().(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return false
+	}
+	return named.Obj().Name() == "VNode" && named.Obj().Pkg().Path() == generatedFileImports["vdom"]
+}
+
+// isBoolType reports whether t is bool or a type whose underlying type is bool.
+func isBoolType(t types.Type) bool {
+	basic, ok := t.Underlying().(*types.Basic)
+	return ok && basic.Info()&types.IsBoolean != 0
+}
+
+// isUntypedType reports whether t is the type of an untyped constant.
+func isUntypedType(t types.Type) bool {
+	basic, ok := t.(*types.Basic)
+	return ok && basic.Info()&types.IsUntyped != 0
+}
+
+// fail reports an expression error at a line/column inside src and exits.
+func (e *exprChecker) fail(src exprSource, line, col int, msg string, loopCtx *loopContext) {
+	line, col = src.position(line, col)
+
+	var hint string
+	if strings.HasPrefix(msg, "undefined:") {
+		available := make([]string, 0, len(e.members))
+		for name := range e.members {
+			if ast.IsExported(name) {
+				available = append(available, name)
+			}
+		}
+		sort.Strings(available)
+		hint = fmt.Sprintf("Available component fields and methods: [%s]\n", strings.Join(available, ", "))
+		for l := loopCtx; l != nil; l = l.Parent {
+			hint += fmt.Sprintf("Loop variables in scope: %s, %s\n", l.IndexVar, l.ValueVar)
+		}
+	}
+
+	// Place the caret directly under the highlighted line of the context block
+	context := getContextLines(e.source, line, 2)
+	if idx := strings.Index(context, "\n> "); idx >= 0 {
+		end := idx + 1 + strings.Index(context[idx+1:], "\n") + 1
+		context = context[:end] + caretLine(e.source, line, col) + context[end:]
+	}
+
+	fmt.Fprintf(os.Stderr, "Compilation Error in %s:%d:%d: %s\n  in expression {%s}\n%s%s",
+		e.comp.Path, line, col, msg, strings.TrimSpace(src.Text), context, hint)
+	os.Exit(1)
+}
+
+// caretLine returns a "^" marker aligned under column col of the given line, matching the
+// layout of getContextLines.
+func caretLine(source string, line, col int) string {
+	lines := strings.Split(source, "\n")
+	if line < 1 || line > len(lines) {
+		return ""
+	}
+	var pad strings.Builder
+	pad.WriteString("         ") // Width of the "> 1234 | " prefix
+	for i, ch := range lines[line-1] {
+		if i >= col-1 {
+			break
+		}
+		if ch == '\t' {
+			pad.WriteByte('\t')
+		} else {
+			pad.WriteByte(' ')
+		}
+	}
+	return pad.String() + "^\n"
+}
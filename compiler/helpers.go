@@ -2,10 +2,13 @@ package compiler
 
 import (
 	"fmt"
+	"os"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
 )
 
 // estimateLineNumber tries to find the approximate line number where text appears in HTML source.
@@ -147,6 +150,25 @@ func getAvailableFieldNames(props map[string]propertyDescriptor) []string {
 	return names
 }
 
+// findSlot returns the content slot field of a component with the given name, ignoring case.
+func findSlot(schema componentSchema, name string) (propertyDescriptor, bool) {
+	for _, slot := range schema.Slots {
+		if slot.LowercaseName == strings.ToLower(name) {
+			return slot, true
+		}
+	}
+	return propertyDescriptor{}, false
+}
+
+// getSlotNames returns the content slot field names of a component for error messages.
+func getSlotNames(schema componentSchema) []string {
+	names := make([]string, 0, len(schema.Slots))
+	for _, slot := range schema.Slots {
+		names = append(names, slot.Name)
+	}
+	return names
+}
+
 // getAvailableMethodNames returns a comma-separated string of available method names for error messages.
 func getAvailableMethodNames(methods map[string]methodDescriptor) string {
 	var names []string
@@ -177,29 +199,49 @@ func findEventLineNumber(n *html.Node, eventName, htmlSource string) int {
 	return lineNumber
 }
 
-// findBody finds the <body> node in the parsed HTML.
-func findBody(n *html.Node) *html.Node {
-	if n.Type == html.ElementNode && n.Data == "body" {
-		return n
+// parseTemplate parses template markup as the content of a <template> element and returns
+// a document node holding its top-level nodes. In that context the parser keeps top-level
+// table rows, cells and list items, so a component can render a set of rows.
+func parseTemplate(src string) (*html.Node, error) {
+	context := &html.Node{Type: html.ElementNode, Data: "template", DataAtom: atom.Template}
+	nodes, err := html.ParseFragment(strings.NewReader(src), context)
+	if err != nil {
+		return nil, err
+	}
+	doc := &html.Node{Type: html.DocumentNode}
+	for _, n := range nodes {
+		doc.AppendChild(n)
 	}
+	return doc, nil
+}
 
-	for c := n.FirstChild; c != nil; c = c.NextSibling {
-		if result := findBody(c); result != nil {
-			return result
+// findRootNodes returns the top-level nodes of a template: its elements, directive
+// placeholders and non-blank text. Comments and whitespace between elements are skipped.
+func findRootNodes(doc *html.Node) []*html.Node {
+	var roots []*html.Node
+	for c := doc.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode || (c.Type == html.TextNode && strings.TrimSpace(c.Data) != "") {
+			roots = append(roots, c)
 		}
 	}
-
-	return nil
+	return roots
 }
 
-// findFirstElementChild finds the first actual element inside a node.
-func findFirstElementChild(n *html.Node) *html.Node {
-	for c := n.FirstChild; c != nil; c = c.NextSibling {
-		if c.Type == html.ElementNode {
-			return c
+// getAttr returns the value of the named attribute, or "" if n does not have it.
+func getAttr(n *html.Node, key string) string {
+	for _, attr := range n.Attr {
+		if attr.Key == key {
+			return attr.Val
 		}
 	}
-	return nil
+	return ""
+}
+
+// directiveLine returns the template line of the directive a placeholder node was written
+// for, recorded by the preprocessor in its data-line attribute, or 0 if it has none.
+func directiveLine(n *html.Node) int {
+	line, _ := strconv.Atoi(getAttr(n, "data-line"))
+	return line
 }
 
 // childCount is a helper function to count preceding element siblings for key generation.
@@ -222,3 +264,16 @@ func findFirstElementChild(n *html.Node) *html.Node {
 
 // 	return count
 // }
+
+// templateError reports an invalid template construct at lineNumber, with context lines, and exits.
+func templateError(comp componentInfo, htmlSource string, lineNumber int, msg string) {
+	contextLines := getContextLines(htmlSource, lineNumber, 2)
+	fmt.Fprintf(os.Stderr, "Compilation Error in %s:%d: %s\n%s\n", comp.Path, lineNumber, msg, contextLines)
+	os.Exit(1)
+}
+
+// templateWarning prints a warning for a template line with context; compilation continues.
+func templateWarning(comp componentInfo, htmlSource string, lineNumber int, msg string) {
+	contextLines := getContextLines(htmlSource, lineNumber, 2)
+	fmt.Fprintf(os.Stderr, "Warning in %s:%d: %s\n%s\n", comp.Path, lineNumber, msg, contextLines)
+}
@@ -0,0 +1,150 @@
+package compiler
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// htmlschema.json describes the HTML elements, their attributes and the DOM events the
+// compiler validates templates against. It is generated by internal/htmlschemagen from the
+// WHATWG HTML Standard's element and attribute indices (https://html.spec.whatwg.org/multipage/indices.html);
+// obsolete elements and attributes are not in the indices, so using them produces a warning.
+// Run go generate to update it to the current spec.
+//
+//go:generate go run ./internal/htmlschemagen -o htmlschema.json
+//go:embed htmlschema.json
+var htmlSchemaJSON []byte
+
+// htmlSchema is the parsed htmlschema.json.
+var htmlSchema = mustParseHTMLSchema(htmlSchemaJSON)
+
+// attrKind is the kind of value an HTML attribute takes.
+type attrKind int
+
+const (
+	attrText    attrKind = iota // Free text, e.g. class, title
+	attrBoolean                 // Present or absent, e.g. disabled; any value means true
+	attrEnum                    // One of a fixed set of keywords, e.g. type="submit"
+	attrURL                     // A URL, e.g. href, src
+	attrNumber                  // A number, e.g. tabindex, colspan
+)
+
+// attrSpec is the schema of one attribute. In htmlschema.json it is written as its kind
+// ("text", "boolean", "url", "number") or, for enumerated attributes, as the list of keywords.
+type attrSpec struct {
+	Kind   attrKind
+	Values []string // Keywords of an enumerated attribute
+}
+
+func (a *attrSpec) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &a.Values); err == nil {
+		a.Kind = attrEnum
+		return nil
+	}
+	var kind string
+	if err := json.Unmarshal(data, &kind); err != nil {
+		return err
+	}
+	switch kind {
+	case "text":
+		a.Kind = attrText
+	case "boolean":
+		a.Kind = attrBoolean
+	case "url":
+		a.Kind = attrURL
+	case "number":
+		a.Kind = attrNumber
+	default:
+		return fmt.Errorf("unknown attribute kind %q", kind)
+	}
+	return nil
+}
+
+// elementSpec is the schema of one HTML element.
+type elementSpec struct {
+	Void       bool                // Has no content (<input>, <img>); events cannot bubble up to it
+	Metadata   bool                // Not rendered (<script>, <style>); fires no UI events
+	Attributes map[string]attrSpec // Element-specific attributes; global attributes apply as well
+}
+
+// eventSpec describes where the browser fires a DOM event.
+type eventSpec struct {
+	Bubbles bool     // Whether the event bubbles from its target to the target's ancestors
+	Targets []string // Elements the event is dispatched to; empty means any rendered element
+}
+
+type htmlSchemaData struct {
+	GlobalAttributes map[string]attrSpec    `json:"globalAttributes"`
+	Elements         map[string]elementSpec `json:"elements"`
+	Events           map[string]eventSpec   `json:"events"`
+}
+
+func mustParseHTMLSchema(data []byte) *htmlSchemaData {
+	var schema htmlSchemaData
+	if err := json.Unmarshal(data, &schema); err != nil {
+		panic(fmt.Sprintf("compiler: invalid htmlschema.json: %v", err))
+	}
+	return &schema
+}
+
+// element returns the schema of an HTML element, or false for unknown elements.
+func (s *htmlSchemaData) element(tagName string) (elementSpec, bool) {
+	el, ok := s.Elements[tagName]
+	return el, ok
+}
+
+// attribute returns the schema of an attribute on an element. data-* and aria-* attributes
+// are free text on every element.
+func (s *htmlSchemaData) attribute(tagName, attrName string) (attrSpec, bool) {
+	if strings.HasPrefix(attrName, "data-") || strings.HasPrefix(attrName, "aria-") {
+		return attrSpec{Kind: attrText}, true
+	}
+	if spec, ok := s.Elements[tagName].Attributes[attrName]; ok {
+		return spec, true
+	}
+	spec, ok := s.GlobalAttributes[attrName]
+	return spec, ok
+}
+
+// attributeNames returns the attributes known on an element, sorted.
+func (s *htmlSchemaData) attributeNames(tagName string) []string {
+	var names []string
+	for name := range s.GlobalAttributes {
+		names = append(names, name)
+	}
+	for name := range s.Elements[tagName].Attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// eventFiresOn reports whether a listener for eventName on tagName can run: the element is
+// one of the event's targets, or the event bubbles and the element can contain a target.
+// Unknown elements (custom elements) and events without a schema entry are accepted. When
+// the event cannot fire, the returned reason explains why.
+func (s *htmlSchemaData) eventFiresOn(eventName, tagName string) (bool, string) {
+	el, known := s.Elements[tagName]
+	if !known {
+		return true, ""
+	}
+	if el.Metadata {
+		return false, fmt.Sprintf("<%s> is not rendered and fires no events", tagName)
+	}
+	ev, ok := s.Events[eventName]
+	if !ok || len(ev.Targets) == 0 || slices.Contains(ev.Targets, tagName) {
+		return true, ""
+	}
+	targets := "<" + strings.Join(ev.Targets, ">, <") + ">"
+	if !ev.Bubbles {
+		return false, fmt.Sprintf("it is only dispatched to %s elements", targets)
+	}
+	if el.Void {
+		return false, fmt.Sprintf("it is dispatched to %s elements and bubbles to their ancestors, but <%s> cannot contain elements", targets, tagName)
+	}
+	return true, ""
+}
@@ -0,0 +1,50 @@
+package main
+
+// event describes where the browser fires a DOM event.
+type event struct {
+	Bubbles bool     // Whether the event bubbles from its target to the target's ancestors
+	Targets []string // Elements the event is dispatched to; empty means any rendered element
+}
+
+// events are the DOM events the compiler checks listener placement for. Most are defined
+// outside the HTML Standard (UI Events, Pointer Events, Touch Events, CSS Animations and
+// Transitions, Clipboard API), and its indices do not say whether an event bubbles, so they
+// are listed here instead of read from the spec.
+var events = map[string]event{
+	"onanimationend":     {Bubbles: true},
+	"onblur":             {Bubbles: false},
+	"onchange":           {Bubbles: true, Targets: []string{"input", "select", "textarea"}},
+	"onclick":            {Bubbles: true},
+	"oncompositionend":   {Bubbles: true},
+	"oncompositionstart": {Bubbles: true},
+	"oncontextmenu":      {Bubbles: true},
+	"oncopy":             {Bubbles: true},
+	"oncut":              {Bubbles: true},
+	"ondblclick":         {Bubbles: true},
+	"ondragend":          {Bubbles: true},
+	"ondragover":         {Bubbles: true},
+	"ondragstart":        {Bubbles: true},
+	"ondrop":             {Bubbles: true},
+	"onfocus":            {Bubbles: false},
+	"oninput":            {Bubbles: true},
+	"onkeydown":          {Bubbles: true},
+	"onkeypress":         {Bubbles: true},
+	"onkeyup":            {Bubbles: true},
+	"onmousedown":        {Bubbles: true},
+	"onmouseenter":       {Bubbles: false},
+	"onmouseleave":       {Bubbles: false},
+	"onmousemove":        {Bubbles: true},
+	"onmouseup":          {Bubbles: true},
+	"onpaste":            {Bubbles: true},
+	"onpointercancel":    {Bubbles: true},
+	"onpointerdown":      {Bubbles: true},
+	"onpointermove":      {Bubbles: true},
+	"onpointerup":        {Bubbles: true},
+	"onscroll":           {Bubbles: false},
+	"onsubmit":           {Bubbles: true, Targets: []string{"form"}},
+	"ontouchend":         {Bubbles: true},
+	"ontouchmove":        {Bubbles: true},
+	"ontouchstart":       {Bubbles: true},
+	"ontransitionend":    {Bubbles: true},
+	"onwheel":            {Bubbles: true},
+}
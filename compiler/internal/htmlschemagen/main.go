@@ -0,0 +1,357 @@
+// Command htmlschemagen writes the compiler's htmlschema.json from the WHATWG HTML Standard's
+// indices (https://html.spec.whatwg.org/multipage/indices.html): the elements come from the
+// element index, their attributes from the attribute index.
+//
+// Usage (from the compiler directory, or through go generate):
+//
+//	go run ./internal/htmlschemagen -o htmlschema.json
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+const specURL = "https://html.spec.whatwg.org/multipage/indices.html"
+
+func main() {
+	spec := flag.String("spec", specURL, "The URL or file path of the HTML Standard's indices page.")
+	out := flag.String("o", "htmlschema.json", "The file to write the schema to.")
+	flag.Parse()
+
+	doc, err := loadSpec(*spec)
+	if err != nil {
+		log.Fatalf("Reading the spec failed: %v", err)
+	}
+	schema, err := parseIndices(doc)
+	if err != nil {
+		log.Fatalf("Parsing the spec failed: %v", err)
+	}
+	if err := os.WriteFile(*out, schema.format(), 0644); err != nil {
+		log.Fatalf("Writing the schema failed: %v", err)
+	}
+	fmt.Printf("Wrote %s: %d elements, %d global attributes, %d events\n", *out, len(schema.Elements), len(schema.GlobalAttributes), len(events))
+}
+
+// loadSpec parses the indices page from a URL or a saved copy of it.
+func loadSpec(source string) (*html.Node, error) {
+	var r io.Reader
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		resp, err := http.Get(source)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("GET %s: %s", source, resp.Status)
+		}
+		r = resp.Body
+	} else {
+		f, err := os.Open(source)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+	return html.Parse(r)
+}
+
+// attr is an attribute's value in htmlschema.json: "text", "boolean", "url", "number", or
+// the keywords of an enumerated attribute.
+type attr struct {
+	Kind     string
+	Keywords []string
+}
+
+type element struct {
+	Void       bool
+	Metadata   bool
+	Attributes map[string]attr
+}
+
+type schema struct {
+	GlobalAttributes map[string]attr
+	Elements         map[string]*element
+}
+
+// parseIndices reads the element and attribute index tables. Obsolete elements and
+// attributes are not in the indices, so they stay out of the schema.
+func parseIndices(doc *html.Node) (*schema, error) {
+	elementRows := tableRows(doc, "List of elements")
+	attributeRows := tableRows(doc, "List of attributes")
+	if len(elementRows) == 0 || len(attributeRows) == 0 {
+		return nil, fmt.Errorf("the element and attribute index tables were not found")
+	}
+
+	s := &schema{GlobalAttributes: map[string]attr{}, Elements: map[string]*element{}}
+	for _, row := range elementRows {
+		// Element, Description, Categories, Parents, Children, Attributes, Interface
+		if len(row) < 5 {
+			continue
+		}
+		for _, name := range codeNames(row[0]) {
+			s.Elements[name] = &element{
+				Void:     text(row[4]) == "empty",
+				Metadata: name == "head" || slices.Contains(splitList(text(row[2])), "metadata"),
+			}
+		}
+	}
+	for _, row := range attributeRows {
+		// Attribute, Element(s), Description, Value
+		if len(row) < 4 {
+			continue
+		}
+		value := attrValue(row[0], row[3])
+		for _, name := range codeNames(row[0]) {
+			if strings.Contains(text(row[1]), "HTML elements") {
+				s.GlobalAttributes[name] = value
+				continue
+			}
+			for _, tag := range codeNames(row[1]) {
+				el, ok := s.Elements[tag]
+				if !ok {
+					continue
+				}
+				if el.Attributes == nil {
+					el.Attributes = map[string]attr{}
+				}
+				el.Attributes[name] = value
+			}
+		}
+	}
+	return s, nil
+}
+
+var numberValue = regexp.MustCompile(`^Valid (non-negative )?(integer|floating-point number)( greater than zero)?$`)
+
+// referencedKeywords are the keywords of values the attribute index names rather than lists.
+var referencedKeywords = map[string][]string{
+	"referrer policy":    {"", "no-referrer", "no-referrer-when-downgrade", "same-origin", "origin", "strict-origin", "origin-when-cross-origin", "strict-origin-when-cross-origin", "unsafe-url"},
+	"input type keyword": {"hidden", "text", "search", "tel", "url", "email", "password", "date", "month", "week", "time", "datetime-local", "number", "range", "color", "checkbox", "radio", "file", "submit", "image", "reset", "button"},
+}
+
+// emptyKeyword lists the enumerated attributes whose empty value maps to a state
+// (crossorigin="" is anonymous) although the index lists only the named keywords.
+var emptyKeyword = map[string]bool{
+	"autocorrect": true, "contenteditable": true, "crossorigin": true, "popover": true,
+	"preload": true, "spellcheck": true, "translate": true, "writingsuggestions": true,
+}
+
+// attrValue reads the kind of an attribute from the Value column of the attribute index.
+func attrValue(nameCell, valueCell *html.Node) attr {
+	value := strings.TrimRight(text(valueCell), "* ")
+	if keywords, ok := referencedKeywords[strings.ToLower(value)]; ok {
+		return attr{Keywords: keywords}
+	}
+	switch {
+	case strings.HasPrefix(value, "Boolean attribute"):
+		return attr{Kind: "boolean"}
+	case strings.HasPrefix(value, "Valid URL"), strings.HasPrefix(value, "Valid non-empty URL"):
+		return attr{Kind: "url"}
+	case numberValue.MatchString(value):
+		return attr{Kind: "number"}
+	}
+
+	// Enumerated: "on"; "off"; the empty string
+	var keywords []string
+	empty := false
+	for _, item := range strings.Split(value, ";") {
+		item = strings.TrimSpace(item)
+		switch {
+		case item == "the empty string":
+			empty = true
+		case len(item) > 2 && strings.HasPrefix(item, `"`) && strings.HasSuffix(item, `"`):
+			keywords = append(keywords, item[1:len(item)-1])
+		default:
+			return attr{Kind: "text"}
+		}
+	}
+	if names := codeNames(nameCell); len(names) > 0 && emptyKeyword[names[0]] {
+		empty = true
+	}
+	if empty {
+		keywords = append([]string{""}, keywords...)
+	}
+	return attr{Keywords: keywords}
+}
+
+// tableRows returns the cells of each body row of the table whose caption starts with
+// caption.
+func tableRows(doc *html.Node, caption string) [][]*html.Node {
+	var rows [][]*html.Node
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode && n.DataAtom == atom.Table && strings.HasPrefix(tableCaption(n), caption) {
+			for body := n.FirstChild; body != nil; body = body.NextSibling {
+				if body.DataAtom != atom.Tbody {
+					continue
+				}
+				for tr := body.FirstChild; tr != nil; tr = tr.NextSibling {
+					var cells []*html.Node
+					for cell := tr.FirstChild; cell != nil; cell = cell.NextSibling {
+						if cell.DataAtom == atom.Th || cell.DataAtom == atom.Td {
+							cells = append(cells, cell)
+						}
+					}
+					if tr.DataAtom == atom.Tr {
+						rows = append(rows, cells)
+					}
+				}
+			}
+			return
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(doc)
+	return rows
+}
+
+func tableCaption(table *html.Node) string {
+	for c := table.FirstChild; c != nil; c = c.NextSibling {
+		if c.DataAtom == atom.Caption {
+			return text(c)
+		}
+	}
+	return ""
+}
+
+// codeNames returns the <code> names in a cell. Names linking to another specification
+// (MathML math, SVG svg) are skipped.
+func codeNames(cell *html.Node) []string {
+	var names []string
+	var walk func(n *html.Node, external bool)
+	walk = func(n *html.Node, external bool) {
+		if n.DataAtom == atom.A {
+			for _, a := range n.Attr {
+				if a.Key == "href" && (strings.HasPrefix(a.Val, "http://") || strings.HasPrefix(a.Val, "https://")) {
+					external = true
+				}
+			}
+		}
+		if n.DataAtom == atom.Code {
+			if !external {
+				names = append(names, text(n))
+			}
+			return
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c, external)
+		}
+	}
+	walk(cell, false)
+	return names
+}
+
+// text returns the text content of n with whitespace collapsed.
+func text(n *html.Node) string {
+	var sb strings.Builder
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			sb.WriteString(n.Data)
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+	return strings.Join(strings.Fields(sb.String()), " ")
+}
+
+// splitList splits a "flow; phrasing*; interactive" cell into its items, without footnote marks.
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ";") {
+		items = append(items, strings.TrimRight(strings.TrimSpace(item), "*"))
+	}
+	return items
+}
+
+// format writes the schema as htmlschema.json: keys sorted, one attribute or event per line.
+func (s *schema) format() []byte {
+	var b bytes.Buffer
+	b.WriteString("{\n  \"globalAttributes\": {\n")
+	writeAttributes(&b, s.GlobalAttributes, "    ")
+	b.WriteString("  },\n  \"elements\": {\n")
+	for i, name := range sortedKeys(s.Elements) {
+		el := s.Elements[name]
+		var fields []string
+		if el.Void {
+			fields = append(fields, "      \"void\": true")
+		}
+		if el.Metadata {
+			fields = append(fields, "      \"metadata\": true")
+		}
+		if len(el.Attributes) > 0 {
+			var attrs bytes.Buffer
+			writeAttributes(&attrs, el.Attributes, "        ")
+			fields = append(fields, "      \"attributes\": {\n"+attrs.String()+"      }")
+		}
+		if len(fields) == 0 {
+			fmt.Fprintf(&b, "    %q: {}", name)
+		} else {
+			fmt.Fprintf(&b, "    %q: {\n%s\n    }", name, strings.Join(fields, ",\n"))
+		}
+		b.WriteString(separator(i, len(s.Elements)))
+	}
+	b.WriteString("  },\n  \"events\": {\n")
+	for i, name := range sortedKeys(events) {
+		ev := events[name]
+		fmt.Fprintf(&b, "    %q: {\"bubbles\": %t", name, ev.Bubbles)
+		if len(ev.Targets) > 0 {
+			fmt.Fprintf(&b, ", \"targets\": %s", stringList(ev.Targets))
+		}
+		b.WriteString("}" + separator(i, len(events)))
+	}
+	b.WriteString("  }\n}\n")
+	return b.Bytes()
+}
+
+func writeAttributes(b *bytes.Buffer, attrs map[string]attr, indent string) {
+	for i, name := range sortedKeys(attrs) {
+		value := fmt.Sprintf("%q", attrs[name].Kind)
+		if attrs[name].Kind == "" {
+			value = stringList(attrs[name].Keywords)
+		}
+		fmt.Fprintf(b, "%s%q: %s%s", indent, name, value, separator(i, len(attrs)))
+	}
+}
+
+func stringList(items []string) string {
+	quoted := make([]string, len(items))
+	for i, item := range items {
+		quoted[i] = fmt.Sprintf("%q", item)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+func separator(i, n int) string {
+	if i < n-1 {
+		return ",\n"
+	}
+	return "\n"
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -4,42 +4,23 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+
+	"golang.org/x/net/html"
 )
 
 // preprocessFor preprocesses template source to extract for-loop blocks and replace them with placeholder nodes.
-// It validates that every {@for} has a matching {@endfor} and that trackBy is specified.
-// Syntax: {@for index, value := range SliceName trackBy uniqueKeyExpression}{@endfor}
-// The index can be _ to ignore it: {@for _, value := range SliceName trackBy uniqueKeyExpression}
+// It validates that every {@for} has a matching {@endfor}.
+// Syntax: {@for index, value := range Expr trackBy keyExpression}...{@empty}...{@endfor}
+// The {@empty} branch is optional and renders when the loop has no iterations.
+// The index can be _ to ignore it: {@for _, value := range Expr trackBy keyExpression}
+// A single variable ranges over an integer or an iter.Seq: {@for i := range 10}
+// The range target and trackBy key are Go expressions; their types are checked during code generation.
 func preprocessFor(src string, templatePath string) (string, error) {
-	// Regex to match ONLY: {@for i, user := range Users trackBy user.ID} or {@for _, user := range Users trackBy user.ID}
-	reFor := regexp.MustCompile(`\{\@for\s+([a-zA-Z_][a-zA-Z0-9_]*)\s*,\s*([a-zA-Z_][a-zA-Z0-9_]*)\s*:=\s*range\s+([a-zA-Z_][a-zA-Z0-9_]*)\s+trackBy\s+([a-zA-Z0-9_.]+)\}`)
-
-	// Regex to detect INVALID syntax: {@for user := range Users trackBy user.ID} (missing index/underscore)
-	reForInvalid := regexp.MustCompile(`\{\@for\s+([a-zA-Z_][a-zA-Z0-9_]*)\s*:=\s*range\s+([a-zA-Z_][a-zA-Z0-9_]*)\s+trackBy\s+([a-zA-Z0-9_.]+)\}`)
+	// Matches: {@for i, user := range Users trackBy user.ID}, {@for k, v := range Settings trackBy k}, {@for i := range 10}
+	reFor := regexp.MustCompile(`\{\@for\s+([a-zA-Z_][a-zA-Z0-9_]*)(?:\s*,\s*([a-zA-Z_][a-zA-Z0-9_]*))?\s*:=\s*range\s+([^}]+?)(?:\s+trackBy\s+([^}]+?))?\s*\}`)
 
 	reEndFor := regexp.MustCompile(`\{\@endfor\}`)
 
-	// Check for invalid syntax (missing index/underscore)
-	if invalidMatches := reForInvalid.FindAllString(src, -1); len(invalidMatches) > 0 {
-		lines := strings.Split(src, "\n")
-		var invalidLines []int
-
-		for i, line := range lines {
-			if reForInvalid.MatchString(line) && !reFor.MatchString(line) {
-				invalidLines = append(invalidLines, i+1)
-			}
-		}
-
-		if len(invalidLines) > 0 {
-			return "", fmt.Errorf("template syntax error in %s: Invalid {@for} syntax at line(s): %v\n"+
-				"  The {@for} directive requires both index and value variables.\n"+
-				"  Correct syntax: {@for index, value := range Slice trackBy value.Field}\n"+
-				"  To ignore the index, use underscore: {@for _, value := range Slice trackBy value.Field}\n"+
-				"  Example: {@for _, user := range Users trackBy user.ID}",
-				templatePath, invalidLines)
-		}
-	}
-
 	// Count directives to validate structure
 	forCount := len(reFor.FindAllString(src, -1))
 	endForCount := len(reEndFor.FindAllString(src, -1))
@@ -75,26 +56,68 @@ func preprocessFor(src string, templatePath string) (string, error) {
 	}
 
 	// Transform {@for i, user := range Users trackBy user.ID} to placeholder elements
-	src = reFor.ReplaceAllStringFunc(src, func(m string) string {
-		matches := reFor.FindStringSubmatch(m)
-		indexVar := matches[1]
-		valueVar := matches[2]
+	var loops strings.Builder
+	last := 0
+	for _, m := range reFor.FindAllStringIndex(src, -1) {
+		loops.WriteString(src[last:m[0]])
+		last = m[1]
+		matches := reFor.FindStringSubmatch(src[m[0]:m[1]])
+		indexVar, valueVar := matches[1], matches[2]
+		if valueVar == "" {
+			// Single variable: {@for i := range 10} or {@for v := range Seq trackBy v}
+			indexVar, valueVar = "", matches[1]
+		}
 		rangeExpr := matches[3]
 		trackByExpr := matches[4]
-		return fmt.Sprintf(`<go-for data-index="%s" data-value="%s" data-range="%s" data-trackby="%s">`,
-			indexVar, valueVar, rangeExpr, trackByExpr)
-	})
+		fmt.Fprintf(&loops, `<go-for data-index="%s" data-value="%s" data-range="%s" data-trackby="%s" data-line="%d">`,
+			indexVar, valueVar, html.EscapeString(rangeExpr), html.EscapeString(trackByExpr), strings.Count(src[:m[0]], "\n")+1)
+	}
+	loops.WriteString(src[last:])
+	src = loops.String()
 
-	src = reEndFor.ReplaceAllString(src, "</go-for>")
-	return src, nil
+	// {@empty} opens the fallback branch of the innermost loop; {@endfor} closes it along with
+	// the loop. A stack keeps nested loops from closing each other's branches.
+	reLoopToken := regexp.MustCompile(`<go-for |\{\@empty\}|\{\@endfor\}`)
+	var emptyOpen []bool
+	var out strings.Builder
+	last = 0
+	for _, m := range reLoopToken.FindAllStringIndex(src, -1) {
+		out.WriteString(src[last:m[0]])
+		last = m[1]
+		switch token := src[m[0]:m[1]]; token {
+		case "<go-for ":
+			emptyOpen = append(emptyOpen, false)
+			out.WriteString(token)
+		case "{@empty}":
+			line := strings.Count(src[:m[0]], "\n") + 1
+			if len(emptyOpen) == 0 {
+				return "", fmt.Errorf("template validation error in %s:%d: {@empty} outside of a {@for} block", templatePath, line)
+			}
+			if emptyOpen[len(emptyOpen)-1] {
+				return "", fmt.Errorf("template validation error in %s:%d: a {@for} block can have only one {@empty} branch", templatePath, line)
+			}
+			emptyOpen[len(emptyOpen)-1] = true
+			out.WriteString("<go-empty>")
+		default: // {@endfor}
+			if len(emptyOpen) == 0 {
+				line := strings.Count(src[:m[0]], "\n") + 1
+				return "", fmt.Errorf("template validation error in %s:%d: {@endfor} without matching {@for}", templatePath, line)
+			}
+			if emptyOpen[len(emptyOpen)-1] {
+				out.WriteString("</go-empty>")
+			}
+			emptyOpen = emptyOpen[:len(emptyOpen)-1]
+			out.WriteString("</go-for>")
+		}
+	}
+	out.WriteString(src[last:])
+	return out.String(), nil
 }
 
 // preprocessConditionals preprocesses template source to extract conditional blocks and replace them with placeholder nodes.
 // It validates that every {@if} has a matching {@endif}.
 func preprocessConditionals(src string, templatePath string) (string, error) {
 	reIf := regexp.MustCompile(`\{\@if ([^}]+)\}`)
-	reElseIf := regexp.MustCompile(`\{\@else if ([^}]+)\}`)
-	reElse := regexp.MustCompile(`\{\@else\}`)
 	reEndIf := regexp.MustCompile(`\{\@endif\}`)
 
 	// Count directives to validate structure
@@ -131,21 +154,262 @@ func preprocessConditionals(src string, templatePath string) (string, error) {
 		}
 	}
 
-	src = reIf.ReplaceAllStringFunc(src, func(m string) string {
-		cond := reIf.FindStringSubmatch(m)[1]
-		return fmt.Sprintf("<go-conditional><go-if data-cond=\"%s\">", cond)
-	})
-	src = reElseIf.ReplaceAllStringFunc(src, func(m string) string {
-		cond := reElseIf.FindStringSubmatch(m)[1]
-		return fmt.Sprintf("</go-if><go-elseif data-cond=\"%s\">", cond)
+	// Directives are replaced in order. A stack holds the open branch placeholder of each
+	// nested {@if}, so every branch is closed exactly once.
+	reDirective := regexp.MustCompile(`\{@(?:if ([^}]+)|else if ([^}]+)|else|endif)\}`)
+	var open []string
+	var out strings.Builder
+	last := 0
+	for _, m := range reDirective.FindAllStringSubmatchIndex(src, -1) {
+		out.WriteString(src[last:m[0]])
+		last = m[1]
+		directive := src[m[0]:m[1]]
+		line := strings.Count(src[:m[0]], "\n") + 1
+
+		switch {
+		case m[2] >= 0: // {@if Cond}
+			open = append(open, "go-if")
+			fmt.Fprintf(&out, "<go-conditional><go-if data-cond=\"%s\" data-line=\"%d\">", html.EscapeString(src[m[2]:m[3]]), line)
+		case len(open) == 0:
+			// {@else} or {@endif} mentioned in prose outside any {@if} block stays text;
+			// a missing {@if} is reported by the directive count check above
+			out.WriteString(directive)
+		case directive == "{@endif}":
+			fmt.Fprintf(&out, "</%s></go-conditional>", open[len(open)-1])
+			open = open[:len(open)-1]
+		default: // {@else if Cond} or {@else}
+			top := &open[len(open)-1]
+			if *top == "go-else" {
+				return "", fmt.Errorf("template validation error in %s:%d: %s after {@else}; {@else} must be the last branch of an {@if}", templatePath, line, directive)
+			}
+			fmt.Fprintf(&out, "</%s>", *top)
+			if m[4] >= 0 {
+				*top = "go-elseif"
+				fmt.Fprintf(&out, "<go-elseif data-cond=\"%s\" data-line=\"%d\">", html.EscapeString(src[m[4]:m[5]]), line)
+			} else {
+				*top = "go-else"
+				out.WriteString("<go-else>")
+			}
+		}
+	}
+	out.WriteString(src[last:])
+	return out.String(), nil
+}
+
+// preprocessSwitch replaces {@switch Expr}{@case A, B}...{@default}...{@endswitch} blocks with
+// <go-switch>/<go-case>/<go-default> placeholder nodes.
+// Directives are matched with a stack rather than by regex replacement so that nested switches
+// close exactly the branch they opened.
+func preprocessSwitch(src string, templatePath string) (string, error) {
+	reDirective := regexp.MustCompile(`\{@(?:switch\s+([^}]+)|case\s+([^}]+)|default|endswitch)\}`)
+
+	type openSwitch struct {
+		line       int
+		branch     string // Currently open placeholder: "", "go-case" or "go-default"
+		hasDefault bool
+		bodyStart  int // Offset just after the {@switch} directive
+	}
+	var stack []*openSwitch
+
+	lineAt := func(offset int) int {
+		return strings.Count(src[:offset], "\n") + 1
+	}
+
+	var out strings.Builder
+	last := 0
+	for _, m := range reDirective.FindAllStringSubmatchIndex(src, -1) {
+		out.WriteString(src[last:m[0]])
+		last = m[1]
+		directive := src[m[0]:m[1]]
+		line := lineAt(m[0])
+
+		switch {
+		case m[2] >= 0: // {@switch Expr}
+			stack = append(stack, &openSwitch{line: line, bodyStart: m[1]})
+			fmt.Fprintf(&out, "<go-switch data-expr=\"%s\" data-line=\"%d\">", html.EscapeString(src[m[2]:m[3]]), line)
+
+		case directive == "{@endswitch}":
+			if len(stack) == 0 {
+				return "", fmt.Errorf("template validation error in %s:%d: {@endswitch} without matching {@switch}", templatePath, line)
+			}
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if top.branch != "" {
+				fmt.Fprintf(&out, "</%s>", top.branch)
+			}
+			out.WriteString("</go-switch>")
+
+		default: // {@case ...} or {@default}
+			if len(stack) == 0 {
+				return "", fmt.Errorf("template validation error in %s:%d: %s outside of a {@switch} block", templatePath, line, directive)
+			}
+			top := stack[len(stack)-1]
+			if top.branch == "" && strings.TrimSpace(src[top.bodyStart:m[0]]) != "" {
+				return "", fmt.Errorf("template validation error in %s:%d: content between {@switch} and its first {@case} is not allowed", templatePath, top.line)
+			}
+			if top.hasDefault {
+				return "", fmt.Errorf("template validation error in %s:%d: %s after {@default}; {@default} must be the last branch of a {@switch}", templatePath, line, directive)
+			}
+			if top.branch != "" {
+				fmt.Fprintf(&out, "</%s>", top.branch)
+			}
+			if m[4] >= 0 {
+				top.branch = "go-case"
+				fmt.Fprintf(&out, "<go-case data-values=\"%s\" data-line=\"%d\">", html.EscapeString(src[m[4]:m[5]]), line)
+			} else {
+				top.branch = "go-default"
+				top.hasDefault = true
+				out.WriteString("<go-default>")
+			}
+		}
+	}
+	out.WriteString(src[last:])
+
+	if len(stack) > 0 {
+		return "", fmt.Errorf("template validation error in %s:%d: {@switch} is missing its {@endswitch}", templatePath, stack[len(stack)-1].line)
+	}
+	return out.String(), nil
+}
+
+// preprocessSlots replaces {@slot Footer}...{@endslot} blocks with <slot name="Footer">
+// elements, which fill a named slot of the enclosing component tag.
+func preprocessSlots(src string, templatePath string) (string, error) {
+	reDirective := regexp.MustCompile(`\{@(?:slot\s+([^}]*?)\s*|endslot)\}`)
+	reSlotName := regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+	openLine := 0 // Line of the open {@slot}, or 0
+	var out strings.Builder
+	last := 0
+	for _, m := range reDirective.FindAllStringSubmatchIndex(src, -1) {
+		out.WriteString(src[last:m[0]])
+		last = m[1]
+		line := strings.Count(src[:m[0]], "\n") + 1
+
+		if m[2] < 0 { // {@endslot}
+			if openLine == 0 {
+				return "", fmt.Errorf("template validation error in %s:%d: {@endslot} without matching {@slot}", templatePath, line)
+			}
+			openLine = 0
+			out.WriteString("</slot>")
+			continue
+		}
+
+		name := src[m[2]:m[3]]
+		if openLine != 0 {
+			return "", fmt.Errorf("template validation error in %s:%d: {@slot %s} inside another {@slot} block; close it with {@endslot} first", templatePath, line, name)
+		}
+		if !reSlotName.MatchString(name) {
+			return "", fmt.Errorf("template validation error in %s:%d: {@slot %s} must name a slot field, e.g. {@slot Footer}", templatePath, line, name)
+		}
+		openLine = line
+		fmt.Fprintf(&out, "<slot name=\"%s\">", name)
+	}
+	out.WriteString(src[last:])
+
+	if openLine != 0 {
+		return "", fmt.Errorf("template validation error in %s:%d: {@slot} is missing its {@endslot}", templatePath, openLine)
+	}
+	return out.String(), nil
+}
+
+// preprocessScopedSlots replaces scoped slot blocks (<row let:user>...</row>) with
+// <go-let data-slot="row" data-var="user"> placeholder nodes. The placeholder keeps the
+// variable's casing, which the HTML parser would lowercase, and lets the block hold table
+// rows. Tags with the slot's name nested inside the block are matched with a depth count.
+func preprocessScopedSlots(src string, templatePath string) (string, error) {
+	reTag := regexp.MustCompile(`<(/?)([A-Za-z][A-Za-z0-9-]*)((?:"[^"]*"|'[^']*'|[^'">])*)>`)
+	reLet := regexp.MustCompile(`(?:^|\s)let:([A-Za-z_][A-Za-z0-9_]*)`)
+
+	type openSlot struct {
+		name  string
+		line  int
+		depth int // Open tags with the same name inside the block
+	}
+	var stack []*openSlot
+
+	var out strings.Builder
+	last := 0
+	for _, m := range reTag.FindAllStringSubmatchIndex(src, -1) {
+		closing := m[3] > m[2]
+		name := strings.ToLower(src[m[4]:m[5]])
+		attrs := src[m[6]:m[7]]
+		var top *openSlot
+		if len(stack) > 0 {
+			top = stack[len(stack)-1]
+		}
+
+		if closing {
+			if top == nil || name != top.name {
+				continue
+			}
+			if top.depth > 0 {
+				top.depth--
+				continue
+			}
+			out.WriteString(src[last:m[0]])
+			last = m[1]
+			out.WriteString("</go-let>")
+			stack = stack[:len(stack)-1]
+			continue
+		}
+
+		let := reLet.FindStringSubmatchIndex(attrs)
+		if let == nil {
+			if top != nil && name == top.name {
+				top.depth++
+			}
+			continue
+		}
+		line := strings.Count(src[:m[0]], "\n") + 1
+		varName := attrs[let[2]:let[3]]
+		if rest := strings.TrimSpace(attrs[:let[0]] + attrs[let[1]:]); rest != "" {
+			return "", fmt.Errorf("template validation error in %s:%d: <%s let:%s> fills a scoped slot and takes no other attributes, found '%s'", templatePath, line, name, varName, rest)
+		}
+		out.WriteString(src[last:m[0]])
+		last = m[1]
+		fmt.Fprintf(&out, `<go-let data-slot="%s" data-var="%s">`, name, varName)
+		stack = append(stack, &openSlot{name: name, line: line})
+	}
+	out.WriteString(src[last:])
+
+	if len(stack) > 0 {
+		top := stack[len(stack)-1]
+		return "", fmt.Errorf("template validation error in %s:%d: <%s let:...> is missing its closing </%s>", templatePath, top.line, top.name, top.name)
+	}
+	return out.String(), nil
+}
+
+// rePlaceholder matches the opening and closing tags of directive placeholders.
+var rePlaceholder = regexp.MustCompile(`<(/?)go-(conditional|if|elseif|else|switch|case|default|for|empty|let)\b`)
+
+// placeholderTemplates rewrites directive placeholders (<go-for ...>...</go-for>) as
+// <template data-go="for" ...>...</template>. The HTML parser moves unknown elements out of
+// tables and selects ("foster parenting"), but keeps <template> wherever it appears, so
+// directives can produce table rows, cells and options. restorePlaceholders undoes the
+// renaming after parsing.
+func placeholderTemplates(src string) string {
+	return rePlaceholder.ReplaceAllStringFunc(src, func(tag string) string {
+		m := rePlaceholder.FindStringSubmatch(tag)
+		if m[1] == "/" {
+			return "</template"
+		}
+		return fmt.Sprintf(`<template data-go="%s"`, m[2])
 	})
-	// Handle {@else} - it closes the previous branch and opens go-else
-	src = reElse.ReplaceAllString(src, func() string {
-		// Check if the previous element is go-if or go-elseif
-		// We need to close whichever was opened
-		return "</go-if></go-elseif><go-else>"
-	}())
-	// {@endif} closes the last opened branch and the wrapper
-	src = reEndIf.ReplaceAllString(src, "</go-if></go-elseif></go-else></go-conditional>")
-	return src, nil
+}
+
+// restorePlaceholders renames the <template data-go="..."> elements produced by
+// placeholderTemplates back to their go-* placeholder names.
+func restorePlaceholders(n *html.Node) {
+	if n.Type == html.ElementNode && n.Data == "template" {
+		for i, a := range n.Attr {
+			if a.Key == "data-go" {
+				n.Data = "go-" + a.Val
+				n.Attr = append(n.Attr[:i], n.Attr[i+1:]...)
+				break
+			}
+		}
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		restorePlaceholders(c)
+	}
 }
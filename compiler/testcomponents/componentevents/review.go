@@ -0,0 +1,36 @@
+package componentevents
+
+import "github.com/ForgeLogic/nojs/runtime"
+
+// Item is one rated row of the Review.
+type Item struct {
+	ID   int
+	Name string
+}
+
+// Review is a test component binding handlers to StarPicker's events with
+// @onpick and @onreset. None of its handlers call StateHasChanged: the
+// EventCallback re-renders it.
+type Review struct {
+	runtime.ComponentBase
+
+	Stars   int
+	Items   []Item
+	Ratings map[int]int
+}
+
+func (c *Review) SetStars(stars int) {
+	c.Stars = stars
+}
+
+func (c *Review) Clear() {
+	c.Stars = 0
+	c.Ratings = nil
+}
+
+func (c *Review) Rate(id, stars int) {
+	if c.Ratings == nil {
+		c.Ratings = make(map[int]int)
+	}
+	c.Ratings[id] = stars
+}
@@ -0,0 +1,75 @@
+//go:build !wasm
+
+package componentevents
+
+import (
+	"testing"
+
+	"github.com/ForgeLogic/nojs-compiler/testcomponents"
+	"github.com/ForgeLogic/nojs/vdom"
+)
+
+// click fires the click handler of a button. The stub adapters return func()
+// handlers, which NewVNode moves from the onClick attribute to OnClick.
+func click(t *testing.T, button *vdom.VNode) {
+	t.Helper()
+	if button.OnClick == nil {
+		t.Fatalf("Expected a click handler on <%s>", button.Tag)
+	}
+	button.OnClick()
+}
+
+// TestComponentEvents_EventCallbackRerendersParent verifies that a child
+// raising an EventCallback runs the parent's handler and re-renders the
+// parent, although the handler does not call StateHasChanged.
+func TestComponentEvents_EventCallbackRerendersParent(t *testing.T) {
+	// Arrange
+	comp := &Review{}
+	renderer := testcomponents.NewTestRenderer(comp)
+	picker := renderer.RenderRoot().Children[0]
+
+	// Act
+	click(t, picker.Children[2])
+
+	// Assert
+	if comp.Stars != 3 {
+		t.Errorf("Expected Stars 3, got %d", comp.Stars)
+	}
+	summary := renderer.GetCurrentVDOM().Children[1]
+	if got := summary.Content; got != "Stars: 3" {
+		t.Errorf("Expected the parent to re-render 'Stars: 3', got '%s'", got)
+	}
+}
+
+// TestComponentEvents_FuncFieldCallsParentMethod verifies that a func-typed
+// event field is bound to the parent's method.
+func TestComponentEvents_FuncFieldCallsParentMethod(t *testing.T) {
+	// Arrange
+	comp := &Review{Stars: 2}
+	picker := testcomponents.NewTestRenderer(comp).RenderRoot().Children[0]
+
+	// Act
+	click(t, picker.Children[3])
+
+	// Assert
+	if comp.Stars != 0 {
+		t.Errorf("Expected Clear to reset Stars to 0, got %d", comp.Stars)
+	}
+}
+
+// TestComponentEvents_HandlerCallReceivesPayloadAndLoopVariables verifies
+// that @onpick="Rate(item.ID, e)" passes the row's item and the event payload.
+func TestComponentEvents_HandlerCallReceivesPayloadAndLoopVariables(t *testing.T) {
+	// Arrange
+	comp := &Review{Items: []Item{{ID: 7, Name: "Tea"}, {ID: 9, Name: "Cake"}}}
+	rows := testcomponents.NewTestRenderer(comp).RenderRoot().Children[2].Children
+	secondPicker := rows[1].Children[1]
+
+	// Act
+	click(t, secondPicker.Children[1])
+
+	// Assert
+	if len(comp.Ratings) != 1 || comp.Ratings[9] != 2 {
+		t.Errorf("Expected Ratings map[9:2], got %v", comp.Ratings)
+	}
+}
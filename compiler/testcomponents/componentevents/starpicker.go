@@ -0,0 +1,24 @@
+package componentevents
+
+import "github.com/ForgeLogic/nojs/runtime"
+
+// StarPicker is a child component raising events: OnPick is an
+// EventCallback, OnReset a plain func field.
+type StarPicker struct {
+	runtime.ComponentBase
+
+	Max int
+
+	OnPick  runtime.EventCallback[int] `nojs:"event"`
+	OnReset func()                     `nojs:"event"`
+}
+
+func (c *StarPicker) Pick(stars int) {
+	c.OnPick.Invoke(stars)
+}
+
+func (c *StarPicker) Reset() {
+	if c.OnReset != nil {
+		c.OnReset()
+	}
+}
@@ -0,0 +1,35 @@
+package conditionalexpr
+
+import (
+	"github.com/ForgeLogic/nojs/runtime"
+)
+
+// User is one entry of a UserList.
+type User struct {
+	Name    string
+	IsAdmin bool
+	Team    string
+}
+
+// UserList is a test component whose {@if} / {@else if} conditions are Go
+// expressions: len() comparisons, compound conditions, loop variable fields
+// and method calls returning bool.
+type UserList struct {
+	runtime.ComponentBase
+
+	Users     []User
+	IsLoading bool
+	Limit     int
+	EditTeam  string
+}
+
+// CanEdit reports whether the current user may edit u.
+func (c *UserList) CanEdit(u User) bool {
+	return u.Team == c.EditTeam
+}
+
+// SetLoading updates the loading flag and triggers a re-render.
+func (c *UserList) SetLoading(loading bool) {
+	c.IsLoading = loading
+	c.StateHasChanged()
+}
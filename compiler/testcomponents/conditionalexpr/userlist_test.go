@@ -0,0 +1,81 @@
+//go:build !wasm
+
+package conditionalexpr
+
+import (
+	"testing"
+
+	"github.com/ForgeLogic/nojs-compiler/testcomponents"
+)
+
+// TestUserList_EmptyBranch verifies that {@if len(Users) == 0} selects the
+// empty-state branch.
+func TestUserList_EmptyBranch(t *testing.T) {
+	// Arrange
+	renderer := testcomponents.NewTestRenderer(&UserList{Limit: 10})
+
+	// Act
+	vnode := renderer.RenderRoot()
+
+	// Assert
+	status := vnode.Children[0]
+	if got := status.Attributes["class"]; got != "empty" {
+		t.Errorf("Expected 'empty' branch, got class '%v'", got)
+	}
+}
+
+// TestUserList_CompoundElseIf verifies that a compound {@else if} condition is
+// evaluated and re-evaluated after a state change.
+func TestUserList_CompoundElseIf(t *testing.T) {
+	// Arrange
+	list := &UserList{
+		Users: []User{{Name: "ann"}},
+		Limit: 10,
+	}
+	renderer := testcomponents.NewTestRenderer(list)
+	vnode := renderer.RenderRoot()
+	if got := vnode.Children[0].Content; got != "1 users" {
+		t.Fatalf("Expected '1 users' before loading, got '%s'", got)
+	}
+
+	// Act
+	list.SetLoading(true)
+
+	// Assert
+	vnode = renderer.GetCurrentVDOM()
+	if got := vnode.Children[0].Attributes["class"]; got != "loading" {
+		t.Errorf("Expected 'loading' branch while loading below the limit, got class '%v'", got)
+	}
+}
+
+// TestUserList_LoopVariableAndMethodConditions verifies conditions on loop
+// variable fields and on a method call taking the loop variable.
+func TestUserList_LoopVariableAndMethodConditions(t *testing.T) {
+	// Arrange
+	list := &UserList{
+		Users: []User{
+			{Name: "root", IsAdmin: true},
+			{Name: "dev", Team: "web"},
+			{Name: "ops", Team: "infra"},
+		},
+		EditTeam: "web",
+	}
+	renderer := testcomponents.NewTestRenderer(list)
+
+	// Act
+	vnode := renderer.RenderRoot()
+
+	// Assert
+	items := vnode.Children[1].Children
+	if len(items) != 3 {
+		t.Fatalf("Expected 3 list items, got %d", len(items))
+	}
+	want := []string{"admin", "editable", ""}
+	for i, item := range items {
+		span := item.Children[0]
+		got, _ := span.Attributes["class"].(string)
+		if got != want[i] {
+			t.Errorf("Item %d: expected class '%s', got '%s'", i, want[i], got)
+		}
+	}
+}
@@ -5,8 +5,8 @@ import (
 )
 
 // ConditionalForm is a minimal test component that mirrors the FormsPage
-// "Live Preview" pattern: when HasName is true a live-preview block is shown;
-// when it is false a muted placeholder is shown instead.
+// "Live Preview" pattern: when Name is non-empty a live-preview block is shown;
+// otherwise a muted placeholder is shown instead.
 //
 // This component exists specifically to exercise the VNode conditional
 // (if/else) rendering path and the type-then-clear regression where clearing
@@ -14,13 +14,11 @@ import (
 type ConditionalForm struct {
 	runtime.ComponentBase
 
-	Name    string
-	HasName bool
+	Name string
 }
 
 // SetName simulates a user typing into the name input field.
 func (c *ConditionalForm) SetName(name string) {
 	c.Name = name
-	c.HasName = name != ""
 	c.StateHasChanged()
 }
@@ -75,12 +75,14 @@ func TestConditionalForm_TypeName_ShowsLivePreview(t *testing.T) {
 	if len(p.Children) != 3 {
 		t.Fatalf("Expected 3 children in p (text, span, text), got %d", len(p.Children))
 	}
-	spanNode := p.Children[1]
-	if len(spanNode.Children) == 0 {
-		t.Fatal("span has no children")
+	if got := p.Children[0].Content; got != "Hello, " {
+		t.Errorf("Expected text 'Hello, ' before the span, got '%s'", got)
 	}
-	if spanNode.Children[0].Content != "Alice" {
-		t.Errorf("Expected span text 'Alice', got '%s'", spanNode.Children[0].Content)
+	if spanNode := p.Children[1]; spanNode.Content != "Alice" {
+		t.Errorf("Expected span text 'Alice', got '%s'", spanNode.Content)
+	}
+	if got := p.Children[2].Content; got != "!" {
+		t.Errorf("Expected text '!' after the span, got '%s'", got)
 	}
 }
 
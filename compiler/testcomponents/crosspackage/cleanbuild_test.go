@@ -0,0 +1,109 @@
+//go:build !wasm
+
+package crosspackage
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// cleanBuild copies the hand-written files of the given component packages and the packages
+// below them into a fresh directory of this module, without any *.generated.go file, and
+// compiles them once with nojsc. It returns the directory and the compiler's output. The
+// directory name starts with "_" so ./... patterns never pick it up.
+func cleanBuild(t *testing.T, packageDirs ...string) (string, string, error) {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("runs the compiler and go vet")
+	}
+	dir, err := os.MkdirTemp(".", "_cleanbuild-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	for _, src := range packageDirs {
+		abs, err := filepath.Abs(src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		root := filepath.Join(dir, filepath.Base(abs))
+		err = filepath.WalkDir(src, func(path string, entry os.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			name := entry.Name()
+			rel, err := filepath.Rel(src, path)
+			if err != nil {
+				return err
+			}
+			if entry.IsDir() {
+				if rel != "." && (strings.HasPrefix(name, "_") || name == "testdata") {
+					return filepath.SkipDir
+				}
+				return os.MkdirAll(filepath.Join(root, rel), 0o755)
+			}
+			if strings.HasSuffix(name, ".generated.go") || strings.HasSuffix(name, "_test.go") ||
+				!(strings.HasSuffix(name, ".go") || strings.HasSuffix(name, ".gt.html")) {
+				return nil
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			return os.WriteFile(filepath.Join(root, rel), data, 0o644)
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	out, err := exec.Command("go", "run", "github.com/ForgeLogic/nojs-compiler/cmd/nojsc", "-in="+dir).CombinedOutput()
+	return dir, string(out), err
+}
+
+// TestCleanBuild_GenericChildFromAnotherPackage_Compiles verifies that the first compile of a
+// template using a generic component from another package instantiates it, even though no
+// generated file imports that package yet.
+func TestCleanBuild_GenericChildFromAnotherPackage_Compiles(t *testing.T) {
+	// Arrange & Act
+	dir, out, err := cleanBuild(t, "../generics", ".")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("nojsc failed: %v\n%s", err, out)
+	}
+	generated, err := os.ReadFile(filepath.Join(dir, "crosspackage", "ThemeSwitcher.generated.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(generated), "&generics.Picker[string]{") {
+		t.Errorf("Expected the Picker to be instantiated with string, got:\n%s", generated)
+	}
+	vet := exec.Command("go", "vet", "./...")
+	vet.Dir = dir
+	if out, err := vet.CombinedOutput(); err != nil {
+		t.Errorf("go vet failed on the generated code: %v\n%s", err, out)
+	}
+}
+
+// TestCleanBuild_MistypedPropOfChildFromAnotherPackage_Fails verifies that a prop binding of
+// the wrong type is reported at its template line on the first compile, before any generated
+// file imports the child's package.
+func TestCleanBuild_MistypedPropOfChildFromAnotherPackage_Fails(t *testing.T) {
+	// Arrange & Act
+	_, out, err := cleanBuild(t, "../expressions", "testdata/badprop")
+
+	// Assert
+	if err == nil {
+		t.Fatalf("Expected nojsc to fail, got:\n%s", out)
+	}
+	for _, want := range []string{"ColorCount.gt.html:2:19:", "cannot use value of type 'string' as prop of type 'int'"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected the output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
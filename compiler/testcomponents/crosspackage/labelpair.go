@@ -0,0 +1,11 @@
+package crosspackage
+
+import "github.com/ForgeLogic/nojs/runtime"
+
+// LabelPair is a test component using children from two packages that are both named labels.
+type LabelPair struct {
+	runtime.ComponentBase
+
+	Left  string
+	Right string
+}
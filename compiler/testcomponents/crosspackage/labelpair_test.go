@@ -0,0 +1,27 @@
+//go:build !wasm
+
+package crosspackage
+
+import (
+	"testing"
+
+	"github.com/ForgeLogic/nojs-compiler/testcomponents"
+	"github.com/ForgeLogic/nojs/vdom"
+)
+
+// TestLabelPair_ChildrenFromPackagesWithTheSameName_RenderBoth verifies that children from two
+// packages with the same name are imported under distinct names.
+func TestLabelPair_ChildrenFromPackagesWithTheSameName_RenderBoth(t *testing.T) {
+	// Arrange
+	comp := &LabelPair{Left: "from", Right: "to"}
+	renderer := testcomponents.NewTestRenderer(comp)
+
+	// Act
+	root := renderer.RenderRoot()
+
+	// Assert
+	want := `<p><span class="left">from</span><span class="right">to</span></p>`
+	if got := vdom.RenderHTMLString(root); got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+}
@@ -0,0 +1,10 @@
+package labels
+
+import "github.com/ForgeLogic/nojs/runtime"
+
+// LeftLabel is a test component in a package that shares its name with right/labels.
+type LeftLabel struct {
+	runtime.ComponentBase
+
+	Text string
+}
@@ -0,0 +1,10 @@
+package labels
+
+import "github.com/ForgeLogic/nojs/runtime"
+
+// RightLabel is a test component in a package that shares its name with left/labels.
+type RightLabel struct {
+	runtime.ComponentBase
+
+	Text string
+}
@@ -0,0 +1,11 @@
+package badprop
+
+import "github.com/ForgeLogic/nojs/runtime"
+
+// ColorCount binds a string to the int Count prop of Badge, a component from another
+// package. Its Go code does not import that package.
+type ColorCount struct {
+	runtime.ComponentBase
+
+	Color string
+}
@@ -0,0 +1,12 @@
+package crosspackage
+
+import "github.com/ForgeLogic/nojs/runtime"
+
+// ThemeSwitcher is a test component using a generic component from another package. Its
+// Go code does not import that package: only the template refers to it.
+type ThemeSwitcher struct {
+	runtime.ComponentBase
+
+	Themes []string
+	Theme  string
+}
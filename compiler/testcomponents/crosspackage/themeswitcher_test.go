@@ -0,0 +1,27 @@
+//go:build !wasm
+
+package crosspackage
+
+import (
+	"testing"
+
+	"github.com/ForgeLogic/nojs-compiler/testcomponents"
+	"github.com/ForgeLogic/nojs/vdom"
+)
+
+// TestThemeSwitcher_GenericChildFromAnotherPackage_IsInstantiated verifies that a generic
+// component from another package is instantiated with the type of the props bound to it.
+func TestThemeSwitcher_GenericChildFromAnotherPackage_IsInstantiated(t *testing.T) {
+	// Arrange
+	comp := &ThemeSwitcher{Themes: []string{"light", "dark"}, Theme: "dark"}
+	renderer := testcomponents.NewTestRenderer(comp)
+
+	// Act
+	root := renderer.RenderRoot()
+
+	// Assert
+	want := `<nav class="themes"><ul class="picker"><li class="">light</li><li class="selected">dark</li></ul></nav>`
+	if got := vdom.RenderHTMLString(root); got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+}
@@ -0,0 +1,58 @@
+package domevents
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/ForgeLogic/nojs/runtime"
+)
+
+// Board is a test component for the pointer, wheel, scroll, drag-and-drop,
+// clipboard, composition, animation and mouse enter/double-click events.
+// Outside WASM builds the typed event args do not exist, so its handlers are
+// bound with modifiers or handler calls, which take no event args.
+type Board struct {
+	runtime.ComponentBase
+
+	Cards    []string
+	Dragging int
+	Over     int
+	Events   []string
+}
+
+func (c *Board) StartDrag(i int) {
+	c.Dragging = i
+}
+
+func (c *Board) Hover(i int) {
+	c.Over = i
+}
+
+// DropOn moves the dragged card to position i.
+func (c *Board) DropOn(i int) {
+	card := c.Cards[c.Dragging]
+	c.Cards = slices.Insert(slices.Delete(c.Cards, c.Dragging, c.Dragging+1), i, card)
+}
+
+func (c *Board) EndDrag() {
+	c.Dragging, c.Over = -1, -1
+}
+
+func (c *Board) Scrolled() {
+	c.log("scroll")
+}
+
+func (c *Board) Press()    { c.log("press") }
+func (c *Board) Release()  { c.log("release") }
+func (c *Board) Zoom()     { c.log("zoom") }
+func (c *Board) Pasted()   { c.log("paste") }
+func (c *Board) Composed() { c.log("compose") }
+func (c *Board) Hold()     { c.log("hold") }
+
+func (c *Board) Dismiss(remaining int) {
+	c.log(fmt.Sprintf("dismiss %d", remaining))
+}
+
+func (c *Board) log(event string) {
+	c.Events = append(c.Events, event)
+}
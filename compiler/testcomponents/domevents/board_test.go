@@ -0,0 +1,113 @@
+//go:build !wasm
+
+package domevents
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/ForgeLogic/nojs-compiler/testcomponents"
+	"github.com/ForgeLogic/nojs/vdom"
+)
+
+// render returns a rendered Board component with three cards.
+func render() (*Board, *vdom.VNode) {
+	comp := &Board{Cards: []string{"a", "b", "c"}, Dragging: -1, Over: -1}
+	return comp, testcomponents.NewTestRenderer(comp).RenderRoot()
+}
+
+// handler returns an element's event handler, unwrapping listener options.
+func handler(t *testing.T, node *vdom.VNode, attr string) func() {
+	t.Helper()
+	switch h := node.Attributes[attr].(type) {
+	case func():
+		return h
+	case vdom.EventListener:
+		return h.Handler.(func())
+	}
+	t.Fatalf("Expected a handler for '%s', got %T", attr, node.Attributes[attr])
+	return nil
+}
+
+// TestBoard_DragAndDropReordersCards verifies that the drag events of a list
+// item are wired to handler calls capturing the loop index.
+func TestBoard_DragAndDropReordersCards(t *testing.T) {
+	// Arrange
+	comp, vnode := render()
+	items := vnode.Children[0].Children
+
+	// Act
+	handler(t, items[0], "onDragstart")()
+	handler(t, items[2], "onDragover")()
+	handler(t, items[2], "onDrop")()
+
+	// Assert
+	if comp.Over != 2 {
+		t.Errorf("Expected dragover to record index 2, got %d", comp.Over)
+	}
+	if want := []string{"b", "c", "a"}; !slices.Equal(comp.Cards, want) {
+		t.Errorf("Expected cards %v after the drop, got %v", want, comp.Cards)
+	}
+
+	// Act
+	handler(t, items[2], "onDragend")()
+
+	// Assert
+	if comp.Dragging != -1 || comp.Over != -1 {
+		t.Errorf("Expected dragend to reset the drag state, got Dragging=%d Over=%d", comp.Dragging, comp.Over)
+	}
+}
+
+// TestBoard_EventsAreWired verifies that the pointer, wheel, scroll, clipboard,
+// composition, animation and mouse events call their handlers.
+func TestBoard_EventsAreWired(t *testing.T) {
+	// Arrange
+	comp, vnode := render()
+	board, pad, textarea, toast := vnode, vnode.Children[1], vnode.Children[2], vnode.Children[3]
+
+	// Act
+	handler(t, board, "onScroll")()
+	handler(t, pad, "onPointerdown")()
+	handler(t, pad, "onPointerup")()
+	handler(t, pad, "onWheel")()
+	handler(t, textarea, "onPaste")()
+	handler(t, textarea, "onCompositionend")()
+	handler(t, toast, "onAnimationend")()
+	handler(t, toast, "onMouseenter")()
+	handler(t, toast, "onDblclick")()
+
+	// Assert
+	want := []string{"scroll", "press", "release", "zoom", "paste", "compose", "dismiss 3", "hold", "hold"}
+	if !slices.Equal(comp.Events, want) {
+		t.Errorf("Expected events %v, got %v", want, comp.Events)
+	}
+}
+
+// TestBoard_ListenerOptions verifies that .passive, .capture and .once on the
+// new events produce listener options.
+func TestBoard_ListenerOptions(t *testing.T) {
+	// Arrange & Act
+	_, vnode := render()
+	pad := vnode.Children[1]
+
+	// Assert
+	tests := []struct {
+		node *vdom.VNode
+		attr string
+		want vdom.EventListener
+	}{
+		{vnode, "onScroll", vdom.EventListener{Passive: true}},
+		{pad, "onPointerdown", vdom.EventListener{Capture: true}},
+		{pad, "onPointerup", vdom.EventListener{Once: true}},
+	}
+	for _, tt := range tests {
+		listener, ok := tt.node.Attributes[tt.attr].(vdom.EventListener)
+		if !ok {
+			t.Errorf("Expected '%s' to be a vdom.EventListener, got %T", tt.attr, tt.node.Attributes[tt.attr])
+			continue
+		}
+		if listener.Once != tt.want.Once || listener.Capture != tt.want.Capture || listener.Passive != tt.want.Passive {
+			t.Errorf("'%s': expected options %+v, got %+v", tt.attr, tt.want, listener)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package elements
+
+import "github.com/ForgeLogic/nojs/runtime"
+
+// Row is one line of the report table.
+type Row struct {
+	Name  string
+	Score int
+}
+
+// Report is a test component using elements without dedicated code generation
+// (table rows and cells, fieldset, details, pre/code, description lists and
+// inline phrasing elements).
+type Report struct {
+	runtime.ComponentBase
+
+	Year     int
+	Rows     []Row
+	Verdict  string
+	Expanded bool
+	Owner    string
+}
+
+// Total returns the sum of all scores.
+func (c *Report) Total() int {
+	total := 0
+	for _, row := range c.Rows {
+		total += row.Score
+	}
+	return total
+}
@@ -0,0 +1,109 @@
+//go:build !wasm
+
+package elements
+
+import (
+	"testing"
+
+	"github.com/ForgeLogic/nojs-compiler/testcomponents"
+	"github.com/ForgeLogic/nojs/vdom"
+)
+
+// render returns a rendered Report with two rows.
+func render() *vdom.VNode {
+	comp := &Report{
+		Year:     2025,
+		Rows:     []Row{{"alpha", 3}, {"beta", 5}},
+		Verdict:  "good",
+		Expanded: true,
+		Owner:    "ann",
+	}
+	return testcomponents.NewTestRenderer(comp).RenderRoot()
+}
+
+// TestElements_EveryTagIsRendered verifies that elements outside the common
+// set keep their tag, attributes and children instead of becoming empty divs.
+func TestElements_EveryTagIsRendered(t *testing.T) {
+	// Arrange & Act
+	vnode := render()
+
+	// Assert
+	var tags []string
+	var walk func(n *vdom.VNode)
+	walk = func(n *vdom.VNode) {
+		tags = append(tags, n.Tag)
+		for _, c := range n.Children {
+			walk(c)
+		}
+	}
+	walk(vnode)
+	for _, want := range []string{"small", "table", "thead", "tbody", "tr", "th", "td", "strong", "ol", "li", "fieldset", "legend", "label", "textarea", "details", "summary", "pre", "code", "dl", "dt", "dd", "em"} {
+		found := false
+		for _, tag := range tags {
+			found = found || tag == want
+		}
+		if !found {
+			t.Errorf("Expected a <%s> element in the rendered tree, got tags %v", want, tags)
+		}
+	}
+
+	table := vnode.Children[1]
+	if got := table.Attributes["class"]; got != "grid" {
+		t.Errorf("Expected table class 'grid', got '%v'", got)
+	}
+	if th := table.Children[0].Children[0].Children[0]; th.Attributes["scope"] != "col" || th.Content != "Name" {
+		t.Errorf("Expected <th scope=\"col\">Name</th>, got %+v", th)
+	}
+}
+
+// TestElements_LoopAndBindingsInAnyTag verifies that loops, bindings and
+// boolean attributes work the same way in every element.
+func TestElements_LoopAndBindingsInAnyTag(t *testing.T) {
+	// Arrange & Act
+	vnode := render()
+
+	// Assert
+	if total := vnode.Children[1].Children[1].Children[0].Children[1].Children[0]; total.Tag != "strong" || total.Content != "8" {
+		t.Errorf("Expected <strong>8</strong> in the table body, got %+v", total)
+	}
+	items := vnode.Children[2].Children
+	if len(items) != 2 {
+		t.Fatalf("Expected 2 list items, got %d", len(items))
+	}
+	if label, space, score := items[1].Children[0], items[1].Children[1], items[1].Children[2]; label.Tag != "label" || label.Content != "beta" || space.Content != " " || score.Content != "5" {
+		t.Errorf("Expected <label>beta</label> <strong>5</strong> in the second item, got %+v", items[1].Children)
+	}
+	if got := vnode.Children[4].Attributes["open"]; got != true {
+		t.Errorf("Expected details open=true, got %v", got)
+	}
+	if code := vnode.Children[4].Children[1].Children[0]; code.Content != "2 rows" {
+		t.Errorf("Expected code text '2 rows', got '%s'", code.Content)
+	}
+	if em := vnode.Children[5].Children[1].Children[0]; em.Content != "ann" {
+		t.Errorf("Expected owner 'ann', got '%s'", em.Content)
+	}
+}
+
+// TestElements_HeadingKeepsElementChildren verifies that a heading with an
+// element child renders both its text, with the space before the child, and the child.
+func TestElements_HeadingKeepsElementChildren(t *testing.T) {
+	// Arrange & Act
+	heading := render().Children[0]
+
+	// Assert
+	if got, want := vdom.RenderHTMLString(heading), "<h1>Report <small>2025</small></h1>"; got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+}
+
+// TestElements_TextareaTextIsItsValue verifies that a textarea's text,
+// including bindings, becomes its initial value.
+func TestElements_TextareaTextIsItsValue(t *testing.T) {
+	// Arrange & Act
+	textarea := render().Children[3].Children[3]
+
+	// Assert
+	if textarea.Tag != "textarea" || textarea.Content != "Looks good" || len(textarea.Children) != 0 {
+		t.Errorf("Expected a textarea with value 'Looks good', got %+v", textarea)
+	}
+}
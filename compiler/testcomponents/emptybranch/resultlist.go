@@ -0,0 +1,14 @@
+package emptybranch
+
+import (
+	"github.com/ForgeLogic/nojs/runtime"
+	"github.com/ForgeLogic/nojs/vdom"
+)
+
+// ResultList is a test component whose {@empty} branch renders the caller's slot content.
+type ResultList struct {
+	runtime.ComponentBase
+
+	Results []string
+	Empty   []*vdom.VNode
+}
@@ -0,0 +1,13 @@
+package emptybranch
+
+import "github.com/ForgeLogic/nojs/runtime"
+
+// TodoList is a test component for the {@empty} branch of {@for}: a slice
+// loop, a map loop, and an inner loop whose empty branch reads the outer
+// loop's variable.
+type TodoList struct {
+	runtime.ComponentBase
+
+	Items  []string
+	Groups map[string][]string
+}
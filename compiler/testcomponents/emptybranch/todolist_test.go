@@ -0,0 +1,138 @@
+//go:build !wasm
+
+package emptybranch
+
+import (
+	"testing"
+
+	"github.com/ForgeLogic/nojs-compiler/testcomponents"
+	"github.com/ForgeLogic/nojs/vdom"
+)
+
+// TestEmpty_RendersFallbackForEmptySlice verifies that the {@empty} branch is
+// rendered in place of the loop body when the slice has no elements.
+func TestEmpty_RendersFallbackForEmptySlice(t *testing.T) {
+	// Arrange
+	renderer := testcomponents.NewTestRenderer(&TodoList{})
+
+	// Act
+	vnode := renderer.RenderRoot()
+
+	// Assert
+	items := vnode.Children[0]
+	if len(items.Children) != 1 {
+		t.Fatalf("Expected 1 placeholder item, got %d", len(items.Children))
+	}
+	if got := items.Children[0].Content; got != "Nothing to do" {
+		t.Errorf("Expected placeholder 'Nothing to do', got '%s'", got)
+	}
+	if got := items.Children[0].Attributes["class"]; got != "placeholder" {
+		t.Errorf("Expected class 'placeholder', got '%v'", got)
+	}
+}
+
+// TestEmpty_TransitionsBetweenItemsAndFallback verifies that the fallback
+// disappears when items are added and returns when they are removed.
+func TestEmpty_TransitionsBetweenItemsAndFallback(t *testing.T) {
+	// Arrange
+	comp := &TodoList{}
+	renderer := testcomponents.NewTestRenderer(comp)
+	renderer.RenderRoot()
+
+	// Act
+	comp.Items = []string{"write docs", "ship"}
+	renderer.ReRender()
+	withItems := renderer.GetCurrentVDOM()
+	comp.Items = nil
+	renderer.ReRender()
+	emptyAgain := renderer.GetCurrentVDOM()
+
+	// Assert
+	items := withItems.Children[0]
+	if len(items.Children) != 2 {
+		t.Fatalf("Expected 2 items, got %d", len(items.Children))
+	}
+	for i, want := range []string{"write docs", "ship"} {
+		if got := items.Children[i].Content; got != want {
+			t.Errorf("Item %d: expected '%s', got '%s'", i, want, got)
+		}
+		if key := items.Children[i].Key; key != want {
+			t.Errorf("Item %d: expected key '%s', got '%v'", i, want, key)
+		}
+	}
+	placeholder := emptyAgain.Children[0].Children
+	if len(placeholder) != 1 || placeholder[0].Content != "Nothing to do" {
+		t.Errorf("Expected the placeholder after clearing items, got %d children", len(placeholder))
+	}
+}
+
+// TestEmpty_FallbackHasDistinctKey verifies that the fallback node is keyed
+// apart from list items so the keyed reconciler never reuses one for the other.
+func TestEmpty_FallbackHasDistinctKey(t *testing.T) {
+	// Arrange
+	renderer := testcomponents.NewTestRenderer(&TodoList{})
+
+	// Act
+	vnode := renderer.RenderRoot()
+
+	// Assert
+	key := vnode.Children[0].Children[0].Key
+	if key != [2]any{"@empty", 0} {
+		t.Errorf("Expected fallback key [@empty 0], got '%v'", key)
+	}
+}
+
+// TestEmpty_MapAndNestedLoops verifies the fallback for a map loop and for an
+// inner loop whose fallback reads the outer loop variable.
+func TestEmpty_MapAndNestedLoops(t *testing.T) {
+	// Arrange
+	comp := &TodoList{}
+	renderer := testcomponents.NewTestRenderer(comp)
+	vnode := renderer.RenderRoot()
+	if got := vnode.Children[1].Children[0].Content; got != "No groups" {
+		t.Fatalf("Expected 'No groups' placeholder, got '%s'", got)
+	}
+
+	// Act
+	comp.Groups = map[string][]string{"home": nil, "work": {"review"}}
+	renderer.ReRender()
+	vnode = renderer.GetCurrentVDOM()
+
+	// Assert
+	groups := vnode.Children[1].Children
+	if len(groups) != 2 {
+		t.Fatalf("Expected 2 groups, got %d", len(groups))
+	}
+	home, work := groups[0].Children, groups[1].Children
+	if len(home) != 2 || home[1].Content != "No tasks in home" {
+		t.Errorf("Expected 'No tasks in home' fallback, got %d children", len(home))
+	}
+	if len(work) != 2 || work[1].Tag != "span" {
+		t.Errorf("Expected the work group to render its task, got %d children", len(work))
+	}
+}
+
+// TestEmpty_SlotContent_KeyedWithoutChangingTheSlot verifies that slot content rendered by
+// the {@empty} branch is keyed on a copy, so the caller's nodes keep their own keys.
+func TestEmpty_SlotContent_KeyedWithoutChangingTheSlot(t *testing.T) {
+	// Arrange
+	message := vdom.NewVNode("li", nil, nil, "No results")
+	renderer := testcomponents.NewTestRenderer(&ResultList{Empty: []*vdom.VNode{message}})
+
+	// Act
+	vnode := renderer.RenderRoot()
+
+	// Assert
+	if message.Key != nil {
+		t.Errorf("Expected the slot node to keep a nil key, got %v", message.Key)
+	}
+	if len(vnode.Children) != 1 {
+		t.Fatalf("Expected 1 fallback item, got %d", len(vnode.Children))
+	}
+	if got := vnode.Children[0].Content; got != "No results" {
+		t.Errorf("Expected fallback 'No results', got '%s'", got)
+	}
+	if key := vnode.Children[0].Key; key != [2]any{"@empty", 0} {
+		t.Errorf("Expected fallback key [@empty 0], got %v", key)
+	}
+}
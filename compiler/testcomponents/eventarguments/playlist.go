@@ -0,0 +1,42 @@
+package eventarguments
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/ForgeLogic/nojs/runtime"
+)
+
+// Song is one row of the Playlist.
+type Song struct {
+	ID    int
+	Title string
+}
+
+// Playlist is a test component for event handlers called with arguments:
+// loop variables, component fields and expressions over them.
+type Playlist struct {
+	runtime.ComponentBase
+
+	Songs    []Song
+	Draft    string
+	Stars    int
+	Selected string
+	Rating   int
+}
+
+func (c *Playlist) Select(i int, song Song) {
+	c.Selected = fmt.Sprintf("%d:%s", i, song.Title)
+}
+
+func (c *Playlist) Remove(id int) {
+	c.Songs = slices.DeleteFunc(c.Songs, func(s Song) bool { return s.ID == id })
+}
+
+func (c *Playlist) Rename(i int, title string) {
+	c.Songs[i].Title = title
+}
+
+func (c *Playlist) Rate(stars int) {
+	c.Rating = stars
+}
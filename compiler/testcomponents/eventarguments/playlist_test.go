@@ -0,0 +1,91 @@
+//go:build !wasm
+
+package eventarguments
+
+import (
+	"testing"
+
+	"github.com/ForgeLogic/nojs-compiler/testcomponents"
+	"github.com/ForgeLogic/nojs/vdom"
+)
+
+// newPlaylist returns a Playlist with three songs.
+func newPlaylist() *Playlist {
+	return &Playlist{
+		Songs: []Song{{ID: 10, Title: "Intro"}, {ID: 20, Title: "Verse"}, {ID: 30, Title: "Outro"}},
+		Stars: 4,
+	}
+}
+
+// handler returns the event handler of v. The stub adapters return func()
+// handlers, which NewVNode moves from the onClick attribute to OnClick.
+func handler(t *testing.T, v *vdom.VNode, attr string) func() {
+	t.Helper()
+	if attr == "onClick" && v.OnClick != nil {
+		return v.OnClick
+	}
+	h, ok := v.Attributes[attr].(func())
+	if !ok {
+		t.Fatalf("Expected a func() %s handler on <%s>, got %T", attr, v.Tag, v.Attributes[attr])
+	}
+	return h
+}
+
+// TestEventArgs_PassesLoopVariables verifies that a handler call inside a
+// {@for} row receives that row's index and value.
+func TestEventArgs_PassesLoopVariables(t *testing.T) {
+	// Arrange
+	comp := newPlaylist()
+	vnode := testcomponents.NewTestRenderer(comp).RenderRoot()
+	rows := vnode.Children[0].Children
+
+	// Act
+	handler(t, rows[1].Children[0], "onClick")()
+
+	// Assert
+	if comp.Selected != "1:Verse" {
+		t.Errorf("Expected Selected '1:Verse', got '%s'", comp.Selected)
+	}
+}
+
+// TestEventArgs_EachRowCapturesItsOwnItem verifies that the closures of
+// different rows do not share loop variables.
+func TestEventArgs_EachRowCapturesItsOwnItem(t *testing.T) {
+	// Arrange
+	comp := newPlaylist()
+	renderer := testcomponents.NewTestRenderer(comp)
+	rows := renderer.RenderRoot().Children[0].Children
+
+	// Act: each row is <span>, space, <button>, space, <input>
+	handler(t, rows[2].Children[2], "onClick")()
+	handler(t, rows[0].Children[2], "onClick")()
+
+	// Assert
+	if len(comp.Songs) != 1 || comp.Songs[0].ID != 20 {
+		t.Fatalf("Expected only song 20 to remain, got %+v", comp.Songs)
+	}
+}
+
+// TestEventArgs_EvaluatesFieldsWhenFired verifies that component fields in the
+// arguments are read when the event fires, not when the row was rendered.
+func TestEventArgs_EvaluatesFieldsWhenFired(t *testing.T) {
+	// Arrange
+	comp := newPlaylist()
+	vnode := testcomponents.NewTestRenderer(comp).RenderRoot()
+	input := vnode.Children[0].Children[0].Children[4]
+	rateAll := vnode.Children[1]
+	comp.Draft = "Prelude"
+	comp.Stars = 5
+
+	// Act
+	handler(t, input, "onKeydown")()
+	handler(t, rateAll, "onClick")()
+
+	// Assert
+	if comp.Songs[0].Title != "Prelude" {
+		t.Errorf("Expected song 0 to be renamed to 'Prelude', got '%s'", comp.Songs[0].Title)
+	}
+	if comp.Rating != 15 {
+		t.Errorf("Expected Rating 15 (3 songs * 5 stars), got %d", comp.Rating)
+	}
+}
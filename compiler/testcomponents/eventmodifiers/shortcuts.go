@@ -0,0 +1,19 @@
+package eventmodifiers
+
+import "github.com/ForgeLogic/nojs/runtime"
+
+// Shortcuts is a test component for event modifiers. Every handler takes no
+// arguments: the modifiers do what the handlers would otherwise need the event
+// args for.
+type Shortcuts struct {
+	runtime.ComponentBase
+
+	Calls []string
+}
+
+func (c *Shortcuts) OpenDocs()   { c.Calls = append(c.Calls, "OpenDocs") }
+func (c *Shortcuts) Submit()     { c.Calls = append(c.Calls, "Submit") }
+func (c *Shortcuts) Save()       { c.Calls = append(c.Calls, "Save") }
+func (c *Shortcuts) Welcome()    { c.Calls = append(c.Calls, "Welcome") }
+func (c *Shortcuts) ClosePanel() { c.Calls = append(c.Calls, "ClosePanel") }
+func (c *Shortcuts) Track()      { c.Calls = append(c.Calls, "Track") }
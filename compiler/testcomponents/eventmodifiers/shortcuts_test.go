@@ -0,0 +1,85 @@
+//go:build !wasm
+
+package eventmodifiers
+
+import (
+	"testing"
+
+	"github.com/ForgeLogic/nojs-compiler/testcomponents"
+	"github.com/ForgeLogic/nojs/vdom"
+)
+
+// render returns a rendered Shortcuts component. Spaces separate the inline <a>, <input>
+// and <button>, so the elements are children 0, 2, 4 and 5.
+func render() (*Shortcuts, *vdom.VNode) {
+	comp := &Shortcuts{}
+	return comp, testcomponents.NewTestRenderer(comp).RenderRoot()
+}
+
+// TestModifiers_NoArgHandlersAreWired verifies that handlers without event
+// args are accepted for events with modifiers and call the component method.
+func TestModifiers_NoArgHandlersAreWired(t *testing.T) {
+	// Arrange
+	comp, vnode := render()
+	input := vnode.Children[2]
+
+	// Act
+	// The stub adapters return func() handlers, which NewVNode moves to OnClick
+	vnode.Children[0].OnClick()
+	input.Attributes["onKeydown"].(func())()
+	input.Attributes["onKeyup"].(func())()
+
+	// Assert
+	want := []string{"OpenDocs", "Submit", "Save"}
+	if len(comp.Calls) != len(want) {
+		t.Fatalf("Expected calls %v, got %v", want, comp.Calls)
+	}
+	for i := range want {
+		if comp.Calls[i] != want[i] {
+			t.Errorf("Call %d: expected '%s', got '%s'", i, want[i], comp.Calls[i])
+		}
+	}
+}
+
+// TestModifiers_ListenerOptions verifies that .once, .capture and .passive
+// produce a vdom.EventListener carrying the listener options.
+func TestModifiers_ListenerOptions(t *testing.T) {
+	// Arrange & Act
+	comp, vnode := render()
+
+	// Assert
+	once, ok := vnode.Children[4].Attributes["onClick"].(vdom.EventListener)
+	if !ok {
+		t.Fatalf("Expected .once to produce a vdom.EventListener, got %T", vnode.Children[4].Attributes["onClick"])
+	}
+	if !once.Once || once.Capture || once.Passive {
+		t.Errorf("Expected only Once to be set, got %+v", once)
+	}
+	track, ok := vnode.Children[5].Attributes["onMousemove"].(vdom.EventListener)
+	if !ok {
+		t.Fatalf("Expected .passive.capture to produce a vdom.EventListener, got %T", vnode.Children[5].Attributes["onMousemove"])
+	}
+	if !track.Passive || !track.Capture || track.Once {
+		t.Errorf("Expected Passive and Capture to be set, got %+v", track)
+	}
+	track.Handler.(func())()
+	if len(comp.Calls) != 1 || comp.Calls[0] != "Track" {
+		t.Errorf("Expected the wrapped handler to call Track, got %v", comp.Calls)
+	}
+}
+
+// TestModifiers_FiltersKeepPlainHandlers verifies that filter-only modifiers
+// (.self.stop) do not wrap the handler in listener options.
+func TestModifiers_FiltersKeepPlainHandlers(t *testing.T) {
+	// Arrange & Act
+	_, vnode := render()
+
+	// Assert
+	panel := vnode.Children[5]
+	if _, ok := panel.Attributes["onClick"].(vdom.EventListener); ok {
+		t.Errorf("Expected .self.stop not to produce a vdom.EventListener")
+	}
+	if panel.OnClick == nil {
+		t.Errorf("Expected a plain click handler for .self.stop")
+	}
+}
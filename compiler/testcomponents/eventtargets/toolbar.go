@@ -0,0 +1,19 @@
+package eventtargets
+
+import "github.com/ForgeLogic/nojs/runtime"
+
+// Toolbar is a test component for events attached where the HTML schema says
+// the browser delivers them: on any rendered element (onclick on <section>,
+// onkeydown on <button>), and, for bubbling events, on an ancestor of their
+// targets (onsubmit and onchange on a <div> around a form).
+type Toolbar struct {
+	runtime.ComponentBase
+
+	Calls []string
+}
+
+func (c *Toolbar) Focus()    { c.Calls = append(c.Calls, "Focus") }
+func (c *Toolbar) Activate() { c.Calls = append(c.Calls, "Activate") }
+func (c *Toolbar) Submit()   { c.Calls = append(c.Calls, "Submit") }
+func (c *Toolbar) Changed()  { c.Calls = append(c.Calls, "Changed") }
+func (c *Toolbar) Cancel()   { c.Calls = append(c.Calls, "Cancel") }
@@ -0,0 +1,50 @@
+//go:build !wasm
+
+package eventtargets
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/ForgeLogic/nojs-compiler/testcomponents"
+)
+
+// TestToolbar_EventsOnAnyElement verifies that events the browser fires on
+// every element are wired on elements outside the old per-event tag lists.
+func TestToolbar_EventsOnAnyElement(t *testing.T) {
+	// Arrange
+	comp := &Toolbar{}
+	vnode := testcomponents.NewTestRenderer(comp).RenderRoot()
+	button, menu := vnode.Children[0], vnode.Children[2]
+
+	// Act
+	vnode.OnClick()
+	button.Attributes["onKeydown"].(func())()
+	menu.Attributes["onFocus"].(func())()
+	menu.Attributes["onKeyup"].(func())()
+
+	// Assert
+	want := []string{"Focus", "Activate", "Focus", "Cancel"}
+	if !slices.Equal(comp.Calls, want) {
+		t.Errorf("Expected calls %v, got %v", want, comp.Calls)
+	}
+}
+
+// TestToolbar_BubblingEventsOnAncestor verifies that onsubmit and onchange,
+// which target forms and form controls, can be handled on a containing element.
+func TestToolbar_BubblingEventsOnAncestor(t *testing.T) {
+	// Arrange
+	comp := &Toolbar{}
+	vnode := testcomponents.NewTestRenderer(comp).RenderRoot()
+	wrapper := vnode.Children[1]
+
+	// Act
+	wrapper.Attributes["onSubmit"].(func())()
+	wrapper.Attributes["onChange"].(func())()
+
+	// Assert
+	want := []string{"Submit", "Changed"}
+	if !slices.Equal(comp.Calls, want) {
+		t.Errorf("Expected calls %v, got %v", want, comp.Calls)
+	}
+}
@@ -0,0 +1,12 @@
+package expressions
+
+import (
+	"github.com/ForgeLogic/nojs/runtime"
+)
+
+// Badge is a child component receiving a prop computed by an expression.
+type Badge struct {
+	runtime.ComponentBase
+
+	Count int
+}
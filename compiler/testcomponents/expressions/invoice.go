@@ -0,0 +1,53 @@
+package expressions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ForgeLogic/nojs/runtime"
+)
+
+// LineItem is one row of an Invoice.
+type LineItem struct {
+	Name  string
+	Qty   int
+	Cents int
+}
+
+// Invoice is a test component whose template uses full Go expressions in its
+// bindings: arithmetic, comparisons, len(), index expressions, method calls,
+// conversions and package-qualified calls.
+type Invoice struct {
+	runtime.ComponentBase
+
+	Customer      string
+	Lines         []LineItem
+	Currencies    []string
+	CurrencyIndex int
+}
+
+// Total returns the invoice total in cents.
+func (c *Invoice) Total() int {
+	total := 0
+	for _, line := range c.Lines {
+		total += line.Qty * line.Cents
+	}
+	return total
+}
+
+// FormatPrice formats an amount in cents as units with two decimals.
+func (c *Invoice) FormatPrice(cents int) string {
+	return fmt.Sprintf("%d.%02d", cents/100, cents%100)
+}
+
+// RemoveLine drops the line with the given name and triggers a re-render.
+func (c *Invoice) RemoveLine(name string) {
+	lines := c.Lines[:0]
+	for _, line := range c.Lines {
+		if !strings.EqualFold(line.Name, name) {
+			lines = append(lines, line)
+		}
+	}
+	c.Lines = lines
+	c.StateHasChanged()
+}
@@ -0,0 +1,112 @@
+//go:build !wasm
+
+package expressions
+
+import (
+	"testing"
+
+	"github.com/ForgeLogic/nojs-compiler/testcomponents"
+)
+
+// newInvoice returns an invoice with two lines totalling 12.50.
+func newInvoice() *Invoice {
+	return &Invoice{
+		Customer: "acme",
+		Lines: []LineItem{
+			{Name: "Widget", Qty: 2, Cents: 325},
+			{Name: "Gadget", Qty: 1, Cents: 600},
+		},
+		Currencies:    []string{"EUR", "USD"},
+		CurrencyIndex: 1,
+	}
+}
+
+// TestExpressions_TextBindings verifies package calls, len() and index
+// expressions in text bindings.
+func TestExpressions_TextBindings(t *testing.T) {
+	// Arrange
+	renderer := testcomponents.NewTestRenderer(newInvoice())
+
+	// Act
+	vnode := renderer.RenderRoot()
+
+	// Assert
+	if got := vnode.Children[0].Content; got != "ACME" {
+		t.Errorf("Expected heading 'ACME', got '%s'", got)
+	}
+	if got := vnode.Children[1].Content; got != "Lines: 2, currency: USD" {
+		t.Errorf("Expected 'Lines: 2, currency: USD', got '%s'", got)
+	}
+}
+
+// TestExpressions_LoopVariables verifies arithmetic and method calls on loop
+// variables, and a ternary condition built from a comparison.
+func TestExpressions_LoopVariables(t *testing.T) {
+	// Arrange
+	renderer := testcomponents.NewTestRenderer(newInvoice())
+
+	// Act
+	vnode := renderer.RenderRoot()
+
+	// Assert
+	items := vnode.Children[2].Children
+	if len(items) != 2 {
+		t.Fatalf("Expected 2 list items, got %d", len(items))
+	}
+	if got := items[0].Content; got != "1. Widget x2 = 6.50" {
+		t.Errorf("Expected '1. Widget x2 = 6.50', got '%s'", got)
+	}
+	if got := items[1].Content; got != "2. Gadget x1 = 6.00" {
+		t.Errorf("Expected '2. Gadget x1 = 6.00', got '%s'", got)
+	}
+	if got := items[0].Attributes["class"]; got != "line multi" {
+		t.Errorf("Expected class 'line multi', got '%v'", got)
+	}
+	if got := items[1].Attributes["class"]; got != "line single" {
+		t.Errorf("Expected class 'line single', got '%v'", got)
+	}
+}
+
+// TestExpressions_MethodCallAndConversion verifies method calls and a
+// conversion inside a comparison.
+func TestExpressions_MethodCallAndConversion(t *testing.T) {
+	// Arrange
+	renderer := testcomponents.NewTestRenderer(newInvoice())
+
+	// Act
+	vnode := renderer.RenderRoot()
+
+	// Assert
+	if got := vnode.Children[3].Content; got != "Total: 12.50 (true)" {
+		t.Errorf("Expected 'Total: 12.50 (true)', got '%s'", got)
+	}
+}
+
+// TestExpressions_AttributeAndProp verifies that a bool expression drives a
+// boolean attribute and that an int expression is passed as a child prop.
+func TestExpressions_AttributeAndProp(t *testing.T) {
+	// Arrange
+	invoice := newInvoice()
+	renderer := testcomponents.NewTestRenderer(invoice)
+	vnode := renderer.RenderRoot()
+
+	if got := vnode.Children[4].Attributes["disabled"]; got != false {
+		t.Errorf("Expected disabled=false with lines, got '%v'", got)
+	}
+	if got := vnode.Children[5].Content; got != "4" {
+		t.Errorf("Expected badge '4', got '%s'", got)
+	}
+
+	// Act: remove every line
+	invoice.RemoveLine("widget")
+	invoice.RemoveLine("gadget")
+
+	// Assert
+	vnode = renderer.GetCurrentVDOM()
+	if got := vnode.Children[4].Attributes["disabled"]; got != true {
+		t.Errorf("Expected disabled=true without lines, got '%v'", got)
+	}
+	if got := vnode.Children[5].Content; got != "0" {
+		t.Errorf("Expected badge '0', got '%s'", got)
+	}
+}
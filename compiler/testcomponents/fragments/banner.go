@@ -0,0 +1,17 @@
+package fragments
+
+import "github.com/ForgeLogic/nojs/runtime"
+
+// Banner is a test component whose root is an {@if} block of two nodes.
+type Banner struct {
+	runtime.ComponentBase
+
+	Text      string
+	Dismissed bool
+}
+
+// Dismiss hides the banner.
+func (c *Banner) Dismiss() {
+	c.Dismissed = true
+	c.StateHasChanged()
+}
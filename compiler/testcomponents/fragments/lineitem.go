@@ -0,0 +1,12 @@
+package fragments
+
+import "github.com/ForgeLogic/nojs/runtime"
+
+// LineItem renders an order line as two sibling table rows.
+type LineItem struct {
+	runtime.ComponentBase
+
+	Name string
+	Qty  int
+	Note string
+}
@@ -0,0 +1,21 @@
+package fragments
+
+import "github.com/ForgeLogic/nojs/runtime"
+
+// Line is one line of an order.
+type Line struct {
+	ID   int
+	Name string
+	Qty  int
+	Note string
+}
+
+// Order is a test component whose template has several root nodes and renders a
+// multi-root component per table line.
+type Order struct {
+	runtime.ComponentBase
+
+	Number int
+	Lines  []Line
+	Status string
+}
@@ -0,0 +1,91 @@
+//go:build !wasm
+
+package fragments
+
+import (
+	"testing"
+
+	"github.com/ForgeLogic/nojs-compiler/testcomponents"
+	"github.com/ForgeLogic/nojs/vdom"
+)
+
+// newOrder returns an order with two lines.
+func newOrder() *Order {
+	return &Order{
+		Number: 7,
+		Lines: []Line{
+			{ID: 1, Name: "Pen", Qty: 2, Note: "blue"},
+			{ID: 2, Name: "Pad", Qty: 1, Note: "lined"},
+		},
+		Status: "Shipped",
+	}
+}
+
+// TestOrder_MultiRootTemplate_RendersFragment verifies that every top-level node of the
+// template is rendered, inside a fragment rather than a wrapper element.
+func TestOrder_MultiRootTemplate_RendersFragment(t *testing.T) {
+	// Arrange
+	comp := newOrder()
+	renderer := testcomponents.NewTestRenderer(comp)
+
+	// Act
+	root := renderer.RenderRoot()
+
+	// Assert
+	if root.Tag != "#fragment" {
+		t.Fatalf("Expected a #fragment root, got <%s>", root.Tag)
+	}
+	var tags []string
+	for _, child := range root.Children {
+		tags = append(tags, child.Tag)
+	}
+	if len(tags) != 3 || tags[0] != "h2" || tags[1] != "table" || tags[2] != "#fragment" {
+		t.Errorf("Expected root nodes [h2 table #fragment], got %v", tags)
+	}
+}
+
+// TestOrder_MultiRootComponent_RendersSiblingRows verifies that a component whose template
+// is two <tr> elements renders both rows directly into the parent's <tbody>.
+func TestOrder_MultiRootComponent_RendersSiblingRows(t *testing.T) {
+	// Arrange
+	comp := newOrder()
+	renderer := testcomponents.NewTestRenderer(comp)
+
+	// Act
+	table := renderer.RenderRoot().Children[1]
+
+	// Assert
+	want := `<table><tbody>` +
+		`<tr class="item"><td>Pen</td><td>2</td></tr><tr class="note"><td colspan="2">blue</td></tr>` +
+		`<tr class="item"><td>Pad</td><td>1</td></tr><tr class="note"><td colspan="2">lined</td></tr>` +
+		`</tbody></table>`
+	if got := vdom.RenderHTMLString(table); got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+	for i, line := range table.Children[0].Children {
+		if line.Key != comp.Lines[i].ID {
+			t.Errorf("Expected line %d to be keyed by %d, got %v", i, comp.Lines[i].ID, line.Key)
+		}
+	}
+}
+
+// TestBanner_RootIf_RendersEveryNodeOfTheBranch verifies that a root {@if} renders all of
+// its nodes while true and nothing once it turns false.
+func TestBanner_RootIf_RendersEveryNodeOfTheBranch(t *testing.T) {
+	// Arrange
+	comp := &Banner{Text: "Shipped"}
+	renderer := testcomponents.NewTestRenderer(comp)
+	root := renderer.RenderRoot()
+	if got := vdom.RenderHTMLString(root); got != "<strong>Shipped</strong> <button>Dismiss</button>" {
+		t.Fatalf("Expected the banner's nodes, got %s", got)
+	}
+
+	// Act
+	root.Children[2].OnClick()
+
+	// Assert
+	root = renderer.GetCurrentVDOM()
+	if root.Tag != "#fragment" || len(root.Children) != 0 {
+		t.Errorf("Expected an empty fragment after Dismiss, got <%s> with %d children", root.Tag, len(root.Children))
+	}
+}
@@ -0,0 +1,14 @@
+package generics
+
+import (
+	"github.com/ForgeLogic/nojs/runtime"
+	"github.com/ForgeLogic/nojs/vdom"
+)
+
+// ItemList is a generic test component whose scoped slot receives items of its type parameter.
+type ItemList[Item any] struct {
+	runtime.ComponentBase
+
+	Items []Item
+	Row   func(item Item) *vdom.VNode
+}
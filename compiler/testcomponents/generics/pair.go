@@ -0,0 +1,11 @@
+package generics
+
+import "github.com/ForgeLogic/nojs/runtime"
+
+// Pair is a test component with two type parameters.
+type Pair[K comparable, V any] struct {
+	runtime.ComponentBase
+
+	Key   K
+	Value V
+}
@@ -0,0 +1,18 @@
+package generics
+
+import "github.com/ForgeLogic/nojs/runtime"
+
+// Picker is a generic test component listing options of any comparable type and
+// highlighting the selected one.
+type Picker[T comparable] struct {
+	runtime.ComponentBase
+
+	Options  []T
+	Selected T
+}
+
+// Choose selects an option and re-renders.
+func (c *Picker[T]) Choose(option T) {
+	c.Selected = option
+	c.StateHasChanged()
+}
@@ -0,0 +1,17 @@
+package generics
+
+import "github.com/ForgeLogic/nojs/runtime"
+
+// Size is a named option type for the size picker.
+type Size int
+
+// Settings is a test component instantiating generic components with inferred and explicit
+// type arguments.
+type Settings struct {
+	runtime.ComponentBase
+
+	Colors []string
+	Color  string
+	Sizes  []Size
+	Volume float64
+}
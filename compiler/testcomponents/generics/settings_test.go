@@ -0,0 +1,89 @@
+//go:build !wasm
+
+package generics
+
+import (
+	"testing"
+
+	"github.com/ForgeLogic/nojs-compiler/testcomponents"
+	"github.com/ForgeLogic/nojs/vdom"
+)
+
+// TestSettings_InferredTypeArguments_RenderProps verifies that a generic component is
+// instantiated with the type arguments of the props bound to it.
+func TestSettings_InferredTypeArguments_RenderProps(t *testing.T) {
+	// Arrange
+	comp := &Settings{Colors: []string{"red", "green"}, Color: "green", Volume: 0.5}
+	renderer := testcomponents.NewTestRenderer(comp)
+
+	// Act
+	root := renderer.RenderRoot()
+
+	// Assert
+	want := `<ul class="picker"><li class="">red</li><li class="selected">green</li></ul>`
+	if got := vdom.RenderHTMLString(root.Children[0]); got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+	want = `<span class="pair">2: 0.5</span>`
+	if got := vdom.RenderHTMLString(root.Children[2]); got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+}
+
+// TestSettings_ExplicitTypeArgument_TypesUntypedProps verifies that type:T on the tag
+// instantiates the component, so an untyped constant prop takes the named type.
+func TestSettings_ExplicitTypeArgument_TypesUntypedProps(t *testing.T) {
+	// Arrange
+	comp := &Settings{Sizes: []Size{1, 2, 3}}
+	renderer := testcomponents.NewTestRenderer(comp)
+
+	// Act
+	picker := renderer.RenderRoot().Children[1]
+
+	// Assert
+	want := `<ul class="picker"><li class="">1</li><li class="selected">2</li><li class="">3</li></ul>`
+	if got := vdom.RenderHTMLString(picker); got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+	if picker.Children[1].Key != Size(2) {
+		t.Errorf("Expected the option keyed by Size(2), got %#v", picker.Children[1].Key)
+	}
+}
+
+// TestSettings_GenericScopedSlot_ReceivesTypedItems verifies that the scoped slot of a
+// generic component receives values of the inferred type argument.
+func TestSettings_GenericScopedSlot_ReceivesTypedItems(t *testing.T) {
+	// Arrange
+	comp := &Settings{Sizes: []Size{1, 2}}
+	renderer := testcomponents.NewTestRenderer(comp)
+
+	// Act
+	list := renderer.RenderRoot().Children[3]
+
+	// Assert
+	want := `<ol class="items"><li><b>10</b></li><li><b>20</b></li></ol>`
+	if got := vdom.RenderHTMLString(list); got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+}
+
+// TestPicker_Choose_SelectsOption verifies that the generated methods of a generic
+// component handle events with values of its type parameter.
+func TestPicker_Choose_SelectsOption(t *testing.T) {
+	// Arrange
+	comp := &Picker[string]{Options: []string{"red", "green"}}
+	renderer := testcomponents.NewTestRenderer(comp)
+	options := renderer.RenderRoot().Children
+
+	// Act
+	options[0].OnClick()
+
+	// Assert
+	if comp.Selected != "red" {
+		t.Fatalf("Expected 'red' to be selected, got %q", comp.Selected)
+	}
+	want := `<ul class="picker"><li class="selected">red</li><li class="">green</li></ul>`
+	if got := vdom.RenderHTMLString(renderer.GetCurrentVDOM()); got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+}
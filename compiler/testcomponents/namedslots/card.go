@@ -0,0 +1,16 @@
+package namedslots
+
+import (
+	"github.com/ForgeLogic/nojs/runtime"
+	"github.com/ForgeLogic/nojs/vdom"
+)
+
+// Card is a test component with three slots. Body is declared first, so it is the default
+// slot that receives content outside a <slot name="..."> block.
+type Card struct {
+	runtime.ComponentBase
+
+	Body   []*vdom.VNode
+	Header []*vdom.VNode
+	Footer []*vdom.VNode
+}
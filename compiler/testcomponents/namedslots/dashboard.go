@@ -0,0 +1,18 @@
+package namedslots
+
+import "github.com/ForgeLogic/nojs/runtime"
+
+// Dashboard is a test component filling the named slots of two cards.
+type Dashboard struct {
+	runtime.ComponentBase
+
+	Title     string
+	Summary   string
+	Refreshes int
+}
+
+// Refresh counts a refresh and re-renders.
+func (c *Dashboard) Refresh() {
+	c.Refreshes++
+	c.StateHasChanged()
+}
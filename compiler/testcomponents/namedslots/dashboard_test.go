@@ -0,0 +1,134 @@
+//go:build !wasm
+
+package namedslots
+
+import (
+	"testing"
+
+	"github.com/ForgeLogic/nojs-compiler/testcomponents"
+	"github.com/ForgeLogic/nojs/runtime"
+	"github.com/ForgeLogic/nojs/vdom"
+)
+
+// TestDashboard_NamedSlots_FillTheirRegions verifies that <slot name="..."> blocks and
+// {@slot} blocks fill their slot, and that other content fills the default slot.
+func TestDashboard_NamedSlots_FillTheirRegions(t *testing.T) {
+	// Arrange
+	comp := &Dashboard{Title: "Sales", Summary: "Up 5%"}
+	renderer := testcomponents.NewTestRenderer(comp)
+
+	// Act
+	card := renderer.RenderRoot().Children[0]
+
+	// Assert
+	want := `<article class="card">` +
+		`<header><h3>Sales</h3></header>` +
+		`<section><p>Up 5%</p></section>` +
+		`<footer><button>Refresh</button> <span>0 refreshes</span></footer>` +
+		`</article>`
+	if got := vdom.RenderHTMLString(card); got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+}
+
+// TestDashboard_EmptySlotBlocks_RenderNothing verifies that an empty <slot name="...">
+// block leaves its slot empty.
+func TestDashboard_EmptySlotBlocks_RenderNothing(t *testing.T) {
+	// Arrange
+	comp := &Dashboard{}
+	renderer := testcomponents.NewTestRenderer(comp)
+
+	// Act
+	card := renderer.RenderRoot().Children[1]
+
+	// Assert
+	want := `<article class="card"><header></header><section><p>Details</p></section><footer></footer></article>`
+	if got := vdom.RenderHTMLString(card); got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+}
+
+// TestDashboard_SlotContent_CallsParentHandlers verifies that handlers in a named slot run
+// on the parent and that the slot shows the parent's new state.
+func TestDashboard_SlotContent_CallsParentHandlers(t *testing.T) {
+	// Arrange
+	comp := &Dashboard{}
+	renderer := testcomponents.NewTestRenderer(comp)
+	footer := renderer.RenderRoot().Children[0].Children[2]
+
+	// Act
+	footer.Children[0].OnClick()
+
+	// Assert
+	if comp.Refreshes != 1 {
+		t.Errorf("Expected Refreshes 1, got %d", comp.Refreshes)
+	}
+	footer = renderer.GetCurrentVDOM().Children[0].Children[2]
+	if got := footer.Children[2].Content; got != "1 refreshes" {
+		t.Errorf("Expected '1 refreshes', got '%s'", got)
+	}
+}
+
+// TestRepeat_SlotInLoop_KeysStayStable verifies that slot content rendered in every
+// iteration of a loop is keyed per iteration without changing the slot's own nodes, so
+// each render produces the same keys.
+func TestRepeat_SlotInLoop_KeysStayStable(t *testing.T) {
+	// Arrange
+	item := vdom.NewVNode("b", nil, nil, "item")
+	comp := &Repeat{Rows: 2, Body: []*vdom.VNode{item}}
+	renderer := testcomponents.NewTestRenderer(comp)
+	first := renderer.RenderRoot()
+
+	// Act
+	renderer.ReRender()
+	second := renderer.GetCurrentVDOM()
+
+	// Assert
+	if item.Key != nil {
+		t.Errorf("Expected the slot node to keep a nil key, got %v", item.Key)
+	}
+	if len(first.Children) != 4 || len(second.Children) != 4 {
+		t.Fatalf("Expected 4 children per render, got %d and %d", len(first.Children), len(second.Children))
+	}
+	if first.Children[1].Key == first.Children[3].Key {
+		t.Errorf("Expected each iteration's copy of the slot to have its own key, got %v twice", first.Children[1].Key)
+	}
+	for i := range first.Children {
+		if first.Children[i].Key != second.Children[i].Key {
+			t.Errorf("Expected child %d to keep key %v, got %v", i, first.Children[i].Key, second.Children[i].Key)
+		}
+	}
+}
+
+// childRecorder is a renderer that keeps every child component it renders.
+type childRecorder struct {
+	*testcomponents.TestRenderer
+	children []runtime.Component
+}
+
+func (r *childRecorder) RenderChild(key string, child runtime.Component) *vdom.VNode {
+	r.children = append(r.children, child)
+	child.SetRenderer(r)
+	return child.Render(r)
+}
+
+// TestDashboard_EmptySlotBlocks_PassEmptySlices verifies that an empty <slot name="...">
+// block passes an empty slice rather than nil, which in dev builds the runtime reports as
+// an unfilled slot.
+func TestDashboard_EmptySlotBlocks_PassEmptySlices(t *testing.T) {
+	// Arrange
+	comp := &Dashboard{}
+	recorder := &childRecorder{TestRenderer: testcomponents.NewTestRenderer(comp)}
+
+	// Act
+	comp.Render(recorder)
+
+	// Assert
+	if len(recorder.children) != 2 {
+		t.Fatalf("Expected 2 cards, got %d", len(recorder.children))
+	}
+	card := recorder.children[1].(*Card)
+	if card.Header == nil || card.Footer == nil {
+		t.Errorf("Expected empty, non-nil Header and Footer, got %#v and %#v", card.Header, card.Footer)
+	}
+}
@@ -0,0 +1,14 @@
+package namedslots
+
+import (
+	"github.com/ForgeLogic/nojs/runtime"
+	"github.com/ForgeLogic/nojs/vdom"
+)
+
+// Repeat is a test component that renders its slot content once per row.
+type Repeat struct {
+	runtime.ComponentBase
+
+	Rows int
+	Body []*vdom.VNode
+}
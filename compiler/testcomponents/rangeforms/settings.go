@@ -0,0 +1,38 @@
+package rangeforms
+
+import (
+	"iter"
+	"slices"
+	"strings"
+
+	"github.com/ForgeLogic/nojs/runtime"
+)
+
+// Settings is a test component exercising every {@for} range form beyond
+// slices: a map (rendered in sorted key order, with and without its values),
+// an integer field, an integer constant, an iter.Seq and an iter.Seq2.
+type Settings struct {
+	runtime.ComponentBase
+
+	Values  map[string]int
+	Rating  int
+	TagList []string
+}
+
+// Tags yields the tag list in sorted order.
+func (c *Settings) Tags() iter.Seq[string] {
+	sorted := slices.Clone(c.TagList)
+	slices.Sort(sorted)
+	return slices.Values(sorted)
+}
+
+// Numbered yields each tag with a 1-based position.
+func (c *Settings) Numbered() iter.Seq2[int, string] {
+	return func(yield func(int, string) bool) {
+		for i, tag := range c.TagList {
+			if !yield(i+1, strings.TrimSpace(tag)) {
+				return
+			}
+		}
+	}
+}
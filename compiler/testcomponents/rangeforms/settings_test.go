@@ -0,0 +1,117 @@
+//go:build !wasm
+
+package rangeforms
+
+import (
+	"testing"
+
+	"github.com/ForgeLogic/nojs-compiler/testcomponents"
+	"github.com/ForgeLogic/nojs/vdom"
+)
+
+// contents returns the text content of each child of list.
+func contents(list *vdom.VNode) []string {
+	var result []string
+	for _, item := range list.Children {
+		result = append(result, item.Content)
+	}
+	return result
+}
+
+// assertContents fails the test if the list's item texts differ from want.
+func assertContents(t *testing.T, name string, list *vdom.VNode, want ...string) {
+	t.Helper()
+	got := contents(list)
+	if len(got) != len(want) {
+		t.Fatalf("%s: expected %d items %v, got %d items %v", name, len(want), want, len(got), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("%s: item %d expected '%s', got '%s'", name, i, want[i], got[i])
+		}
+	}
+}
+
+// newSettings returns a Settings component with data for every range form.
+func newSettings() *Settings {
+	return &Settings{
+		Values:  map[string]int{"volume": 7, "brightness": 3, "contrast": 5},
+		Rating:  2,
+		TagList: []string{"wasm", "go"},
+	}
+}
+
+// TestRange_MapInSortedKeyOrder verifies that maps are iterated in sorted key
+// order and keyed by the map key.
+func TestRange_MapInSortedKeyOrder(t *testing.T) {
+	// Arrange
+	renderer := testcomponents.NewTestRenderer(newSettings())
+
+	// Act
+	vnode := renderer.RenderRoot()
+
+	// Assert
+	list := vnode.Children[0]
+	assertContents(t, "map", list, "brightness=3", "contrast=5", "volume=7")
+	if key := list.Children[0].Key; key != "brightness" {
+		t.Errorf("Expected first item keyed by 'brightness', got '%v'", key)
+	}
+}
+
+// TestRange_MapKeysOnly verifies that a map range discarding the value ({@for name, _ := ...})
+// iterates the keys in sorted order.
+func TestRange_MapKeysOnly(t *testing.T) {
+	// Arrange
+	renderer := testcomponents.NewTestRenderer(newSettings())
+
+	// Act
+	vnode := renderer.RenderRoot()
+
+	// Assert
+	assertContents(t, "map keys", vnode.Children[5], "brightness", "contrast", "volume")
+}
+
+// TestRange_Integers verifies ranges over an int field and an int constant,
+// keyed by the integer when trackBy is omitted.
+func TestRange_Integers(t *testing.T) {
+	// Arrange
+	renderer := testcomponents.NewTestRenderer(newSettings())
+
+	// Act
+	vnode := renderer.RenderRoot()
+
+	// Assert
+	assertContents(t, "int field", vnode.Children[1], "1", "2")
+	assertContents(t, "int constant", vnode.Children[2], "0", "1", "2")
+	if key := vnode.Children[2].Children[2].Key; key != 2 {
+		t.Errorf("Expected integer key 2, got '%v'", key)
+	}
+}
+
+// TestRange_Iterators verifies ranges over iter.Seq and iter.Seq2 methods.
+func TestRange_Iterators(t *testing.T) {
+	// Arrange
+	renderer := testcomponents.NewTestRenderer(newSettings())
+
+	// Act
+	vnode := renderer.RenderRoot()
+
+	// Assert
+	assertContents(t, "iter.Seq", vnode.Children[3], "GO", "WASM")
+	assertContents(t, "iter.Seq2", vnode.Children[4], "1:wasm", "2:go")
+}
+
+// TestRange_MapUpdate verifies that adding a map entry re-renders in order.
+func TestRange_MapUpdate(t *testing.T) {
+	// Arrange
+	settings := newSettings()
+	renderer := testcomponents.NewTestRenderer(settings)
+	renderer.RenderRoot()
+
+	// Act
+	settings.Values["balance"] = 1
+	settings.StateHasChanged()
+
+	// Assert
+	assertContents(t, "map", renderer.GetCurrentVDOM().Children[0], "balance=1", "brightness=3", "contrast=5", "volume=7")
+}
@@ -0,0 +1,10 @@
+package scopedcss
+
+import "github.com/ForgeLogic/nojs/runtime"
+
+// Chip is a test component with a scoped stylesheet.
+type Chip struct {
+	runtime.ComponentBase
+
+	Label string
+}
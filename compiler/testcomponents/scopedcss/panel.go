@@ -0,0 +1,8 @@
+package scopedcss
+
+import "github.com/ForgeLogic/nojs/runtime"
+
+// Panel is a test component whose scoped stylesheet uses the same class name as Chip's.
+type Panel struct {
+	runtime.ComponentBase
+}
@@ -0,0 +1,71 @@
+//go:build !wasm
+
+package scopedcss
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/ForgeLogic/nojs-compiler/testcomponents"
+	"github.com/ForgeLogic/nojs/vdom"
+)
+
+// The scope attributes are hashes of the component import paths, so they are fixed.
+const (
+	chipScope  = "data-nojs-0043a0eb"
+	panelScope = "data-nojs-47b18b71"
+)
+
+// TestPanel_ScopeAttributes_StampedOnOwnElements verifies that every element carries the
+// scope attribute of the component whose template renders it, not of its parent.
+func TestPanel_ScopeAttributes_StampedOnOwnElements(t *testing.T) {
+	// Arrange
+	comp := &Panel{}
+	renderer := testcomponents.NewTestRenderer(comp)
+
+	// Act
+	root := renderer.RenderRoot()
+
+	// Assert
+	want := `<div class="panel" ` + panelScope + `="">` +
+		`<span class="chip" ` + chipScope + `=""><b ` + chipScope + `="">New</b></span>` +
+		`<p class="chip" ` + panelScope + `="">Plain text</p>` +
+		`</div>`
+	if got := vdom.RenderHTMLString(root); got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+}
+
+// TestPanel_CSSBundle_ScopesSelectorsInOrder verifies that the bundle holds each
+// component's stylesheet in name order with its selectors scoped, including rules inside
+// @media, while @keyframes blocks are kept as they are.
+func TestPanel_CSSBundle_ScopesSelectorsInOrder(t *testing.T) {
+	// Arrange
+	src, err := os.ReadFile("../components.generated.css")
+	if err != nil {
+		t.Fatalf("Failed to read the CSS bundle: %v", err)
+	}
+
+	// Act
+	bundle := string(src)
+
+	// Assert
+	for _, want := range []string{
+		".chip[" + chipScope + "] {",
+		".chip > b[" + chipScope + "]:hover, .chip[" + chipScope + "]::after {",
+		"@media (max-width: 600px) {\n.chip[" + chipScope + "] { font-size: 0.8em; }\n}",
+		"@keyframes pop {\n    from { opacity: 0; }",
+		".panel .chip[" + panelScope + "] {",
+	} {
+		if !strings.Contains(bundle, want) {
+			t.Errorf("Expected the bundle to contain %q, got:\n%s", want, bundle)
+		}
+	}
+	if strings.Contains(bundle, "The chip and its label") {
+		t.Errorf("Expected comments to be dropped from the bundle, got:\n%s", bundle)
+	}
+	if chip, panel := strings.Index(bundle, "scopedcss.Chip"), strings.Index(bundle, "scopedcss.Panel"); chip < 0 || panel < chip {
+		t.Errorf("Expected Chip's stylesheet before Panel's, got:\n%s", bundle)
+	}
+}
@@ -0,0 +1,21 @@
+package scopedslots
+
+import (
+	"github.com/ForgeLogic/nojs/runtime"
+	"github.com/ForgeLogic/nojs/vdom"
+)
+
+// User is a row of the test lists.
+type User struct {
+	ID   int
+	Name string
+}
+
+// DataList is a test component rendering each item through a scoped slot, so the parent
+// controls the markup of every list item.
+type DataList struct {
+	runtime.ComponentBase
+
+	Items []User
+	Row   func(user User) *vdom.VNode
+}
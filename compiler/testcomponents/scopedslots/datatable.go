@@ -0,0 +1,14 @@
+package scopedslots
+
+import (
+	"github.com/ForgeLogic/nojs/runtime"
+	"github.com/ForgeLogic/nojs/vdom"
+)
+
+// DataTable is a test component whose scoped slot renders whole table rows.
+type DataTable struct {
+	runtime.ComponentBase
+
+	Rows []User
+	Row  func(user User) *vdom.VNode
+}
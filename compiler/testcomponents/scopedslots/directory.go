@@ -0,0 +1,21 @@
+package scopedslots
+
+import "github.com/ForgeLogic/nojs/runtime"
+
+// Directory is a test component filling the scoped slots of a list and a table.
+type Directory struct {
+	runtime.ComponentBase
+
+	Users []User
+}
+
+// Remove deletes the user with the given ID and re-renders.
+func (c *Directory) Remove(id int) {
+	for i, user := range c.Users {
+		if user.ID == id {
+			c.Users = append(c.Users[:i], c.Users[i+1:]...)
+			break
+		}
+	}
+	c.StateHasChanged()
+}
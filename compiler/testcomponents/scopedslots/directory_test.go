@@ -0,0 +1,80 @@
+//go:build !wasm
+
+package scopedslots
+
+import (
+	"testing"
+
+	"github.com/ForgeLogic/nojs-compiler/testcomponents"
+	"github.com/ForgeLogic/nojs/vdom"
+)
+
+func newUsers() []User {
+	return []User{{ID: 1, Name: "Ann"}, {ID: 2, Name: "Bob"}}
+}
+
+// TestDirectory_ScopedSlot_RendersEveryItem verifies that the child calls the scoped slot
+// once per item and that the slot content sees the item through its let: variable.
+func TestDirectory_ScopedSlot_RendersEveryItem(t *testing.T) {
+	// Arrange
+	comp := &Directory{Users: newUsers()}
+	renderer := testcomponents.NewTestRenderer(comp)
+
+	// Act
+	list := renderer.RenderRoot().Children[0]
+
+	// Assert
+	want := `<ul class="data-list">` +
+		`<li><strong>Ann</strong> <button>Remove</button></li>` +
+		`<li><strong>Bob</strong> <button>Remove</button></li>` +
+		`</ul>`
+	if got := vdom.RenderHTMLString(list); got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+}
+
+// TestDirectory_ScopedSlot_RendersTableRows verifies that a scoped slot can render table
+// rows, which the child keys by its trackBy expression.
+func TestDirectory_ScopedSlot_RendersTableRows(t *testing.T) {
+	// Arrange
+	comp := &Directory{Users: newUsers()}
+	renderer := testcomponents.NewTestRenderer(comp)
+
+	// Act
+	table := renderer.RenderRoot().Children[1]
+
+	// Assert
+	want := `<table><thead><tr><th>ID</th><th>Name</th></tr></thead><tbody>` +
+		`<tr><td>1</td><td>Ann</td></tr>` +
+		`<tr><td>2</td><td>Bob</td></tr>` +
+		`</tbody></table>`
+	if got := vdom.RenderHTMLString(table); got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+	rows := table.Children[1].Children
+	if rows[0].Key != 1 || rows[1].Key != 2 {
+		t.Errorf("Expected rows keyed 1 and 2, got %v and %v", rows[0].Key, rows[1].Key)
+	}
+}
+
+// TestDirectory_ScopedSlotHandler_CallsParentWithItem verifies that a handler inside a
+// scoped slot runs on the parent with the value of the item it was rendered for.
+func TestDirectory_ScopedSlotHandler_CallsParentWithItem(t *testing.T) {
+	// Arrange
+	comp := &Directory{Users: newUsers()}
+	renderer := testcomponents.NewTestRenderer(comp)
+	list := renderer.RenderRoot().Children[0]
+	remove := list.Children[0].Children[0].Children[2]
+
+	// Act
+	remove.OnClick()
+
+	// Assert
+	if len(comp.Users) != 1 || comp.Users[0].Name != "Bob" {
+		t.Fatalf("Expected only Bob to remain, got %v", comp.Users)
+	}
+	want := `<ul class="data-list"><li><strong>Bob</strong> <button>Remove</button></li></ul>`
+	if got := vdom.RenderHTMLString(renderer.GetCurrentVDOM().Children[0]); got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+}
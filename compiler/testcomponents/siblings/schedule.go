@@ -0,0 +1,30 @@
+package siblings
+
+import "github.com/ForgeLogic/nojs/runtime"
+
+// Slot is one talk in the schedule.
+type Slot struct {
+	ID      int
+	Day     string
+	Talk    string
+	Keynote bool
+	Speaker string
+}
+
+// Track groups talks by topic.
+type Track struct {
+	Name  string
+	Talks []string
+}
+
+// Schedule is a test component whose {@if}, {@switch} and {@for} blocks render several
+// sibling nodes into grids, tables and lists.
+type Schedule struct {
+	runtime.ComponentBase
+
+	ShowHeader bool
+	Updated    string
+	Slots      []Slot
+	Level      string
+	Tracks     []Track
+}
@@ -0,0 +1,142 @@
+//go:build !wasm
+
+package siblings
+
+import (
+	"testing"
+
+	"github.com/ForgeLogic/nojs-compiler/testcomponents"
+	"github.com/ForgeLogic/nojs/vdom"
+)
+
+// newSchedule returns a schedule with two talks, the second a keynote.
+func newSchedule() *Schedule {
+	return &Schedule{
+		ShowHeader: true,
+		Updated:    "today",
+		Slots: []Slot{
+			{ID: 1, Day: "Mon", Talk: "Go tips"},
+			{ID: 2, Day: "Tue", Talk: "WASM", Keynote: true, Speaker: "Ada"},
+		},
+		Level: "beginner",
+		Tracks: []Track{
+			{Name: "web", Talks: []string{"vdom", "router"}},
+			{Name: "tools", Talks: []string{"compiler"}},
+		},
+	}
+}
+
+// describe returns "tag:content" for every child, "#text:content" for text nodes.
+func describe(children []*vdom.VNode) []string {
+	var result []string
+	for _, c := range children {
+		text := c.Content
+		if text == "" && len(c.Children) > 0 {
+			text = c.Children[0].Content
+		}
+		result = append(result, c.Tag+":"+text)
+	}
+	return result
+}
+
+// assertNodes compares the described children with want.
+func assertNodes(t *testing.T, what string, children []*vdom.VNode, want ...string) {
+	t.Helper()
+	got := describe(children)
+	if len(got) != len(want) {
+		t.Fatalf("Expected %s %v, got %v", what, want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Expected %s %v, got %v", what, want, got)
+			return
+		}
+	}
+}
+
+// TestSchedule_BranchesAndLoopBodiesRenderAllSiblings verifies that every node of an {@if}
+// branch and of a loop body is spliced into the grid, without wrapper elements.
+func TestSchedule_BranchesAndLoopBodiesRenderAllSiblings(t *testing.T) {
+	// Arrange & Act
+	vnode := testcomponents.NewTestRenderer(newSchedule()).RenderRoot()
+
+	// Assert
+	assertNodes(t, "grid cells", vnode.Children[0].Children,
+		"span:Day", "#text: ", "span:Talk", "#text: Updated today",
+		"span:Mon", "#text: ", "span:Go tips",
+		"span:Tue", "#text: ", "span:WASM", "strong:Keynote", "#text: ", "em:Ada")
+}
+
+// TestSchedule_FalseBranchRendersNothing verifies that a false {@if} leaves no node behind.
+func TestSchedule_FalseBranchRendersNothing(t *testing.T) {
+	// Arrange
+	comp := newSchedule()
+	comp.ShowHeader = false
+
+	// Act
+	vnode := testcomponents.NewTestRenderer(comp).RenderRoot()
+
+	// Assert
+	if got := describe(vnode.Children[0].Children); len(got) != 9 || got[0] != "span:Mon" {
+		t.Errorf("Expected the grid to start with the first slot, got %v", got)
+	}
+}
+
+// TestSchedule_TableRowsFromDirectives verifies that loops and conditionals inside <tbody>
+// produce rows of the table.
+func TestSchedule_TableRowsFromDirectives(t *testing.T) {
+	// Arrange & Act
+	vnode := testcomponents.NewTestRenderer(newSchedule()).RenderRoot()
+
+	// Assert
+	table := vnode.Children[1]
+	if len(table.Children) != 1 || table.Children[0].Tag != "tbody" {
+		t.Fatalf("Expected the table to hold a single <tbody>, got %v", describe(table.Children))
+	}
+	assertNodes(t, "table rows", table.Children[0].Children, "tr:Go tips", "tr:WASM", "tr:Keynote by Ada")
+}
+
+// TestSchedule_EmptyBranchRendersAllSiblings verifies that an {@empty} branch with several
+// rows renders all of them.
+func TestSchedule_EmptyBranchRendersAllSiblings(t *testing.T) {
+	// Arrange
+	comp := newSchedule()
+	comp.Slots = nil
+
+	// Act
+	vnode := testcomponents.NewTestRenderer(comp).RenderRoot()
+
+	// Assert
+	assertNodes(t, "table rows", vnode.Children[1].Children[0].Children, "tr:No talks", "tr:Check back later")
+}
+
+// TestSchedule_SwitchCaseAndNestedLoops verifies that a {@case} branch and a loop nested
+// directly in a loop body splice their nodes into the list.
+func TestSchedule_SwitchCaseAndNestedLoops(t *testing.T) {
+	// Arrange & Act
+	list := testcomponents.NewTestRenderer(newSchedule()).RenderRoot().Children[2]
+
+	// Assert
+	assertNodes(t, "list items", list.Children, "li:Intro", "li:Basics", "li:web: vdom", "li:web: router", "li:tools: compiler")
+}
+
+// TestSchedule_SplicedNodesHaveUniqueKeys verifies that every node a loop iteration
+// renders through a nested directive gets a distinct key.
+func TestSchedule_SplicedNodesHaveUniqueKeys(t *testing.T) {
+	// Arrange & Act
+	vnode := testcomponents.NewTestRenderer(newSchedule()).RenderRoot()
+
+	// Assert
+	for _, parent := range []*vdom.VNode{vnode.Children[0], vnode.Children[1].Children[0], vnode.Children[2]} {
+		seen := make(map[any]bool)
+		for _, child := range parent.Children {
+			if child.Key == nil {
+				continue
+			}
+			if seen[child.Key] {
+				t.Errorf("Duplicate key %v in <%s>", child.Key, parent.Tag)
+			}
+			seen[child.Key] = true
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package svgchart
+
+import "github.com/ForgeLogic/nojs/runtime"
+
+// Bar is one bar of the chart.
+type Bar struct {
+	Label string
+	Value int
+}
+
+// Chart is a test component rendering inline SVG and MathML.
+type Chart struct {
+	runtime.ComponentBase
+
+	Title    string
+	Bars     []Bar
+	Selected int
+}
+
+// Width returns the width of the chart's view box.
+func (c *Chart) Width() int {
+	return len(c.Bars) * 10
+}
+
+// Select marks the bar at index i as selected.
+func (c *Chart) Select(i int) {
+	c.Selected = i
+}
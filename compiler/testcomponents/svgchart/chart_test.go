@@ -0,0 +1,102 @@
+//go:build !wasm
+
+package svgchart
+
+import (
+	"testing"
+
+	"github.com/ForgeLogic/nojs-compiler/testcomponents"
+	"github.com/ForgeLogic/nojs/vdom"
+)
+
+// newChart returns a chart with three bars.
+func newChart() *Chart {
+	return &Chart{Title: "Sales", Bars: []Bar{{"a", 10}, {"b", 30}, {"c", 20}}}
+}
+
+// TestChart_SVGElementsHaveNamespace verifies that elements inside <svg> are created
+// in the SVG namespace, keeping the case of their tag and attribute names.
+func TestChart_SVGElementsHaveNamespace(t *testing.T) {
+	// Arrange & Act
+	vnode := testcomponents.NewTestRenderer(newChart()).RenderRoot()
+
+	// Assert
+	if vnode.Namespace != "" {
+		t.Errorf("Expected <figure> to be HTML, got namespace %q", vnode.Namespace)
+	}
+	svg := vnode.Children[0]
+	if svg.Tag != "svg" || svg.Namespace != vdom.NamespaceSVG {
+		t.Fatalf("Expected an SVG <svg>, got <%s> in %q", svg.Tag, svg.Namespace)
+	}
+	if got := svg.Attributes["viewBox"]; got != "0 0 30 40" {
+		t.Errorf("Expected viewBox '0 0 30 40', got '%v'", got)
+	}
+	if got := svg.Attributes["preserveAspectRatio"]; got != "none" {
+		t.Errorf("Expected preserveAspectRatio 'none', got '%v'", got)
+	}
+	gradient := svg.Children[0].Children[0]
+	if gradient.Tag != "linearGradient" || gradient.Namespace != vdom.NamespaceSVG || gradient.Attributes["gradientUnits"] != "userSpaceOnUse" {
+		t.Errorf("Expected an SVG <linearGradient gradientUnits>, got %+v", gradient)
+	}
+	if text := svg.Children[5]; text.Tag != "text" || text.Content != "Sales" {
+		t.Errorf("Expected <text>Sales</text>, got <%s>%s", text.Tag, text.Content)
+	}
+}
+
+// TestChart_LoopBindingsAndEventsInSVG verifies that loops, attribute bindings and
+// event handlers work on SVG elements.
+func TestChart_LoopBindingsAndEventsInSVG(t *testing.T) {
+	// Arrange
+	comp := newChart()
+	svg := testcomponents.NewTestRenderer(comp).RenderRoot().Children[0]
+	bar := svg.Children[3]
+
+	// Act
+	bar.OnClick() // NewVNode moves a func() onClick handler to OnClick
+
+	// Assert
+	if bar.Tag != "rect" || bar.Namespace != vdom.NamespaceSVG {
+		t.Fatalf("Expected an SVG <rect>, got <%s> in %q", bar.Tag, bar.Namespace)
+	}
+	if bar.Attributes["y"] != 20 || bar.Attributes["height"] != 20 {
+		t.Errorf("Expected y=20 height=20 for the third bar, got %v", bar.Attributes)
+	}
+	if comp.Selected != 2 {
+		t.Errorf("Expected bar 2 to be selected, got %d", comp.Selected)
+	}
+}
+
+// TestChart_XLinkAndForeignObject verifies that xlink: attributes keep their prefix and
+// that the content of <foreignObject> is HTML again.
+func TestChart_XLinkAndForeignObject(t *testing.T) {
+	// Arrange & Act
+	svg := testcomponents.NewTestRenderer(newChart()).RenderRoot().Children[0]
+
+	// Assert
+	use := svg.Children[4]
+	if use.Tag != "use" || use.Attributes["xlink:href"] != "#marker" {
+		t.Errorf("Expected <use xlink:href=\"#marker\">, got %+v", use.Attributes)
+	}
+	foreign := svg.Children[6]
+	if foreign.Tag != "foreignObject" || foreign.Namespace != vdom.NamespaceSVG {
+		t.Fatalf("Expected an SVG <foreignObject>, got <%s> in %q", foreign.Tag, foreign.Namespace)
+	}
+	if p := foreign.Children[0]; p.Tag != "p" || p.Namespace != "" || p.Content != "3 bars" {
+		t.Errorf("Expected an HTML <p>3 bars</p>, got %+v", p)
+	}
+}
+
+// TestChart_MathML verifies that elements inside <math> are created in the MathML namespace.
+func TestChart_MathML(t *testing.T) {
+	// Arrange & Act
+	caption := testcomponents.NewTestRenderer(newChart()).RenderRoot().Children[1]
+
+	// Assert
+	math := caption.Children[0]
+	if math.Namespace != vdom.NamespaceMathML || len(math.Children) != 3 {
+		t.Fatalf("Expected a MathML <math> with 3 children, got %+v", math)
+	}
+	if mn := math.Children[2]; mn.Namespace != vdom.NamespaceMathML || mn.Content != "3" {
+		t.Errorf("Expected <mn>3</mn>, got %+v", mn)
+	}
+}
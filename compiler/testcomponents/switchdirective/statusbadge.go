@@ -0,0 +1,33 @@
+package switchdirective
+
+import (
+	"github.com/ForgeLogic/nojs/runtime"
+)
+
+// Status is the lifecycle state shown by a StatusBadge.
+type Status int
+
+const (
+	Draft Status = iota
+	Pending
+	InReview
+	Active
+)
+
+// StatusBadge is a test component exercising {@switch}: a switch over a named
+// constant type with multi-value cases, a nested switch over a string, and a
+// switch on an expression of loop variables.
+type StatusBadge struct {
+	runtime.ComponentBase
+
+	Status   Status
+	Reviewer string
+	Steps    []string
+	Current  int
+}
+
+// SetStatus updates the status and triggers a re-render.
+func (c *StatusBadge) SetStatus(status Status) {
+	c.Status = status
+	c.StateHasChanged()
+}
@@ -0,0 +1,98 @@
+//go:build !wasm
+
+package switchdirective
+
+import (
+	"testing"
+
+	"github.com/ForgeLogic/nojs-compiler/testcomponents"
+)
+
+// badge returns the class and text of the rendered status badge.
+func badge(t *testing.T, renderer *testcomponents.TestRenderer) (string, string) {
+	t.Helper()
+	span := renderer.GetCurrentVDOM().Children[0]
+	if span.Tag != "span" || span.Content == "" {
+		t.Fatalf("Expected a badge span with text, got %+v", span)
+	}
+	class, _ := span.Attributes["class"].(string)
+	return class, span.Content
+}
+
+// TestSwitch_SelectsMatchingCase verifies that the branch of the matching
+// {@case} is rendered, including multi-value cases and a nested switch.
+func TestSwitch_SelectsMatchingCase(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    Status
+		reviewer  string
+		wantClass string
+		wantText  string
+	}{
+		{"single value", Active, "", "badge active", "Active"},
+		{"first of two values", Pending, "", "badge pending", "Waiting for a reviewer"},
+		{"second of two values, nested default", InReview, "ann", "badge pending", "Reviewed by ann"},
+		{"default", Draft, "", "badge muted", "Status 0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			renderer := testcomponents.NewTestRenderer(&StatusBadge{Status: tt.status, Reviewer: tt.reviewer})
+
+			// Act
+			renderer.RenderRoot()
+
+			// Assert
+			class, text := badge(t, renderer)
+			if class != tt.wantClass || text != tt.wantText {
+				t.Errorf("Expected (%q, %q), got (%q, %q)", tt.wantClass, tt.wantText, class, text)
+			}
+		})
+	}
+}
+
+// TestSwitch_StateUpdate verifies that the switch is re-evaluated on re-render.
+func TestSwitch_StateUpdate(t *testing.T) {
+	// Arrange
+	component := &StatusBadge{Status: Draft}
+	renderer := testcomponents.NewTestRenderer(component)
+	renderer.RenderRoot()
+
+	// Act
+	component.SetStatus(Active)
+
+	// Assert
+	if class, _ := badge(t, renderer); class != "badge active" {
+		t.Errorf("Expected 'badge active' after update, got '%s'", class)
+	}
+}
+
+// TestSwitch_LoopVariableExpression verifies a switch on an expression of loop
+// variables and component fields.
+func TestSwitch_LoopVariableExpression(t *testing.T) {
+	// Arrange
+	renderer := testcomponents.NewTestRenderer(&StatusBadge{
+		Steps:   []string{"Account", "Address", "Payment"},
+		Current: 2,
+	})
+
+	// Act
+	vnode := renderer.RenderRoot()
+
+	// Assert
+	items := vnode.Children[1].Children
+	if len(items) != 3 {
+		t.Fatalf("Expected 3 steps, got %d", len(items))
+	}
+	for i, item := range items {
+		class, _ := item.Children[0].Attributes["class"].(string)
+		want := ""
+		if i == 1 {
+			want = "current"
+		}
+		if class != want {
+			t.Errorf("Step %d: expected class '%s', got '%s'", i, want, class)
+		}
+	}
+}
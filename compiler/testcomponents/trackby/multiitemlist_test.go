@@ -306,6 +306,49 @@ func TestMultiItemList_MultipleChildrenPerIteration_VariableNamesUnique(t *testi
 	t.Log("✓ Compiler generated unique variable names - no variable shadowing errors")
 }
 
+// TestMultiItemList_MultipleChildrenPerIteration_UniqueKeys verifies that when a loop body
+// emits several siblings per iteration, each sibling gets a distinct key derived from the
+// trackBy value and its position in the body.
+func TestMultiItemList_MultipleChildrenPerIteration_UniqueKeys(t *testing.T) {
+	// Arrange
+	multiList := &MultiItemList{
+		Items: []Item{
+			{ID: 101, Name: "Alpha"},
+			{ID: 102, Name: "Beta"},
+		},
+	}
+	renderer := testcomponents.NewTestRenderer(multiList)
+
+	// Act
+	vnode := renderer.RenderRoot()
+
+	// Assert
+	var ulNode *vdom.VNode
+	for _, child := range vnode.Children {
+		if child.Tag == "ul" {
+			ulNode = child
+			break
+		}
+	}
+	if ulNode == nil {
+		t.Fatalf("Expected <ul> element not found in root children")
+		return // unreachable, but satisfies staticcheck SA5011
+	}
+
+	expectedKeys := []any{
+		[2]any{101, 0}, [2]any{101, 1},
+		[2]any{102, 0}, [2]any{102, 1},
+	}
+	if len(ulNode.Children) != len(expectedKeys) {
+		t.Fatalf("Expected %d <li> elements, got %d", len(expectedKeys), len(ulNode.Children))
+	}
+	for i, expected := range expectedKeys {
+		if ulNode.Children[i].Key != expected {
+			t.Errorf("Child %d: expected Key %v, got %v", i, expected, ulNode.Children[i].Key)
+		}
+	}
+}
+
 // Helper function to check if a string contains a substring
 func contains(s, substr string) bool {
 	for i := 0; i <= len(s)-len(substr); i++ {
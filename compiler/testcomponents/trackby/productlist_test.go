@@ -357,6 +357,10 @@ func TestProductList_DotNotationTrackBy_TrackByUsingProductID(t *testing.T) {
 		if liNode.Tag != "li" {
 			t.Errorf("Expected li tag at index %d", i)
 		}
+		// The trackBy value is stamped on each VNode for keyed reconciliation
+		if liNode.Key != productList.Products[i].ID {
+			t.Errorf("Expected Key %d at index %d, got %v", productList.Products[i].ID, i, liNode.Key)
+		}
 	}
 
 	// Act: Add a product and verify IDs increment correctly
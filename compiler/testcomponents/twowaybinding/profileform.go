@@ -0,0 +1,42 @@
+package twowaybinding
+
+import (
+	"errors"
+	"net/netip"
+	"strings"
+
+	"github.com/ForgeLogic/nojs/runtime"
+)
+
+// Plan is a named string type, bound to a <select>.
+type Plan string
+
+// Email is a string type that validates itself through encoding.TextUnmarshaler.
+type Email string
+
+// UnmarshalText accepts only addresses containing an '@'.
+func (e *Email) UnmarshalText(text []byte) error {
+	if !strings.Contains(string(text), "@") {
+		return errors.New("invalid email")
+	}
+	*e = Email(text)
+	return nil
+}
+
+// ProfileForm is a test component for @bind: strings, numbers, a checkbox, a
+// select, a textarea, TextUnmarshaler types (local and from another package)
+// and a map entry.
+type ProfileForm struct {
+	runtime.ComponentBase
+
+	Name       string
+	Age        int
+	Ratio      float32
+	Subscribed bool
+	Plan       Plan
+	Notes      string
+	Email      Email
+	Server     netip.Addr
+	Labels     map[string]string
+	Selected   string
+}
@@ -0,0 +1,205 @@
+//go:build !wasm
+
+package twowaybinding
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/ForgeLogic/nojs-compiler/testcomponents"
+	"github.com/ForgeLogic/nojs/vdom"
+)
+
+// Element positions inside the form, in template order. The spaces between the inline
+// controls are text nodes, which the positions skip.
+const (
+	nameInput = iota
+	ageInput
+	ratioInput
+	subscribedInput
+	planSelect
+	notesTextarea
+	emailInput
+	serverInput
+	labelInput
+)
+
+// newProfileForm returns a rendered ProfileForm with initial values.
+func newProfileForm() (*ProfileForm, *testcomponents.TestRenderer) {
+	comp := &ProfileForm{
+		Name:     "Ada",
+		Age:      36,
+		Ratio:    0.5,
+		Plan:     "pro",
+		Server:   netip.MustParseAddr("10.0.0.1"),
+		Labels:   map[string]string{"home": "Home"},
+		Selected: "home",
+	}
+	renderer := testcomponents.NewTestRenderer(comp)
+	renderer.RenderRoot()
+	return comp, renderer
+}
+
+// input simulates the user typing value into the element at index.
+func input(t *testing.T, renderer *testcomponents.TestRenderer, index int, event, value string) {
+	t.Helper()
+	handler, ok := element(renderer, index).Attributes[event].(func(string))
+	if !ok {
+		t.Fatalf("element %d has no %s value handler", index, event)
+	}
+	handler(value)
+}
+
+// element returns the element at index in the current render.
+func element(renderer *testcomponents.TestRenderer, index int) *vdom.VNode {
+	var elements []*vdom.VNode
+	for _, child := range renderer.GetCurrentVDOM().Children {
+		if child.Tag != "#text" {
+			elements = append(elements, child)
+		}
+	}
+	return elements[index]
+}
+
+// TestBind_RendersFieldValues verifies that bound fields are formatted into the
+// elements' values.
+func TestBind_RendersFieldValues(t *testing.T) {
+	// Arrange & Act
+	_, renderer := newProfileForm()
+
+	// Assert
+	want := map[int]string{
+		nameInput:   "Ada",
+		ageInput:    "36",
+		ratioInput:  "0.5",
+		planSelect:  "pro",
+		serverInput: "10.0.0.1",
+		labelInput:  "Home",
+	}
+	for index, value := range want {
+		if got := element(renderer, index).Content; got != value {
+			t.Errorf("element %d: expected value '%s', got '%s'", index, value, got)
+		}
+	}
+	if got := element(renderer, subscribedInput).Attributes["checked"]; got != false {
+		t.Errorf("Expected checkbox unchecked, got '%v'", got)
+	}
+}
+
+// TestBind_TextInputUpdatesField verifies that typing into a bound input
+// assigns the field and re-renders.
+func TestBind_TextInputUpdatesField(t *testing.T) {
+	// Arrange
+	comp, renderer := newProfileForm()
+
+	// Act
+	input(t, renderer, nameInput, "onInput", "Grace")
+
+	// Assert
+	if comp.Name != "Grace" {
+		t.Errorf("Expected Name 'Grace', got '%s'", comp.Name)
+	}
+	if got := element(renderer, nameInput).Content; got != "Grace" {
+		t.Errorf("Expected re-rendered value 'Grace', got '%s'", got)
+	}
+}
+
+// TestBind_NumbersAreParsed verifies that numeric fields are parsed and that
+// input which does not parse leaves the field unchanged.
+func TestBind_NumbersAreParsed(t *testing.T) {
+	// Arrange
+	comp, renderer := newProfileForm()
+
+	// Act
+	input(t, renderer, ageInput, "onInput", " 42 ")
+	input(t, renderer, ratioInput, "onChange", "0.25")
+	input(t, renderer, ageInput, "onInput", "4x")
+
+	// Assert
+	if comp.Age != 42 {
+		t.Errorf("Expected Age 42 (invalid input ignored), got %d", comp.Age)
+	}
+	if comp.Ratio != 0.25 {
+		t.Errorf("Expected Ratio 0.25, got %v", comp.Ratio)
+	}
+}
+
+// TestBind_EventOverride verifies that @bind:event selects the update event.
+func TestBind_EventOverride(t *testing.T) {
+	// Arrange & Act
+	_, renderer := newProfileForm()
+
+	// Assert
+	attrs := element(renderer, ratioInput).Attributes
+	if _, ok := attrs["onChange"]; !ok {
+		t.Error("Expected @bind:event=\"onchange\" to register onChange")
+	}
+	if _, ok := attrs["onInput"]; ok {
+		t.Error("Expected no onInput handler when @bind:event overrides it")
+	}
+}
+
+// TestBind_CheckboxAndSelect verifies checkbox and select bindings, including
+// the conversion to a named string type.
+func TestBind_CheckboxAndSelect(t *testing.T) {
+	// Arrange
+	comp, renderer := newProfileForm()
+	toggle, ok := element(renderer, subscribedInput).Attributes["onChange"].(func(bool))
+	if !ok {
+		t.Fatal("checkbox has no checked handler")
+	}
+
+	// Act
+	toggle(true)
+	input(t, renderer, planSelect, "onChange", "free")
+
+	// Assert
+	if !comp.Subscribed {
+		t.Error("Expected Subscribed to be true")
+	}
+	if got := element(renderer, subscribedInput).Attributes["checked"]; got != true {
+		t.Errorf("Expected checkbox checked after re-render, got '%v'", got)
+	}
+	if comp.Plan != "free" {
+		t.Errorf("Expected Plan 'free', got '%s'", comp.Plan)
+	}
+}
+
+// TestBind_TextUnmarshaler verifies that types implementing
+// encoding.TextUnmarshaler are parsed with UnmarshalText, and that input it
+// rejects leaves the field unchanged.
+func TestBind_TextUnmarshaler(t *testing.T) {
+	// Arrange
+	comp, renderer := newProfileForm()
+
+	// Act
+	input(t, renderer, emailInput, "onInput", "ada@example.com")
+	input(t, renderer, emailInput, "onInput", "not an email")
+	input(t, renderer, serverInput, "onInput", "192.168.1.10")
+
+	// Assert
+	if comp.Email != "ada@example.com" {
+		t.Errorf("Expected Email 'ada@example.com', got '%s'", comp.Email)
+	}
+	if got := element(renderer, serverInput).Content; got != "192.168.1.10" {
+		t.Errorf("Expected server '192.168.1.10', got '%s'", got)
+	}
+}
+
+// TestBind_TextareaAndMapEntry verifies binding a textarea and a map entry.
+func TestBind_TextareaAndMapEntry(t *testing.T) {
+	// Arrange
+	comp, renderer := newProfileForm()
+
+	// Act
+	input(t, renderer, notesTextarea, "onInput", "Call back")
+	input(t, renderer, labelInput, "onInput", "House")
+
+	// Assert
+	if comp.Notes != "Call back" {
+		t.Errorf("Expected Notes 'Call back', got '%s'", comp.Notes)
+	}
+	if comp.Labels["home"] != "House" {
+		t.Errorf("Expected label 'House', got '%s'", comp.Labels["home"])
+	}
+}
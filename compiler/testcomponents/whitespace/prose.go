@@ -0,0 +1,11 @@
+package whitespace
+
+import "github.com/ForgeLogic/nojs/runtime"
+
+// Prose is a test component for the whitespace of text around inline elements, between
+// block elements, in bindings and inside <pre>.
+type Prose struct {
+	runtime.ComponentBase
+
+	Name string
+}
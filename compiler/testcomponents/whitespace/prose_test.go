@@ -0,0 +1,32 @@
+//go:build !wasm
+
+package whitespace
+
+import (
+	"testing"
+
+	"github.com/ForgeLogic/nojs-compiler/testcomponents"
+	"github.com/ForgeLogic/nojs/vdom"
+)
+
+// TestProse_WhitespaceRendersAsInTheBrowser verifies that the space between words and
+// inline elements survives, that runs of whitespace collapse to one space, and that
+// indentation between block elements and at the edges of an element is dropped.
+func TestProse_WhitespaceRendersAsInTheBrowser(t *testing.T) {
+	// Arrange
+	renderer := testcomponents.NewTestRenderer(&Prose{Name: "Ada"})
+
+	// Act
+	root := renderer.RenderRoot()
+
+	// Assert
+	want := `<article class="prose">` +
+		`<p>Hello <strong>world</strong> again</p>` +
+		`<p>Dear Ada, <em>thanks</em> <b>a lot</b></p>` +
+		`<p>Quoted <code>a  b</code>.</p>` +
+		"<pre>  keep\n    <b>this</b>   as is  </pre>" +
+		`</article>`
+	if got := vdom.RenderHTMLString(root); got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+}
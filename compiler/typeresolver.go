@@ -2,296 +2,178 @@ package compiler
 
 import (
 	"fmt"
-	"go/ast"
-	"go/parser"
 	"go/token"
+	"go/types"
+	"os"
 	"path/filepath"
 	"strings"
 
 	"golang.org/x/tools/go/packages"
 )
 
-// resolveNestedFieldType resolves the type of a nested field (e.g., "Ctx.Title" -> "string")
-// Returns the Go type string, or empty string if field not found.
-// componentDir is the directory containing the component's Go files.
-func resolveNestedFieldType(fieldPath string, comp componentInfo, componentDir string) (string, error) {
-	parts := strings.Split(fieldPath, ".")
-	if len(parts) < 2 {
-		return "", fmt.Errorf("not a nested field path: %s", fieldPath)
-	}
-
-	// Start with the root field
-	rootField := strings.ToLower(parts[0])
-	var currentType string
-
-	// Get the root field type from the component
-	if desc, exists := comp.Schema.Props[rootField]; exists {
-		currentType = desc.GoType
-	} else if desc, exists := comp.Schema.State[rootField]; exists {
-		currentType = desc.GoType
-	} else {
-		return "", fmt.Errorf("root field '%s' not found on component '%s'", parts[0], comp.PascalName)
-	}
-
-	// Traverse the nested fields
-	for i := 1; i < len(parts); i++ {
-		fieldName := parts[i]
-
-		// Remove pointer dereference marker if present
-		currentType = strings.TrimPrefix(currentType, "*")
-
-		// Remove slice marker if present
-		currentType = strings.TrimPrefix(currentType, "[]")
-
-		// If it's a simple type (like string, int), we can't access fields
-		if isBuiltinType(currentType) {
-			return "", fmt.Errorf("cannot access field '%s' on built-in type '%s'", fieldName, currentType)
-		}
-
-		// Extract the struct name and potential package prefix
-		structName := currentType
-		var packagePath string
-
-		if strings.Contains(currentType, ".") {
-			parts := strings.Split(currentType, ".")
-			packageAlias := parts[0]
-			structName = parts[1]
-
-			// Try to resolve the package alias to a full path by inspecting imports in the component file
-			packagePath, _ = resolvePackageFromAlias(packageAlias, componentDir)
-		}
+// packageTypes holds the type-checked form of one component package.
+type packageTypes struct {
+	Fset    *token.FileSet
+	Types   *types.Package
+	Imports map[string]*types.PkgName // Package names visible in the component's own .go files
+}
 
-		// Find the struct definition to get the field type
-		fieldType, err := findStructFieldType(componentDir, structName, fieldName, packagePath)
-		if err != nil {
-			return "", fmt.Errorf("cannot resolve field '%s' on type '%s': %v", fieldName, currentType, err)
+// packageTypesCache holds the type-checked component packages, keyed by directory.
+var packageTypesCache = make(map[string]*packageTypes)
+
+// loadedPackages holds every package of the shared load, with its dependencies, by import path.
+var loadedPackages = make(map[string]*types.Package)
+
+// packageLoadMode is what the type-checked component packages need.
+const packageLoadMode = packages.NeedName | packages.NeedFiles | packages.NeedImports |
+	packages.NeedTypes | packages.NeedSyntax | packages.NeedTypesInfo | packages.NeedDeps
+
+// loadComponentPackages type-checks every package that holds a component in a single
+// packages.Load call, configured for WASM like discovery. Types from one load are shared, so
+// a child component's type is the same whether or not its parent's package already imports
+// the child's package, e.g. on a clean build without *.generated.go files.
+// Type errors (e.g. a stale or missing *.generated.go file) are tolerated: the struct and
+// method declarations that template expressions need are still available.
+func loadComponentPackages(rootDir string, components []componentInfo) error {
+	packageTypesCache = make(map[string]*packageTypes)
+	loadedPackages = make(map[string]*types.Package)
+
+	// The events package is loaded too: templates use its event args types even when no
+	// hand-written file of the component's package imports it
+	patterns := []string{generatedFileImports["events"]}
+	dirs := make(map[string]string) // Import path -> directory
+	for _, comp := range components {
+		if _, ok := dirs[comp.ImportPath]; !ok {
+			dirs[comp.ImportPath] = filepath.Dir(comp.Path)
+			patterns = append(patterns, comp.ImportPath)
 		}
-
-		currentType = fieldType
+	}
+	if len(dirs) == 0 {
+		return nil
 	}
 
-	return currentType, nil
-}
-
-// resolvePackageFromAlias looks for import statements in Go files to resolve package aliases.
-// Returns the full import path for the package, or empty string if not found.
-func resolvePackageFromAlias(alias string, componentDir string) (string, error) {
-	matches, err := filepath.Glob(filepath.Join(componentDir, "*.go"))
+	cfg := &packages.Config{
+		Mode: packageLoadMode,
+		Dir:  rootDir,
+		Env:  append(os.Environ(), "GOOS=js", "GOARCH=wasm"),
+	}
+	pkgs, err := packages.Load(cfg, patterns...)
 	if err != nil {
-		return "", err
+		return fmt.Errorf("failed to load the component packages: %w", err)
 	}
-
-	for _, filePath := range matches {
-		if strings.Contains(filePath, ".generated.") {
-			continue
+	packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+		if pkg.Types != nil {
+			loadedPackages[pkg.PkgPath] = pkg.Types
 		}
-
-		fset := token.NewFileSet()
-		node, err := parser.ParseFile(fset, filePath, nil, 0)
-		if err != nil {
-			continue
-		}
-
-		for _, imp := range node.Imports {
-			importPath := strings.Trim(imp.Path.Value, "\"")
-
-			// Check if this import has an alias
-			if imp.Name != nil {
-				if imp.Name.Name == alias {
-					return importPath, nil
-				}
-			} else {
-				// No alias, check if the last part of the import path matches the alias
-				lastPart := importPath
-				if idx := strings.LastIndex(importPath, "/"); idx >= 0 {
-					lastPart = importPath[idx+1:]
-				}
-				if lastPart == alias {
-					return importPath, nil
-				}
-			}
+	})
+	for _, pkg := range pkgs {
+		if dir, ok := dirs[pkg.PkgPath]; ok && pkg.Types != nil {
+			packageTypesCache[dir] = newPackageTypes(pkg)
 		}
 	}
-
-	return "", fmt.Errorf("package alias '%s' not found in imports", alias)
+	return nil
 }
 
-// isBuiltinType checks if a type is a Go built-in type.
-func isBuiltinType(t string) bool {
-	builtins := map[string]bool{
-		"string": true, "int": true, "int8": true, "int16": true, "int32": true, "int64": true,
-		"uint": true, "uint8": true, "uint16": true, "uint32": true, "uint64": true,
-		"float32": true, "float64": true, "bool": true, "byte": true, "rune": true,
-	}
-	return builtins[t]
+// loadedPackage returns a package of the shared load by import path, or nil if it was not loaded.
+func loadedPackage(path string) *types.Package {
+	return loadedPackages[path]
 }
 
-// findStructFieldType searches for a struct definition and returns the type of a field.
-// Searches in the component directory and optionally in the specified package.
-func findStructFieldType(componentDir, structName, fieldName string, packagePath string) (string, error) {
-	// First try to find it in the component directory
-	if result, err := findStructFieldTypeInDir(componentDir, structName, fieldName); err == nil {
-		return result, nil
+// loadPackageTypes returns the type-checked Go package in dir. Packages that
+// loadComponentPackages did not load are loaded on their own.
+func loadPackageTypes(dir string) (*packageTypes, error) {
+	if cached, ok := packageTypesCache[dir]; ok {
+		return cached, nil
 	}
 
-	// If not found and packagePath is provided, try to find it in that package
-	if packagePath != "" {
-		// Try searching in the package directory
-		if pkgDir := findPackageDir(packagePath); pkgDir != "" {
-			if result, err := findStructFieldTypeInDir(pkgDir, structName, fieldName); err == nil {
-				return result, nil
-			}
-		}
+	cfg := &packages.Config{
+		Mode: packageLoadMode,
+		Dir:  dir,
+		Env:  append(os.Environ(), "GOOS=js", "GOARCH=wasm"),
+	}
+	pkgs, err := packages.Load(cfg, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load package in %s: %w", dir, err)
+	}
+	if len(pkgs) == 0 || pkgs[0].Types == nil {
+		return nil, fmt.Errorf("no Go package found in %s", dir)
 	}
 
-	return "", fmt.Errorf("struct '%s' with field '%s' not found in %s", structName, fieldName, componentDir)
+	result := newPackageTypes(pkgs[0])
+	packageTypesCache[dir] = result
+	return result, nil
 }
 
-// findStructFieldTypeInDir searches for a struct field in a specific directory.
-func findStructFieldTypeInDir(dir, structName, fieldName string) (string, error) {
-	matches, err := filepath.Glob(filepath.Join(dir, "*.go"))
-	if err != nil {
-		return "", err
+// newPackageTypes collects the packageTypes of a loaded package.
+func newPackageTypes(pkg *packages.Package) *packageTypes {
+	result := &packageTypes{
+		Fset:    pkg.Fset,
+		Types:   pkg.Types,
+		Imports: make(map[string]*types.PkgName),
 	}
 
-	for _, filePath := range matches {
-		// Skip generated files
-		if strings.Contains(filePath, ".generated.") {
+	// Collect imports from hand-written files only; generated files import a fixed set.
+	for i, file := range pkg.Syntax {
+		if i < len(pkg.CompiledGoFiles) && strings.HasSuffix(pkg.CompiledGoFiles[i], ".generated.go") {
 			continue
 		}
-
-		fset := token.NewFileSet()
-		node, err := parser.ParseFile(fset, filePath, nil, 0)
-		if err != nil {
-			continue
-		}
-
-		var foundType string
-		ast.Inspect(node, func(n ast.Node) bool {
-			if typeSpec, ok := n.(*ast.TypeSpec); ok && typeSpec.Name.Name == structName {
-				if structType, ok := typeSpec.Type.(*ast.StructType); ok {
-					for _, field := range structType.Fields.List {
-						if len(field.Names) > 0 && field.Names[0].Name == fieldName && field.Names[0].IsExported() {
-							foundType = extractTypeName(field.Type)
-							return false
-						}
-					}
-				}
+		for _, spec := range file.Imports {
+			pkgName := pkg.TypesInfo.PkgNameOf(spec)
+			if pkgName == nil || pkgName.Name() == "_" || pkgName.Name() == "." {
+				continue
+			}
+			if _, exists := result.Imports[pkgName.Name()]; !exists {
+				result.Imports[pkgName.Name()] = pkgName
 			}
-			return true
-		})
-
-		if foundType != "" {
-			return foundType, nil
 		}
 	}
-
-	return "", fmt.Errorf("field '%s' not found in %s", fieldName, dir)
+	return result
 }
 
-// findPackageDir tries to locate the directory for a given import path.
-func findPackageDir(importPath string) string {
-	// Use the Go packages tool to find the package
-	cfg := &packages.Config{
-		Mode: packages.NeedFiles,
-	}
-	pkgs, err := packages.Load(cfg, importPath)
-	if err == nil && len(pkgs) > 0 && len(pkgs[0].GoFiles) > 0 {
-		return filepath.Dir(pkgs[0].GoFiles[0])
+// componentNamedType returns the named struct type declared for a component.
+func componentNamedType(comp componentInfo) (*packageTypes, *types.Named, error) {
+	pkgTypes, err := loadPackageTypes(filepath.Dir(comp.Path))
+	if err != nil {
+		return nil, nil, err
 	}
-	return ""
-}
-
-// getAvailableNestedFields returns a list of available exported fields on a nested type.
-// For example, if fieldPath is "Ctx.Title1", it returns available fields on the Ctx type.
-func getAvailableNestedFields(fieldPath string, comp componentInfo, componentDir string) []string {
-	parts := strings.Split(fieldPath, ".")
-	if len(parts) < 1 {
-		return nil
+	obj, ok := pkgTypes.Types.Scope().Lookup(comp.PascalName).(*types.TypeName)
+	if !ok {
+		return nil, nil, fmt.Errorf("type '%s' not found in package %s", comp.PascalName, pkgTypes.Types.Path())
 	}
-
-	rootField := strings.ToLower(parts[0])
-	var fieldType string
-
-	// Get the root field type from the component
-	if desc, exists := comp.Schema.Props[rootField]; exists {
-		fieldType = desc.GoType
-	} else if desc, exists := comp.Schema.State[rootField]; exists {
-		fieldType = desc.GoType
-	} else {
-		return nil
+	named, ok := obj.Type().(*types.Named)
+	if !ok {
+		return nil, nil, fmt.Errorf("'%s' is not a named type", comp.PascalName)
 	}
-
-	// Remove pointer and slice markers
-	fieldType = strings.TrimPrefix(fieldType, "*")
-	fieldType = strings.TrimPrefix(fieldType, "[]")
-
-	// Extract struct name from qualified types (e.g., "context.MainLayoutCtx" -> "MainLayoutCtx")
-	structName := fieldType
-	var packagePath string
-	if strings.Contains(fieldType, ".") {
-		typeParts := strings.Split(fieldType, ".")
-		packageAlias := typeParts[0]
-		structName = typeParts[1]
-		packagePath, _ = resolvePackageFromAlias(packageAlias, componentDir)
+	if _, ok := named.Underlying().(*types.Struct); !ok {
+		return nil, nil, fmt.Errorf("component '%s' must be a struct type", comp.PascalName)
 	}
+	return pkgTypes, named, nil
+}
 
-	// Get available fields from the nested type
-	var fields []string
-
-	// First try to find it in the component directory
-	if availableFields, err := getStructFields(componentDir, structName); err == nil {
-		fields = availableFields
-	} else if packagePath != "" {
-		// If not found and packagePath is provided, try to find it in that package
-		if pkgDir := findPackageDir(packagePath); pkgDir != "" {
-			if availableFields, err := getStructFields(pkgDir, structName); err == nil {
-				fields = availableFields
-			}
+// componentFieldType returns the type of an exported field on a child component, or nil if
+// the child's package cannot be type-checked. A generic child's fields have the types of the
+// instance being compiled (comp.Instance).
+func componentFieldType(comp componentInfo, fieldName string) types.Type {
+	named := comp.Instance
+	if named == nil {
+		if named = componentType(comp); named == nil {
+			return nil
 		}
 	}
-
-	return fields
+	field, _, _ := types.LookupFieldOrMethod(types.NewPointer(named), true, named.Obj().Pkg(), fieldName)
+	if v, ok := field.(*types.Var); ok && v.IsField() {
+		return v.Type()
+	}
+	return nil
 }
 
-// getStructFields returns a list of exported fields on a struct type.
-func getStructFields(dir, structName string) ([]string, error) {
-	matches, err := filepath.Glob(filepath.Join(dir, "*.go"))
+// componentType returns the named type of a child component, looked up by its import path
+// in the shared load of loadComponentPackages, or nil if the child's package cannot be
+// type-checked.
+func componentType(comp componentInfo) *types.Named {
+	_, named, err := componentNamedType(comp)
 	if err != nil {
-		return nil, err
-	}
-
-	for _, filePath := range matches {
-		// Skip generated files
-		if strings.Contains(filePath, ".generated.") {
-			continue
-		}
-
-		fset := token.NewFileSet()
-		node, err := parser.ParseFile(fset, filePath, nil, 0)
-		if err != nil {
-			continue
-		}
-
-		var foundFields []string
-		ast.Inspect(node, func(n ast.Node) bool {
-			if typeSpec, ok := n.(*ast.TypeSpec); ok && typeSpec.Name.Name == structName {
-				if structType, ok := typeSpec.Type.(*ast.StructType); ok {
-					for _, field := range structType.Fields.List {
-						if len(field.Names) > 0 && field.Names[0].IsExported() {
-							foundFields = append(foundFields, field.Names[0].Name)
-						}
-					}
-					return false
-				}
-			}
-			return true
-		})
-
-		if len(foundFields) > 0 {
-			return foundFields, nil
-		}
+		return nil
 	}
-
-	return nil, fmt.Errorf("struct '%s' not found in %s", structName, dir)
+	return named
 }
@@ -1,13 +1,20 @@
 package compiler
 
-import "regexp"
+import (
+	"go/types"
+	"regexp"
+)
 
 // componentSchema holds the type information for a component's props.
 type componentSchema struct {
 	Props   map[string]propertyDescriptor // Map of Prop name to its Go type (e.g., "Title": "string")
 	State   map[string]propertyDescriptor // Map of State name to its Go type (internal component state)
 	Methods map[string]methodDescriptor   // Map of method names to their signatures
-	Slot    *propertyDescriptor           // Optional: single content slot field ([]*vdom.VNode)
+	Events  map[string]propertyDescriptor // Component events (fields tagged nojs:"event"), bound with @onname on the component tag
+	Slots   []propertyDescriptor          // Content slot fields ([]*vdom.VNode) in declaration order; the first is the default slot
+
+	ScopedSlots map[string]propertyDescriptor // Scoped slot fields (func(T) *vdom.VNode), filled with a <name let:v> block
+	TypeParams  []string                      // Type parameter names of a generic component struct, in order
 }
 
 type propertyDescriptor struct {
@@ -36,7 +43,11 @@ type componentInfo struct {
 	LowercaseName string
 	PackageName   string
 	ImportPath    string // Full import path (e.g., "github.com/ForgeLogic/nojs/appcomponents")
+	CSSPath       string // Path of the component's Name.gt.css stylesheet, or "" if it has none
+	ScopeAttr     string // Attribute stamped on every element the component renders when it has a stylesheet
 	Schema        componentSchema
+	Expr          *exprChecker // Type-checks template expressions; set while the template is compiled
+	Instance      *types.Named // A generic child instantiated for one component tag; set while the tag is compiled
 }
 
 // compileOptions holds compiler-wide options passed from CLI flags.
@@ -47,8 +58,11 @@ type compileOptions struct {
 
 // loopContext holds information about variables available in a loop scope.
 type loopContext struct {
-	IndexVar string // e.g., "i" or "_"
-	ValueVar string // e.g., "user"
+	IndexVar  string       // e.g., "i" or "_"
+	ValueVar  string       // e.g., "user"
+	IndexType types.Type   // Type of IndexVar, for type-checking expressions in the loop body
+	ValueType types.Type   // Type of ValueVar
+	Parent    *loopContext // Enclosing loop, if any
 }
 
 // textNodePosition tracks the location of an unwrapped text node in slot content.
@@ -58,41 +72,10 @@ type loopContext struct {
 // 	textContent string
 // }
 
-// Regex to find data binding expressions like {FieldName} or {user.Name}
+// Regex to find simple field bindings like {FieldName} or {user.Name}.
+// Full expressions are handled by scanBindings; this is used to detect slot spreads.
 var dataBindingRegex = regexp.MustCompile(`\{([a-zA-Z0-9_.]+)\}`)
 
-// Regex to find ternary expressions like { condition ? 'value1' : 'value2' }
-var ternaryExprRegex = regexp.MustCompile(`\{\s*(!?)([a-zA-Z0-9_]+)\s*\?\s*'([^']*)'\s*:\s*'([^']*)'\s*\}`)
-
-// Regex to find boolean shorthand like {condition} or {!condition}
-var booleanShorthandRegex = regexp.MustCompile(`^\{\s*(!?)([a-zA-Z0-9_]+)\s*\}$`)
-
-// Standard HTML boolean attributes
-var standardBooleanAttrs = map[string]bool{
-	"disabled":       true,
-	"checked":        true,
-	"readonly":       true,
-	"required":       true,
-	"autofocus":      true,
-	"autoplay":       true,
-	"controls":       true,
-	"loop":           true,
-	"muted":          true,
-	"selected":       true,
-	"hidden":         true,
-	"multiple":       true,
-	"novalidate":     true,
-	"open":           true,
-	"reversed":       true,
-	"scoped":         true,
-	"seamless":       true,
-	"sortable":       true,
-	"truespeed":      true,
-	"default":        true,
-	"ismap":          true,
-	"formnovalidate": true,
-}
-
 // problematicHTMLTags lists HTML tags that conflict with component names.
 // The Go html parser treats these case-insensitively and applies HTML5 semantics
 // (e.g., <link> becomes self-closing and moves to <head>).
@@ -3,9 +3,13 @@ package compiler
 import (
 	"fmt"
 	"os"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/ForgeLogic/nojs/events"
+	"golang.org/x/net/html"
 )
 
 // validateComponentName checks if a component name conflicts with HTML tags.
@@ -34,55 +38,99 @@ func validateComponentName(componentName, templatePath string) error {
 	return nil
 }
 
-// isBooleanAttribute checks if an attribute name is a standard HTML boolean attribute.
-func isBooleanAttribute(attrName string) bool {
-	return standardBooleanAttrs[attrName]
+// isBooleanAttribute checks if an attribute is an HTML boolean attribute of the element.
+func isBooleanAttribute(tagName, attrName string) bool {
+	spec, ok := htmlSchema.attribute(tagName, attrName)
+	return ok && spec.Kind == attrBoolean
 }
 
-// validateBooleanCondition validates that a condition references a boolean field on the component.
-// Returns the propertyDescriptor if valid, or exits with a compile error.
-func validateBooleanCondition(condition string, comp componentInfo, templatePath string, lineNumber int, htmlSource string) propertyDescriptor {
-	propDesc, exists := comp.Schema.Props[strings.ToLower(condition)]
-	if !exists {
-		// Also check state fields
-		propDesc, exists = comp.Schema.State[strings.ToLower(condition)]
+// validateElementAttributes checks an HTML element and its static attributes against the
+// HTML schema and prints a warning for unknown elements, unknown attributes (suggesting
+// the closest known name, e.g. 'clas' => 'class') and static values that do not fit the
+// attribute's kind. Custom elements (<my-widget>) and SVG/MathML content are not checked.
+func validateElementAttributes(n *html.Node, comp componentInfo, htmlSource string) {
+	tagName := n.Data
+	if n.Namespace != "" || strings.Contains(tagName, "-") {
+		return
 	}
-	if !exists {
-		allFields := append(getAvailableFieldNames(comp.Schema.Props), getAvailableFieldNames(comp.Schema.State)...)
-		availableFields := strings.Join(allFields, ", ")
-		contextLines := getContextLines(htmlSource, lineNumber, 2)
-		fmt.Fprintf(os.Stderr, "Compilation Error in %s:%d: Condition '%s' not found on component '%s'. Available fields: [%s]\n%s",
-			templatePath, lineNumber, condition, comp.PascalName, availableFields, contextLines)
-		os.Exit(1)
+	if _, known := htmlSchema.element(tagName); !known {
+		lineNumber := estimateLineNumber(htmlSource, "<"+tagName)
+		templateWarning(comp, htmlSource, lineNumber, fmt.Sprintf("Unknown HTML element <%s>.", tagName))
+		return
 	}
-	if propDesc.GoType != "bool" {
-		contextLines := getContextLines(htmlSource, lineNumber, 2)
-		fmt.Fprintf(os.Stderr, "Compilation Error in %s:%d: Condition '%s' must be a bool field, found type '%s'.\n%s",
-			templatePath, lineNumber, condition, propDesc.GoType, contextLines)
-		os.Exit(1)
+
+	for _, a := range n.Attr {
+		if strings.HasPrefix(a.Key, "@") {
+			continue // Directives and events are validated where they are compiled
+		}
+		lineNumber := estimateLineNumber(htmlSource, fmt.Sprintf(`%s="%s"`, a.Key, a.Val))
+		if a.Val == "" {
+			lineNumber = estimateLineNumber(htmlSource, " "+a.Key)
+		}
+
+		spec, known := htmlSchema.attribute(tagName, a.Key)
+		if !known {
+			msg := fmt.Sprintf("Unknown attribute '%s' on <%s>.", a.Key, tagName)
+			if after, isHandler := strings.CutPrefix(a.Key, "on"); isHandler && events.GetEventSignature(a.Key) != nil {
+				msg += fmt.Sprintf(" Inline JavaScript handlers are not supported; use @on%s=\"Method\".", after)
+			} else if suggestion := closestAttributeName(a.Key, htmlSchema.attributeNames(tagName)); suggestion != "" {
+				msg += fmt.Sprintf(" Did you mean '%s'?", suggestion)
+			}
+			templateWarning(comp, htmlSource, lineNumber, msg)
+			continue
+		}
+		if hasBindings(scanBindings(a.Val)) {
+			continue // Bound values are checked against their Go type
+		}
+
+		switch spec.Kind {
+		case attrBoolean:
+			if strings.EqualFold(a.Val, "false") {
+				templateWarning(comp, htmlSource, lineNumber, fmt.Sprintf("Boolean attribute '%s' is true whenever it is present, even as %s=\"false\". Remove it, or bind a bool: %s=\"{Field}\".", a.Key, a.Key, a.Key))
+			}
+		case attrEnum:
+			if !slices.ContainsFunc(spec.Values, func(v string) bool { return strings.EqualFold(v, a.Val) }) {
+				templateWarning(comp, htmlSource, lineNumber, fmt.Sprintf("Invalid value \"%s\" for attribute '%s' on <%s>. Allowed values: %s", a.Val, a.Key, tagName, quoteValues(spec.Values)))
+			}
+		case attrNumber:
+			if _, err := strconv.ParseFloat(strings.TrimSpace(a.Val), 64); err != nil {
+				templateWarning(comp, htmlSource, lineNumber, fmt.Sprintf("Attribute '%s' on <%s> takes a number, found \"%s\".", a.Key, tagName, a.Val))
+			}
+		case attrURL:
+			if strings.HasPrefix(strings.ToLower(strings.TrimSpace(a.Val)), "javascript:") {
+				templateWarning(comp, htmlSource, lineNumber, fmt.Sprintf("'%s' holds a javascript: URL, which runs outside the component; use an @onclick handler instead.", a.Key))
+			}
+		}
 	}
-	return propDesc
 }
 
-// validateEventHandler validates that an event handler exists and has the correct signature.
-// Returns the methodDescriptor if valid, or exits with a compile error and helpful suggestions.
-func validateEventHandler(eventName, handlerName, tagName string, comp componentInfo, templatePath string, lineNumber int, htmlSource string) methodDescriptor {
-	// Get the event signature from the registry
-	eventSig := events.GetEventSignature(eventName)
-	if eventSig == nil {
-		contextLines := getContextLines(htmlSource, lineNumber, 2)
-		fmt.Fprintf(os.Stderr, "Compilation Error in %s:%d: Unknown event '@%s'.\n%s\nSupported events: @onclick, @oninput, @onchange, @onkeydown, @onkeyup, @onkeypress, @onfocus, @onblur, @onsubmit, @onmousedown, @onmouseup, @onmousemove\n",
-			templatePath, lineNumber, eventName, contextLines)
-		os.Exit(1)
+// closestAttributeName returns the known attribute name closest to a misspelled one, or ""
+// if none is within two edits.
+func closestAttributeName(name string, known []string) string {
+	best, bestDistance := "", 3
+	for _, candidate := range known {
+		if d := levenshteinDistance(name, candidate); d < bestDistance && d < len(candidate) {
+			best, bestDistance = candidate, d
+		}
 	}
+	return best
+}
 
-	// Check if the event is supported on this HTML tag
-	if !events.IsEventSupported(eventName, tagName) {
-		contextLines := getContextLines(htmlSource, lineNumber, 2)
-		fmt.Fprintf(os.Stderr, "Compilation Error in %s:%d: Event '@%s' is not supported on <%s>.\n%s\nSupported elements for @%s: %v\n",
-			templatePath, lineNumber, eventName, tagName, contextLines, eventName, eventSig.SupportedTags)
-		os.Exit(1)
+// quoteValues formats the keywords of an enumerated attribute for messages.
+func quoteValues(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = strconv.Quote(v)
 	}
+	return strings.Join(quoted, ", ")
+}
+
+// validateEventHandler validates that an event handler exists and has the correct signature.
+// Returns the methodDescriptor if valid, or exits with a compile error and helpful suggestions.
+// With event modifiers (hasModifiers), a handler may also take no arguments, since the
+// modifiers do what it would otherwise need the event args for.
+func validateEventHandler(eventName, handlerName, tagName string, hasModifiers bool, comp componentInfo, templatePath string, lineNumber int, htmlSource string) methodDescriptor {
+	eventSig := validateEventName(eventName, tagName, templatePath, lineNumber, htmlSource)
 
 	// Check if the handler method exists
 	method, exists := comp.Schema.Methods[handlerName]
@@ -123,6 +171,10 @@ func validateEventHandler(eventName, handlerName, tagName string, comp component
 	}
 
 	// Standard validation for other events
+	if eventSig.RequiresArgs && hasModifiers && len(method.Params) == 0 {
+		// func() with modifiers, e.g. @onkeydown.enter="Submit" - valid, will use AdaptNoArgEvent
+		return method
+	}
 	if eventSig.RequiresArgs {
 		// Event requires arguments - handler must have exactly one parameter of the correct type
 		if len(method.Params) != 1 {
@@ -172,6 +224,33 @@ func validateEventHandler(eventName, handlerName, tagName string, comp component
 	return method
 }
 
+// validateEventName checks that eventName is a known event supported on tagName and returns
+// its signature, or exits with a compile error.
+func validateEventName(eventName, tagName, templatePath string, lineNumber int, htmlSource string) *events.EventSignature {
+	// Get the event signature from the registry
+	eventSig := events.GetEventSignature(eventName)
+	if eventSig == nil {
+		contextLines := getContextLines(htmlSource, lineNumber, 2)
+		supported := make([]string, 0, len(events.EventRegistry))
+		for name := range events.EventRegistry {
+			supported = append(supported, "@"+name)
+		}
+		sort.Strings(supported)
+		fmt.Fprintf(os.Stderr, "Compilation Error in %s:%d: Unknown event '@%s'.\n%s\nSupported events: %s\n",
+			templatePath, lineNumber, eventName, contextLines, strings.Join(supported, ", "))
+		os.Exit(1)
+	}
+
+	// Check that the browser fires the event where the listener is attached
+	if ok, reason := htmlSchema.eventFiresOn(eventName, tagName); !ok {
+		contextLines := getContextLines(htmlSource, lineNumber, 2)
+		fmt.Fprintf(os.Stderr, "Compilation Error in %s:%d: Event '@%s' is never fired on <%s>: %s.\n%s\n",
+			templatePath, lineNumber, eventName, tagName, reason, contextLines)
+		os.Exit(1)
+	}
+	return eventSig
+}
+
 // levenshteinDistance calculates the edit distance between two strings.
 // Used for fuzzy matching component name suggestions.
 // Returns the minimum number of single-character edits (insertions, deletions, substitutions)
@@ -2,7 +2,9 @@
 
 package events
 
-import "syscall/js"
+import (
+	"syscall/js"
+)
 
 // AdaptClickEvent creates a JavaScript-compatible event handler from a Go handler
 // that expects ClickEventArgs. This is used for @onclick events with event arguments.
@@ -26,9 +28,16 @@ func AdaptClickEvent(handler func(ClickEventArgs)) func(js.Value) {
 // that expects ChangeEventArgs. This is used for @oninput and @onchange events.
 func AdaptChangeEvent(handler func(ChangeEventArgs)) func(js.Value) {
 	return func(e js.Value) {
+		target := parseChangeTarget(wrap(e.Get("target")))
 		args := ChangeEventArgs{
-			EventBase: NewEventBase(e),
-			Value:     e.Get("target").Get("value").String(),
+			EventBase:       NewEventBase(e),
+			Value:           target.Value,
+			Checked:         target.Checked,
+			ValueAsNumber:   target.ValueAsNumber,
+			SelectedOptions: target.SelectedOptions,
+			Files:           target.Files,
+			SelectionStart:  target.SelectionStart,
+			SelectionEnd:    target.SelectionEnd,
 		}
 		handler(args)
 	}
@@ -52,18 +61,171 @@ func AdaptKeyboardEvent(handler func(KeyboardEventArgs)) func(js.Value) {
 }
 
 // AdaptMouseEvent creates a JavaScript-compatible event handler from a Go handler
-// that expects MouseEventArgs. This is used for @onmousedown, @onmouseup, @onmousemove events.
+// that expects MouseEventArgs. This is used for @onmousedown, @onmouseup, @onmousemove,
+// @onmouseenter, @onmouseleave, @ondblclick and @oncontextmenu events.
 func AdaptMouseEvent(handler func(MouseEventArgs)) func(js.Value) {
 	return func(e js.Value) {
-		args := MouseEventArgs{
+		handler(newMouseEventArgs(e))
+	}
+}
+
+// newMouseEventArgs reads the MouseEvent properties shared by mouse, pointer, wheel and drag events.
+func newMouseEventArgs(e js.Value) MouseEventArgs {
+	return MouseEventArgs{
+		EventBase: NewEventBase(e),
+		ClientX:   e.Get("clientX").Int(),
+		ClientY:   e.Get("clientY").Int(),
+		Button:    e.Get("button").Int(),
+		AltKey:    e.Get("altKey").Bool(),
+		CtrlKey:   e.Get("ctrlKey").Bool(),
+		ShiftKey:  e.Get("shiftKey").Bool(),
+		MetaKey:   e.Get("metaKey").Bool(),
+	}
+}
+
+// AdaptPointerEvent creates a JavaScript-compatible event handler from a Go handler
+// that expects PointerEventArgs. This is used for @onpointerdown, @onpointermove,
+// @onpointerup and @onpointercancel events.
+func AdaptPointerEvent(handler func(PointerEventArgs)) func(js.Value) {
+	return func(e js.Value) {
+		args := PointerEventArgs{
+			MouseEventArgs: newMouseEventArgs(e),
+			PointerID:      e.Get("pointerId").Int(),
+			PointerType:    e.Get("pointerType").String(),
+			IsPrimary:      e.Get("isPrimary").Bool(),
+			Pressure:       e.Get("pressure").Float(),
+			Width:          e.Get("width").Float(),
+			Height:         e.Get("height").Float(),
+		}
+		handler(args)
+	}
+}
+
+// AdaptTouchEvent creates a JavaScript-compatible event handler from a Go handler
+// that expects TouchEventArgs. This is used for @ontouchstart, @ontouchmove and @ontouchend events.
+func AdaptTouchEvent(handler func(TouchEventArgs)) func(js.Value) {
+	return func(e js.Value) {
+		args := TouchEventArgs{
+			EventBase:      NewEventBase(e),
+			Touches:        touchPoints(e.Get("touches")),
+			ChangedTouches: touchPoints(e.Get("changedTouches")),
+			AltKey:         e.Get("altKey").Bool(),
+			CtrlKey:        e.Get("ctrlKey").Bool(),
+			ShiftKey:       e.Get("shiftKey").Bool(),
+			MetaKey:        e.Get("metaKey").Bool(),
+		}
+		handler(args)
+	}
+}
+
+// touchPoints converts a JavaScript TouchList to TouchPoints.
+func touchPoints(list js.Value) []TouchPoint {
+	points := make([]TouchPoint, list.Length())
+	for i := range points {
+		touch := list.Index(i)
+		points[i] = TouchPoint{
+			Identifier: touch.Get("identifier").Int(),
+			ClientX:    touch.Get("clientX").Int(),
+			ClientY:    touch.Get("clientY").Int(),
+		}
+	}
+	return points
+}
+
+// AdaptWheelEvent creates a JavaScript-compatible event handler from a Go handler
+// that expects WheelEventArgs. This is used for @onwheel events.
+func AdaptWheelEvent(handler func(WheelEventArgs)) func(js.Value) {
+	return func(e js.Value) {
+		args := WheelEventArgs{
+			MouseEventArgs: newMouseEventArgs(e),
+			DeltaX:         e.Get("deltaX").Float(),
+			DeltaY:         e.Get("deltaY").Float(),
+			DeltaZ:         e.Get("deltaZ").Float(),
+			DeltaMode:      e.Get("deltaMode").Int(),
+		}
+		handler(args)
+	}
+}
+
+// AdaptScrollEvent creates a JavaScript-compatible event handler from a Go handler
+// that expects ScrollEventArgs. This is used for @onscroll events.
+func AdaptScrollEvent(handler func(ScrollEventArgs)) func(js.Value) {
+	return func(e js.Value) {
+		target := e.Get("currentTarget")
+		args := ScrollEventArgs{
+			EventBase:    NewEventBase(e),
+			ScrollTop:    target.Get("scrollTop").Float(),
+			ScrollLeft:   target.Get("scrollLeft").Float(),
+			ScrollHeight: target.Get("scrollHeight").Int(),
+			ScrollWidth:  target.Get("scrollWidth").Int(),
+			ClientHeight: target.Get("clientHeight").Int(),
+			ClientWidth:  target.Get("clientWidth").Int(),
+		}
+		handler(args)
+	}
+}
+
+// AdaptDragEvent creates a JavaScript-compatible event handler from a Go handler
+// that expects DragEventArgs. This is used for @ondragstart, @ondragover, @ondrop
+// and @ondragend events.
+func AdaptDragEvent(handler func(DragEventArgs)) func(js.Value) {
+	return func(e js.Value) {
+		args := DragEventArgs{
+			MouseEventArgs: newMouseEventArgs(e),
+			DataTransfer:   DataTransfer{value: e.Get("dataTransfer")},
+		}
+		handler(args)
+	}
+}
+
+// AdaptClipboardEvent creates a JavaScript-compatible event handler from a Go handler
+// that expects ClipboardEventArgs. This is used for @oncopy, @oncut and @onpaste events.
+func AdaptClipboardEvent(handler func(ClipboardEventArgs)) func(js.Value) {
+	return func(e js.Value) {
+		args := ClipboardEventArgs{
+			EventBase:     NewEventBase(e),
+			ClipboardData: DataTransfer{value: e.Get("clipboardData")},
+		}
+		handler(args)
+	}
+}
+
+// AdaptCompositionEvent creates a JavaScript-compatible event handler from a Go handler
+// that expects CompositionEventArgs. This is used for @oncompositionstart and
+// @oncompositionend events.
+func AdaptCompositionEvent(handler func(CompositionEventArgs)) func(js.Value) {
+	return func(e js.Value) {
+		args := CompositionEventArgs{
 			EventBase: NewEventBase(e),
-			ClientX:   e.Get("clientX").Int(),
-			ClientY:   e.Get("clientY").Int(),
-			Button:    e.Get("button").Int(),
-			AltKey:    e.Get("altKey").Bool(),
-			CtrlKey:   e.Get("ctrlKey").Bool(),
-			ShiftKey:  e.Get("shiftKey").Bool(),
-			MetaKey:   e.Get("metaKey").Bool(),
+			Data:      e.Get("data").String(),
+		}
+		handler(args)
+	}
+}
+
+// AdaptAnimationEvent creates a JavaScript-compatible event handler from a Go handler
+// that expects AnimationEventArgs. This is used for @onanimationend events.
+func AdaptAnimationEvent(handler func(AnimationEventArgs)) func(js.Value) {
+	return func(e js.Value) {
+		args := AnimationEventArgs{
+			EventBase:     NewEventBase(e),
+			AnimationName: e.Get("animationName").String(),
+			ElapsedTime:   e.Get("elapsedTime").Float(),
+			PseudoElement: e.Get("pseudoElement").String(),
+		}
+		handler(args)
+	}
+}
+
+// AdaptTransitionEvent creates a JavaScript-compatible event handler from a Go handler
+// that expects TransitionEventArgs. This is used for @ontransitionend events.
+func AdaptTransitionEvent(handler func(TransitionEventArgs)) func(js.Value) {
+	return func(e js.Value) {
+		args := TransitionEventArgs{
+			EventBase:     NewEventBase(e),
+			PropertyName:  e.Get("propertyName").String(),
+			ElapsedTime:   e.Get("elapsedTime").Float(),
+			PseudoElement: e.Get("pseudoElement").String(),
 		}
 		handler(args)
 	}
@@ -98,3 +260,46 @@ func AdaptNoArgEvent(handler func()) func(js.Value) {
 		handler()
 	}
 }
+
+// AdaptBindValue creates a JavaScript-compatible event handler that passes the
+// target element's current value to handler. The compiler uses it for @bind on
+// <input>, <textarea> and <select> elements.
+func AdaptBindValue(handler func(string)) func(js.Value) {
+	return func(e js.Value) {
+		handler(e.Get("target").Get("value").String())
+	}
+}
+
+// AdaptBindChecked creates a JavaScript-compatible event handler that passes the
+// target element's checked state to handler. The compiler uses it for @bind on
+// checkboxes.
+func AdaptBindChecked(handler func(bool)) func(js.Value) {
+	return func(e js.Value) {
+		handler(e.Get("target").Get("checked").Bool())
+	}
+}
+
+// ApplyModifiers wraps an adapted handler with the event modifiers of its
+// template attribute. Events that fail the .self or key filters are ignored;
+// the others get preventDefault/stopPropagation applied before handler runs.
+func ApplyModifiers(handler func(js.Value), m Modifiers) func(js.Value) {
+	return func(e js.Value) {
+		if m.Self && !e.Get("target").Equal(e.Get("currentTarget")) {
+			return
+		}
+		key := ""
+		if k := e.Get("key"); k.Type() == js.TypeString {
+			key = k.String()
+		}
+		if !m.Accepts(key, e.Get("ctrlKey").Truthy(), e.Get("shiftKey").Truthy(), e.Get("altKey").Truthy(), e.Get("metaKey").Truthy()) {
+			return
+		}
+		if m.PreventDefault {
+			e.Call("preventDefault")
+		}
+		if m.StopPropagation {
+			e.Call("stopPropagation")
+		}
+		handler(e)
+	}
+}
@@ -0,0 +1,236 @@
+package events
+
+// EventBase provides common functionality for all DOM events.
+// Components can embed this to gain access to PreventDefault and StopPropagation.
+// It has no build tags, so components with typed event handlers also compile for
+// server-side rendering, where no event is ever dispatched.
+type EventBase struct {
+	event                 domEvent
+	preventDefaultCalled  bool
+	stopPropagationCalled bool
+}
+
+// domEvent is the browser event an EventBase was created from, or nil outside the browser.
+type domEvent interface {
+	preventDefault()
+	stopPropagation()
+	target() object
+	currentTarget() object
+}
+
+// PreventDefault prevents the browser's default action for this event.
+// For example, prevents form submission or link navigation.
+func (e *EventBase) PreventDefault() {
+	if !e.preventDefaultCalled {
+		if e.event != nil {
+			e.event.preventDefault()
+		}
+		e.preventDefaultCalled = true
+	}
+}
+
+// StopPropagation stops the event from bubbling up the DOM tree.
+func (e *EventBase) StopPropagation() {
+	if !e.stopPropagationCalled {
+		if e.event != nil {
+			e.event.stopPropagation()
+		}
+		e.stopPropagationCalled = true
+	}
+}
+
+// IsDefaultPrevented returns whether preventDefault was called.
+func (e *EventBase) IsDefaultPrevented() bool {
+	return e.preventDefaultCalled
+}
+
+// IsPropagationStopped returns whether stopPropagation was called.
+func (e *EventBase) IsPropagationStopped() bool {
+	return e.stopPropagationCalled
+}
+
+// Target returns information about the element that dispatched the event
+// (event.target). For events bubbling up from a child, this is the child.
+func (e *EventBase) Target() ElementInfo {
+	if e.event == nil {
+		return ElementInfo{}
+	}
+	return parseElementInfo(e.event.target())
+}
+
+// CurrentTarget returns information about the element whose handler is
+// running (event.currentTarget), i.e. the element carrying the @event attribute.
+func (e *EventBase) CurrentTarget() ElementInfo {
+	if e.event == nil {
+		return ElementInfo{}
+	}
+	return parseElementInfo(e.event.currentTarget())
+}
+
+// ClickEventArgs represents the data passed from click events.
+// Used for @onclick handlers that need event details.
+type ClickEventArgs struct {
+	EventBase
+	ClientX  int  // X coordinate relative to the viewport
+	ClientY  int  // Y coordinate relative to the viewport
+	Button   int  // Which mouse button was pressed (0=left, 1=middle, 2=right)
+	AltKey   bool // Whether the Alt key was pressed
+	CtrlKey  bool // Whether the Ctrl key was pressed
+	ShiftKey bool // Whether the Shift key was pressed
+	MetaKey  bool // Whether the Meta key was pressed
+}
+
+// ChangeEventArgs represents the data passed from input/select/textarea change events.
+// This struct provides type-safe access to the current state of form elements.
+type ChangeEventArgs struct {
+	EventBase
+	// Value is the current value of the input element.
+	// For text inputs, this is the text content.
+	// For select elements, this is the selected option's value.
+	// For checkboxes and radio buttons, this is the value attribute ("on" if it
+	// has none); use Checked for their state.
+	Value string
+	// Checked is the checked state of a checkbox or radio button; false for other elements.
+	Checked bool
+	// ValueAsNumber is the value of a number, range or date input as a number,
+	// or NaN if the element has none (use math.IsNaN).
+	ValueAsNumber float64
+	// SelectedOptions holds the values of the selected options of a <select>,
+	// including all selections of a <select multiple>; nil for other elements.
+	SelectedOptions []string
+	// Files holds the files chosen in an <input type="file">; nil for other elements.
+	Files []File
+	// SelectionStart and SelectionEnd are the selected text range of a text
+	// input or textarea, or -1 if the element has no text selection.
+	SelectionStart int
+	SelectionEnd   int
+}
+
+// KeyboardEventArgs represents the data passed from keyboard events.
+// Used for @onkeydown, @onkeyup, @onkeypress handlers.
+type KeyboardEventArgs struct {
+	EventBase
+	Key      string // The key value of the key pressed (e.g., "a", "Enter", "Escape")
+	Code     string // The physical key code (e.g., "KeyA", "Enter")
+	AltKey   bool   // Whether the Alt key was pressed
+	CtrlKey  bool   // Whether the Ctrl key was pressed
+	ShiftKey bool   // Whether the Shift key was pressed
+	MetaKey  bool   // Whether the Meta (Command/Windows) key was pressed
+}
+
+// MouseEventArgs represents the data passed from mouse events.
+// Used for @onmousedown, @onmouseup, @onmousemove handlers.
+type MouseEventArgs struct {
+	EventBase
+	ClientX  int  // X coordinate relative to the viewport
+	ClientY  int  // Y coordinate relative to the viewport
+	Button   int  // Which mouse button was pressed (0=left, 1=middle, 2=right)
+	AltKey   bool // Whether the Alt key was pressed
+	CtrlKey  bool // Whether the Ctrl key was pressed
+	ShiftKey bool // Whether the Shift key was pressed
+	MetaKey  bool // Whether the Meta key was pressed
+}
+
+// FocusEventArgs represents the data passed from focus/blur events.
+type FocusEventArgs struct {
+	EventBase
+}
+
+// FormEventArgs represents the data passed from form submission events.
+// Used for @onsubmit handlers.
+type FormEventArgs struct {
+	EventBase
+}
+
+// PointerEventArgs represents the data passed from pointer events.
+// Used for @onpointerdown, @onpointermove, @onpointerup, @onpointercancel handlers.
+type PointerEventArgs struct {
+	MouseEventArgs
+	PointerID   int     // Unique identifier of the pointer causing the event
+	PointerType string  // "mouse", "pen" or "touch"
+	IsPrimary   bool    // Whether this is the primary pointer of its type
+	Pressure    float64 // Normalized pressure from 0 to 1
+	Width       float64 // Width of the contact geometry in CSS pixels
+	Height      float64 // Height of the contact geometry in CSS pixels
+}
+
+// TouchPoint is a single point of contact on a touch surface.
+type TouchPoint struct {
+	Identifier int // Unique identifier of the touch point for the duration of the touch
+	ClientX    int // X coordinate relative to the viewport
+	ClientY    int // Y coordinate relative to the viewport
+}
+
+// TouchEventArgs represents the data passed from touch events.
+// Used for @ontouchstart, @ontouchmove, @ontouchend handlers.
+type TouchEventArgs struct {
+	EventBase
+	Touches        []TouchPoint // All points currently touching the surface
+	ChangedTouches []TouchPoint // Points that changed in this event
+	AltKey         bool         // Whether the Alt key was pressed
+	CtrlKey        bool         // Whether the Ctrl key was pressed
+	ShiftKey       bool         // Whether the Shift key was pressed
+	MetaKey        bool         // Whether the Meta key was pressed
+}
+
+// WheelEventArgs represents the data passed from wheel events.
+// Used for @onwheel handlers.
+type WheelEventArgs struct {
+	MouseEventArgs
+	DeltaX    float64 // Horizontal scroll amount
+	DeltaY    float64 // Vertical scroll amount
+	DeltaZ    float64 // Scroll amount on the z-axis
+	DeltaMode int     // Unit of the deltas (0=pixels, 1=lines, 2=pages)
+}
+
+// ScrollEventArgs represents the data passed from scroll events, read from
+// the scrolled element. Used for @onscroll handlers.
+type ScrollEventArgs struct {
+	EventBase
+	ScrollTop    float64 // Pixels scrolled from the top
+	ScrollLeft   float64 // Pixels scrolled from the left
+	ScrollHeight int     // Height of the element's content
+	ScrollWidth  int     // Width of the element's content
+	ClientHeight int     // Visible height of the element
+	ClientWidth  int     // Visible width of the element
+}
+
+// DragEventArgs represents the data passed from drag-and-drop events.
+// Used for @ondragstart, @ondragover, @ondrop, @ondragend handlers.
+// A drop target must call PreventDefault in @ondragover to accept drops.
+type DragEventArgs struct {
+	MouseEventArgs
+	DataTransfer DataTransfer // The data being dragged
+}
+
+// ClipboardEventArgs represents the data passed from clipboard events.
+// Used for @oncopy, @oncut, @onpaste handlers.
+type ClipboardEventArgs struct {
+	EventBase
+	ClipboardData DataTransfer // Pasted data, or the data to copy (call PreventDefault after SetData)
+}
+
+// CompositionEventArgs represents the data passed from IME composition events.
+// Used for @oncompositionstart and @oncompositionend handlers.
+type CompositionEventArgs struct {
+	EventBase
+	Data string // The composed text (empty at compositionstart)
+}
+
+// AnimationEventArgs represents the data passed from CSS animation events.
+// Used for @onanimationend handlers.
+type AnimationEventArgs struct {
+	EventBase
+	AnimationName string  // The name of the CSS animation
+	ElapsedTime   float64 // Seconds the animation had been running
+	PseudoElement string  // The pseudo-element the animation runs on, or ""
+}
+
+// TransitionEventArgs represents the data passed from CSS transition events.
+// Used for @ontransitionend handlers.
+type TransitionEventArgs struct {
+	EventBase
+	PropertyName  string  // The CSS property that transitioned
+	ElapsedTime   float64 // Seconds the transition had been running
+	PseudoElement string  // The pseudo-element the transition runs on, or ""
+}
@@ -2,107 +2,217 @@
 
 package events
 
-import "syscall/js"
-
-// EventBase provides common functionality for all DOM events.
-// Components can embed this to gain access to PreventDefault and StopPropagation.
-type EventBase struct {
-	jsEvent               js.Value
-	preventDefaultCalled  bool
-	stopPropagationCalled bool
-}
+import (
+	"errors"
+	"syscall/js"
+)
 
 // NewEventBase creates a new EventBase from a JavaScript event object.
 // This is called by the adapter functions.
 func NewEventBase(jsEvent js.Value) EventBase {
-	return EventBase{
-		jsEvent: jsEvent,
+	return EventBase{event: jsDOMEvent{jsEvent}}
+}
+
+// jsDOMEvent is the browser event behind an EventBase.
+type jsDOMEvent struct {
+	value js.Value
+}
+
+func (e jsDOMEvent) preventDefault()       { e.value.Call("preventDefault") }
+func (e jsDOMEvent) stopPropagation()      { e.value.Call("stopPropagation") }
+func (e jsDOMEvent) target() object        { return wrap(e.value.Get("target")) }
+func (e jsDOMEvent) currentTarget() object { return wrap(e.value.Get("currentTarget")) }
+
+// jsObject reads a js.Value for the parsers in targets.go.
+type jsObject struct {
+	value js.Value
+}
+
+// wrap returns v as an object, or nil if it is undefined or null.
+func wrap(v js.Value) object {
+	if v.IsUndefined() || v.IsNull() {
+		return nil
 	}
+	return jsObject{v}
 }
 
-// PreventDefault prevents the browser's default action for this event.
-// For example, prevents form submission or link navigation.
-func (e *EventBase) PreventDefault() {
-	if !e.preventDefaultCalled {
-		e.jsEvent.Call("preventDefault")
-		e.preventDefaultCalled = true
+func (o jsObject) String(name string) (string, bool) {
+	prop := o.value.Get(name)
+	if prop.Type() != js.TypeString {
+		return "", false
 	}
+	return prop.String(), true
 }
 
-// StopPropagation stops the event from bubbling up the DOM tree.
-func (e *EventBase) StopPropagation() {
-	if !e.stopPropagationCalled {
-		e.jsEvent.Call("stopPropagation")
-		e.stopPropagationCalled = true
+func (o jsObject) Number(name string) (float64, bool) {
+	prop := o.value.Get(name)
+	if prop.Type() != js.TypeNumber {
+		return 0, false
 	}
+	return prop.Float(), true
+}
+
+func (o jsObject) Bool(name string) bool {
+	return o.value.Get(name).Truthy()
 }
 
-// IsDefaultPrevented returns whether preventDefault was called.
-func (e *EventBase) IsDefaultPrevented() bool {
-	return e.preventDefaultCalled
+func (o jsObject) Object(name string) object {
+	return wrap(o.value.Get(name))
 }
 
-// IsPropagationStopped returns whether stopPropagation was called.
-func (e *EventBase) IsPropagationStopped() bool {
-	return e.stopPropagationCalled
+func (o jsObject) Len() int {
+	return o.value.Length()
 }
 
-// ClickEventArgs represents the data passed from click events.
-// Used for @onclick handlers that need event details.
-type ClickEventArgs struct {
-	EventBase
-	ClientX  int  // X coordinate relative to the viewport
-	ClientY  int  // Y coordinate relative to the viewport
-	Button   int  // Which mouse button was pressed (0=left, 1=middle, 2=right)
-	AltKey   bool // Whether the Alt key was pressed
-	CtrlKey  bool // Whether the Ctrl key was pressed
-	ShiftKey bool // Whether the Shift key was pressed
-	MetaKey  bool // Whether the Meta key was pressed
+func (o jsObject) Index(i int) object {
+	return wrap(o.value.Index(i))
 }
 
-// ChangeEventArgs represents the data passed from input/select/textarea change events.
-// This struct provides type-safe access to the current value of form elements.
-type ChangeEventArgs struct {
-	EventBase
-	// Value is the current value of the input element.
-	// For text inputs, this is the text content.
-	// For select elements, this is the selected option's value.
-	// For checkboxes, this will be "true" or "false".
-	Value string
+func (o jsObject) Keys() []string {
+	keys := js.Global().Get("Object").Call("keys", o.value)
+	result := make([]string, keys.Length())
+	for i := range result {
+		result[i] = keys.Index(i).String()
+	}
+	return result
+}
+
+// DataTransfer wraps the browser's DataTransfer object, which holds the data
+// of a drag-and-drop or clipboard operation.
+type DataTransfer struct {
+	value js.Value
 }
 
-// KeyboardEventArgs represents the data passed from keyboard events.
-// Used for @onkeydown, @onkeyup, @onkeypress handlers.
-type KeyboardEventArgs struct {
-	EventBase
-	Key      string // The key value of the key pressed (e.g., "a", "Enter", "Escape")
-	Code     string // The physical key code (e.g., "KeyA", "Enter")
-	AltKey   bool   // Whether the Alt key was pressed
-	CtrlKey  bool   // Whether the Ctrl key was pressed
-	ShiftKey bool   // Whether the Shift key was pressed
-	MetaKey  bool   // Whether the Meta (Command/Windows) key was pressed
+// valid reports whether the browser provided a DataTransfer; it is missing on
+// synthetic events and outside of drag-and-drop and clipboard handlers.
+func (d DataTransfer) valid() bool {
+	return !d.value.IsUndefined() && !d.value.IsNull()
 }
 
-// MouseEventArgs represents the data passed from mouse events.
-// Used for @onmousedown, @onmouseup, @onmousemove handlers.
-type MouseEventArgs struct {
-	EventBase
-	ClientX  int  // X coordinate relative to the viewport
-	ClientY  int  // Y coordinate relative to the viewport
-	Button   int  // Which mouse button was pressed (0=left, 1=middle, 2=right)
-	AltKey   bool // Whether the Alt key was pressed
-	CtrlKey  bool // Whether the Ctrl key was pressed
-	ShiftKey bool // Whether the Shift key was pressed
-	MetaKey  bool // Whether the Meta key was pressed
+// GetData returns the data for the given format (e.g., "text/plain"), or ""
+// if there is none.
+func (d DataTransfer) GetData(format string) string {
+	if !d.valid() {
+		return ""
+	}
+	return d.value.Call("getData", format).String()
 }
 
-// FocusEventArgs represents the data passed from focus/blur events.
-type FocusEventArgs struct {
-	EventBase
+// SetData sets the data for the given format. Only effective in dragstart,
+// copy and cut handlers.
+func (d DataTransfer) SetData(format, data string) {
+	if !d.valid() {
+		return
+	}
+	d.value.Call("setData", format, data)
 }
 
-// FormEventArgs represents the data passed from form submission events.
-// Used for @onsubmit handlers.
-type FormEventArgs struct {
-	EventBase
+// ClearData removes the data for the given formats, or all data if none are given.
+func (d DataTransfer) ClearData(formats ...string) {
+	if !d.valid() {
+		return
+	}
+	if len(formats) == 0 {
+		d.value.Call("clearData")
+		return
+	}
+	for _, format := range formats {
+		d.value.Call("clearData", format)
+	}
+}
+
+// Types returns the formats of the data, such as "text/plain" or "Files".
+func (d DataTransfer) Types() []string {
+	if !d.valid() {
+		return nil
+	}
+	types := d.value.Get("types")
+	result := make([]string, types.Length())
+	for i := range result {
+		result[i] = types.Index(i).String()
+	}
+	return result
+}
+
+// FileNames returns the names of the files being dragged or pasted.
+func (d DataTransfer) FileNames() []string {
+	if !d.valid() {
+		return nil
+	}
+	files := d.value.Get("files")
+	result := make([]string, files.Length())
+	for i := range result {
+		result[i] = files.Index(i).Get("name").String()
+	}
+	return result
+}
+
+// DropEffect returns the drop effect: "none", "copy", "link" or "move".
+func (d DataTransfer) DropEffect() string {
+	if !d.valid() {
+		return ""
+	}
+	return d.value.Get("dropEffect").String()
+}
+
+// SetDropEffect sets the drop effect shown to the user ("none", "copy", "link" or "move").
+func (d DataTransfer) SetDropEffect(effect string) {
+	if !d.valid() {
+		return
+	}
+	d.value.Set("dropEffect", effect)
+}
+
+// SetEffectAllowed sets the operations allowed for a drag (e.g., "move", "copyMove", "all").
+func (d DataTransfer) SetEffectAllowed(effect string) {
+	if !d.valid() {
+		return
+	}
+	d.value.Set("effectAllowed", effect)
+}
+
+// Files returns the files being dragged or pasted.
+func (d DataTransfer) Files() []File {
+	if !d.valid() {
+		return nil
+	}
+	return parseFiles(wrap(d.value.Get("files")))
+}
+
+// ReadBytes reads the file's contents with a FileReader and passes them to done.
+// Reading is asynchronous: done runs after the event handler has returned, so it
+// must call StateHasChanged itself to show the result. Event handlers run on the
+// browser's event loop and must not block waiting for the contents.
+func (f File) ReadBytes(done func(data []byte, err error)) {
+	file, ok := f.value.(jsObject)
+	if !ok {
+		done(nil, errors.New("events: file has no contents"))
+		return
+	}
+	reader := js.Global().Get("FileReader").New()
+	var onLoad, onError js.Func
+	release := func() {
+		onLoad.Release()
+		onError.Release()
+	}
+	onLoad = js.FuncOf(func(this js.Value, args []js.Value) any {
+		release()
+		array := js.Global().Get("Uint8Array").New(reader.Get("result"))
+		data := make([]byte, array.Length())
+		js.CopyBytesToGo(data, array)
+		done(data, nil)
+		return nil
+	})
+	onError = js.FuncOf(func(this js.Value, args []js.Value) any {
+		release()
+		message := "read failed"
+		if err := reader.Get("error"); !err.IsNull() && !err.IsUndefined() {
+			message = err.Get("message").String()
+		}
+		done(nil, errors.New("events: reading "+f.Name+": "+message))
+		return nil
+	})
+	reader.Set("onload", onLoad)
+	reader.Set("onerror", onError)
+	reader.Call("readAsArrayBuffer", file.value)
 }
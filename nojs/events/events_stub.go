@@ -3,6 +3,8 @@
 
 package events
 
+import "errors"
+
 // Stub file for non-WASM builds to allow generated code to compile.
 // The actual implementation is in events.go with js/wasm build tags.
 
@@ -10,3 +12,147 @@ package events
 func AdaptNoArgEvent(handler func()) func() {
 	return handler
 }
+
+// AdaptClickEvent is a stub for non-WASM builds. It returns the handler so server-side
+// rendering compiles and tests can call it with ClickEventArgs.
+func AdaptClickEvent(handler func(ClickEventArgs)) func(ClickEventArgs) {
+	return handler
+}
+
+// AdaptChangeEvent is a stub for non-WASM builds. It returns the handler so server-side
+// rendering compiles and tests can call it with ChangeEventArgs.
+func AdaptChangeEvent(handler func(ChangeEventArgs)) func(ChangeEventArgs) {
+	return handler
+}
+
+// AdaptKeyboardEvent is a stub for non-WASM builds. It returns the handler so server-side
+// rendering compiles and tests can call it with KeyboardEventArgs.
+func AdaptKeyboardEvent(handler func(KeyboardEventArgs)) func(KeyboardEventArgs) {
+	return handler
+}
+
+// AdaptMouseEvent is a stub for non-WASM builds. It returns the handler so server-side
+// rendering compiles and tests can call it with MouseEventArgs.
+func AdaptMouseEvent(handler func(MouseEventArgs)) func(MouseEventArgs) {
+	return handler
+}
+
+// AdaptPointerEvent is a stub for non-WASM builds. It returns the handler so server-side
+// rendering compiles and tests can call it with PointerEventArgs.
+func AdaptPointerEvent(handler func(PointerEventArgs)) func(PointerEventArgs) {
+	return handler
+}
+
+// AdaptTouchEvent is a stub for non-WASM builds. It returns the handler so server-side
+// rendering compiles and tests can call it with TouchEventArgs.
+func AdaptTouchEvent(handler func(TouchEventArgs)) func(TouchEventArgs) {
+	return handler
+}
+
+// AdaptWheelEvent is a stub for non-WASM builds. It returns the handler so server-side
+// rendering compiles and tests can call it with WheelEventArgs.
+func AdaptWheelEvent(handler func(WheelEventArgs)) func(WheelEventArgs) {
+	return handler
+}
+
+// AdaptScrollEvent is a stub for non-WASM builds. It returns the handler so server-side
+// rendering compiles and tests can call it with ScrollEventArgs.
+func AdaptScrollEvent(handler func(ScrollEventArgs)) func(ScrollEventArgs) {
+	return handler
+}
+
+// AdaptDragEvent is a stub for non-WASM builds. It returns the handler so server-side
+// rendering compiles and tests can call it with DragEventArgs.
+func AdaptDragEvent(handler func(DragEventArgs)) func(DragEventArgs) {
+	return handler
+}
+
+// AdaptClipboardEvent is a stub for non-WASM builds. It returns the handler so server-side
+// rendering compiles and tests can call it with ClipboardEventArgs.
+func AdaptClipboardEvent(handler func(ClipboardEventArgs)) func(ClipboardEventArgs) {
+	return handler
+}
+
+// AdaptCompositionEvent is a stub for non-WASM builds. It returns the handler so server-side
+// rendering compiles and tests can call it with CompositionEventArgs.
+func AdaptCompositionEvent(handler func(CompositionEventArgs)) func(CompositionEventArgs) {
+	return handler
+}
+
+// AdaptAnimationEvent is a stub for non-WASM builds. It returns the handler so server-side
+// rendering compiles and tests can call it with AnimationEventArgs.
+func AdaptAnimationEvent(handler func(AnimationEventArgs)) func(AnimationEventArgs) {
+	return handler
+}
+
+// AdaptTransitionEvent is a stub for non-WASM builds. It returns the handler so server-side
+// rendering compiles and tests can call it with TransitionEventArgs.
+func AdaptTransitionEvent(handler func(TransitionEventArgs)) func(TransitionEventArgs) {
+	return handler
+}
+
+// AdaptFocusEvent is a stub for non-WASM builds. It returns the handler so server-side
+// rendering compiles and tests can call it with FocusEventArgs.
+func AdaptFocusEvent(handler func(FocusEventArgs)) func(FocusEventArgs) {
+	return handler
+}
+
+// AdaptFormEvent is a stub for non-WASM builds. It returns the handler so server-side
+// rendering compiles and tests can call it with FormEventArgs.
+func AdaptFormEvent(handler func(FormEventArgs)) func(FormEventArgs) {
+	return handler
+}
+
+// AdaptBindValue is a stub for non-WASM builds. It returns the handler so tests
+// can simulate user input by calling it with the new value.
+func AdaptBindValue(handler func(string)) func(string) {
+	return handler
+}
+
+// AdaptBindChecked is a stub for non-WASM builds. It returns the handler so
+// tests can simulate toggling a checkbox.
+func AdaptBindChecked(handler func(bool)) func(bool) {
+	return handler
+}
+
+// ApplyModifiers is a stub for non-WASM builds. There is no DOM event to
+// filter, so the handler is returned unchanged.
+func ApplyModifiers[H any](handler H, m Modifiers) H {
+	return handler
+}
+
+// DataTransfer is the data of a drag-and-drop or clipboard operation. Outside the
+// browser there is none: reads return empty values and writes do nothing.
+type DataTransfer struct{}
+
+// GetData returns "" outside the browser.
+func (d DataTransfer) GetData(format string) string { return "" }
+
+// SetData does nothing outside the browser.
+func (d DataTransfer) SetData(format, data string) {}
+
+// ClearData does nothing outside the browser.
+func (d DataTransfer) ClearData(formats ...string) {}
+
+// Types returns nil outside the browser.
+func (d DataTransfer) Types() []string { return nil }
+
+// FileNames returns nil outside the browser.
+func (d DataTransfer) FileNames() []string { return nil }
+
+// DropEffect returns "" outside the browser.
+func (d DataTransfer) DropEffect() string { return "" }
+
+// SetDropEffect does nothing outside the browser.
+func (d DataTransfer) SetDropEffect(effect string) {}
+
+// SetEffectAllowed does nothing outside the browser.
+func (d DataTransfer) SetEffectAllowed(effect string) {}
+
+// Files returns nil outside the browser.
+func (d DataTransfer) Files() []File { return nil }
+
+// ReadBytes reports an error outside the browser, where files have no contents to read.
+func (f File) ReadBytes(done func(data []byte, err error)) {
+	done(nil, errors.New("events: reading "+f.Name+": files can only be read in the browser"))
+}
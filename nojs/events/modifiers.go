@@ -0,0 +1,55 @@
+package events
+
+import "strings"
+
+// Modifiers are the event modifiers written after an event name in a template,
+// such as @onclick.prevent.stop or @onkeydown.ctrl.s. The compiler emits them
+// for ApplyModifiers, which checks the filters before calling the handler.
+type Modifiers struct {
+	PreventDefault  bool // .prevent: call preventDefault() before the handler
+	StopPropagation bool // .stop: call stopPropagation() before the handler
+	Self            bool // .self: ignore events dispatched by child elements
+
+	// Key is the KeyboardEvent.key the event must carry (e.g. "Enter", "s"), or
+	// "" to accept any key. Single letters match regardless of case.
+	Key string
+
+	// Ctrl, Shift, Alt and Meta require the modifier key to be held.
+	Ctrl, Shift, Alt, Meta bool
+}
+
+// Accepts reports whether an event with the given key and modifier key state
+// passes the key filters. Events without a key (such as clicks) pass key as "".
+func (m Modifiers) Accepts(key string, ctrl, shift, alt, meta bool) bool {
+	if (m.Ctrl && !ctrl) || (m.Shift && !shift) || (m.Alt && !alt) || (m.Meta && !meta) {
+		return false
+	}
+	if m.Key == "" {
+		return true
+	}
+	if len(m.Key) == 1 {
+		return strings.EqualFold(key, m.Key)
+	}
+	return key == m.Key
+}
+
+// KeyModifiers maps the key filters accepted after a keyboard event name to
+// KeyboardEvent.key values. Single letters and digits (@onkeydown.ctrl.s) are
+// accepted as well.
+var KeyModifiers = map[string]string{
+	"enter":     "Enter",
+	"escape":    "Escape",
+	"esc":       "Escape",
+	"tab":       "Tab",
+	"space":     " ",
+	"up":        "ArrowUp",
+	"down":      "ArrowDown",
+	"left":      "ArrowLeft",
+	"right":     "ArrowRight",
+	"delete":    "Delete",
+	"backspace": "Backspace",
+	"home":      "Home",
+	"end":       "End",
+	"pageup":    "PageUp",
+	"pagedown":  "PageDown",
+}
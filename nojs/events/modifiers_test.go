@@ -0,0 +1,29 @@
+package events
+
+import "testing"
+
+func TestModifiers_Accepts(t *testing.T) {
+	tests := []struct {
+		name  string
+		mods  Modifiers
+		key   string
+		ctrl  bool
+		shift bool
+		want  bool
+	}{
+		{"no filters", Modifiers{}, "", false, false, true},
+		{"named key matches", Modifiers{Key: "Enter"}, "Enter", false, false, true},
+		{"named key differs", Modifiers{Key: "Enter"}, "Escape", false, false, false},
+		{"letter ignores case", Modifiers{Key: "s", Ctrl: true}, "S", true, true, true},
+		{"required modifier missing", Modifiers{Key: "s", Ctrl: true}, "s", false, false, false},
+		{"extra modifiers allowed", Modifiers{Ctrl: true}, "", true, true, true},
+		{"named key is case sensitive", Modifiers{Key: "Enter"}, "enter", false, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.mods.Accepts(tt.key, tt.ctrl, tt.shift, false, false); got != tt.want {
+				t.Errorf("Accepts(%q) = %v, want %v", tt.key, got, tt.want)
+			}
+		})
+	}
+}
@@ -6,9 +6,6 @@ type EventSignature struct {
 	// EventName is the event attribute name without @ prefix (e.g., "onclick", "oninput")
 	EventName string
 
-	// SupportedTags lists HTML elements that support this event
-	SupportedTags []string
-
 	// ExpectedSig is a human-readable signature string for error messages
 	ExpectedSig string
 
@@ -21,100 +18,249 @@ type EventSignature struct {
 }
 
 // EventRegistry maps event names to their expected signatures.
-// This is used by the compiler for compile-time validation.
+// This is used by the compiler for compile-time validation. Which elements an
+// event can be attached to is part of the compiler's HTML schema.
 var EventRegistry = map[string]EventSignature{
 	// Phase 1: Core events (MVP)
 	"onclick": {
-		EventName:     "onclick",
-		SupportedTags: []string{"button", "a", "div", "span", "p", "img", "li", "td", "th"},
-		ExpectedSig:   "func() or func(events.ClickEventArgs)",
-		RequiresArgs:  false, // Can be either func() or func(ClickEventArgs)
-		ArgsType:      "events.ClickEventArgs",
+		EventName:    "onclick",
+		ExpectedSig:  "func() or func(events.ClickEventArgs)",
+		RequiresArgs: false, // Can be either func() or func(ClickEventArgs)
+		ArgsType:     "events.ClickEventArgs",
 	},
 	"oninput": {
-		EventName:     "oninput",
-		SupportedTags: []string{"input", "textarea"},
-		ExpectedSig:   "func(events.ChangeEventArgs)",
-		RequiresArgs:  true,
-		ArgsType:      "events.ChangeEventArgs",
+		EventName:    "oninput",
+		ExpectedSig:  "func(events.ChangeEventArgs)",
+		RequiresArgs: true,
+		ArgsType:     "events.ChangeEventArgs",
 	},
 	"onchange": {
-		EventName:     "onchange",
-		SupportedTags: []string{"input", "select", "textarea"},
-		ExpectedSig:   "func(events.ChangeEventArgs)",
-		RequiresArgs:  true,
-		ArgsType:      "events.ChangeEventArgs",
+		EventName:    "onchange",
+		ExpectedSig:  "func(events.ChangeEventArgs)",
+		RequiresArgs: true,
+		ArgsType:     "events.ChangeEventArgs",
 	},
 
 	// Phase 2: Keyboard events
 	"onkeydown": {
-		EventName:     "onkeydown",
-		SupportedTags: []string{"input", "textarea", "div"},
-		ExpectedSig:   "func(events.KeyboardEventArgs)",
-		RequiresArgs:  true,
-		ArgsType:      "events.KeyboardEventArgs",
+		EventName:    "onkeydown",
+		ExpectedSig:  "func(events.KeyboardEventArgs)",
+		RequiresArgs: true,
+		ArgsType:     "events.KeyboardEventArgs",
 	},
 	"onkeyup": {
-		EventName:     "onkeyup",
-		SupportedTags: []string{"input", "textarea", "div"},
-		ExpectedSig:   "func(events.KeyboardEventArgs)",
-		RequiresArgs:  true,
-		ArgsType:      "events.KeyboardEventArgs",
+		EventName:    "onkeyup",
+		ExpectedSig:  "func(events.KeyboardEventArgs)",
+		RequiresArgs: true,
+		ArgsType:     "events.KeyboardEventArgs",
 	},
 	"onkeypress": {
-		EventName:     "onkeypress",
-		SupportedTags: []string{"input", "textarea", "div"},
-		ExpectedSig:   "func(events.KeyboardEventArgs)",
-		RequiresArgs:  true,
-		ArgsType:      "events.KeyboardEventArgs",
+		EventName:    "onkeypress",
+		ExpectedSig:  "func(events.KeyboardEventArgs)",
+		RequiresArgs: true,
+		ArgsType:     "events.KeyboardEventArgs",
 	},
 
 	// Phase 2: Focus events
 	"onfocus": {
-		EventName:     "onfocus",
-		SupportedTags: []string{"input", "textarea", "select", "button"},
-		ExpectedSig:   "func(events.FocusEventArgs)",
-		RequiresArgs:  true,
-		ArgsType:      "events.FocusEventArgs",
+		EventName:    "onfocus",
+		ExpectedSig:  "func(events.FocusEventArgs)",
+		RequiresArgs: true,
+		ArgsType:     "events.FocusEventArgs",
 	},
 	"onblur": {
-		EventName:     "onblur",
-		SupportedTags: []string{"input", "textarea", "select", "button"},
-		ExpectedSig:   "func(events.FocusEventArgs)",
-		RequiresArgs:  true,
-		ArgsType:      "events.FocusEventArgs",
+		EventName:    "onblur",
+		ExpectedSig:  "func(events.FocusEventArgs)",
+		RequiresArgs: true,
+		ArgsType:     "events.FocusEventArgs",
 	},
 
 	// Phase 2: Form events
 	"onsubmit": {
-		EventName:     "onsubmit",
-		SupportedTags: []string{"form"},
-		ExpectedSig:   "func(events.FormEventArgs)",
-		RequiresArgs:  true,
-		ArgsType:      "events.FormEventArgs",
+		EventName:    "onsubmit",
+		ExpectedSig:  "func(events.FormEventArgs)",
+		RequiresArgs: true,
+		ArgsType:     "events.FormEventArgs",
 	},
 
 	// Phase 3: Mouse events
 	"onmousedown": {
-		EventName:     "onmousedown",
-		SupportedTags: []string{"button", "div", "span", "img", "a"},
-		ExpectedSig:   "func(events.MouseEventArgs)",
-		RequiresArgs:  true,
-		ArgsType:      "events.MouseEventArgs",
+		EventName:    "onmousedown",
+		ExpectedSig:  "func(events.MouseEventArgs)",
+		RequiresArgs: true,
+		ArgsType:     "events.MouseEventArgs",
 	},
 	"onmouseup": {
-		EventName:     "onmouseup",
-		SupportedTags: []string{"button", "div", "span", "img", "a"},
-		ExpectedSig:   "func(events.MouseEventArgs)",
-		RequiresArgs:  true,
-		ArgsType:      "events.MouseEventArgs",
+		EventName:    "onmouseup",
+		ExpectedSig:  "func(events.MouseEventArgs)",
+		RequiresArgs: true,
+		ArgsType:     "events.MouseEventArgs",
 	},
 	"onmousemove": {
-		EventName:     "onmousemove",
-		SupportedTags: []string{"div", "span", "canvas"},
-		ExpectedSig:   "func(events.MouseEventArgs)",
-		RequiresArgs:  true,
-		ArgsType:      "events.MouseEventArgs",
+		EventName:    "onmousemove",
+		ExpectedSig:  "func(events.MouseEventArgs)",
+		RequiresArgs: true,
+		ArgsType:     "events.MouseEventArgs",
+	},
+
+	// More mouse events
+	"ondblclick": {
+		EventName:    "ondblclick",
+		ExpectedSig:  "func(events.MouseEventArgs)",
+		RequiresArgs: true,
+		ArgsType:     "events.MouseEventArgs",
+	},
+	"oncontextmenu": {
+		EventName:    "oncontextmenu",
+		ExpectedSig:  "func(events.MouseEventArgs)",
+		RequiresArgs: true,
+		ArgsType:     "events.MouseEventArgs",
+	},
+	"onmouseenter": {
+		EventName:    "onmouseenter",
+		ExpectedSig:  "func(events.MouseEventArgs)",
+		RequiresArgs: true,
+		ArgsType:     "events.MouseEventArgs",
+	},
+	"onmouseleave": {
+		EventName:    "onmouseleave",
+		ExpectedSig:  "func(events.MouseEventArgs)",
+		RequiresArgs: true,
+		ArgsType:     "events.MouseEventArgs",
+	},
+
+	// Pointer events
+	"onpointerdown": {
+		EventName:    "onpointerdown",
+		ExpectedSig:  "func(events.PointerEventArgs)",
+		RequiresArgs: true,
+		ArgsType:     "events.PointerEventArgs",
+	},
+	"onpointermove": {
+		EventName:    "onpointermove",
+		ExpectedSig:  "func(events.PointerEventArgs)",
+		RequiresArgs: true,
+		ArgsType:     "events.PointerEventArgs",
+	},
+	"onpointerup": {
+		EventName:    "onpointerup",
+		ExpectedSig:  "func(events.PointerEventArgs)",
+		RequiresArgs: true,
+		ArgsType:     "events.PointerEventArgs",
+	},
+	"onpointercancel": {
+		EventName:    "onpointercancel",
+		ExpectedSig:  "func(events.PointerEventArgs)",
+		RequiresArgs: true,
+		ArgsType:     "events.PointerEventArgs",
+	},
+
+	// Touch events
+	"ontouchstart": {
+		EventName:    "ontouchstart",
+		ExpectedSig:  "func(events.TouchEventArgs)",
+		RequiresArgs: true,
+		ArgsType:     "events.TouchEventArgs",
+	},
+	"ontouchmove": {
+		EventName:    "ontouchmove",
+		ExpectedSig:  "func(events.TouchEventArgs)",
+		RequiresArgs: true,
+		ArgsType:     "events.TouchEventArgs",
+	},
+	"ontouchend": {
+		EventName:    "ontouchend",
+		ExpectedSig:  "func(events.TouchEventArgs)",
+		RequiresArgs: true,
+		ArgsType:     "events.TouchEventArgs",
+	},
+
+	// Wheel and scroll events
+	"onwheel": {
+		EventName:    "onwheel",
+		ExpectedSig:  "func(events.WheelEventArgs)",
+		RequiresArgs: true,
+		ArgsType:     "events.WheelEventArgs",
+	},
+	"onscroll": {
+		EventName:    "onscroll",
+		ExpectedSig:  "func(events.ScrollEventArgs)",
+		RequiresArgs: true,
+		ArgsType:     "events.ScrollEventArgs",
+	},
+
+	// Drag-and-drop events
+	"ondragstart": {
+		EventName:    "ondragstart",
+		ExpectedSig:  "func(events.DragEventArgs)",
+		RequiresArgs: true,
+		ArgsType:     "events.DragEventArgs",
+	},
+	"ondragover": {
+		EventName:    "ondragover",
+		ExpectedSig:  "func(events.DragEventArgs)",
+		RequiresArgs: true,
+		ArgsType:     "events.DragEventArgs",
+	},
+	"ondrop": {
+		EventName:    "ondrop",
+		ExpectedSig:  "func(events.DragEventArgs)",
+		RequiresArgs: true,
+		ArgsType:     "events.DragEventArgs",
+	},
+	"ondragend": {
+		EventName:    "ondragend",
+		ExpectedSig:  "func(events.DragEventArgs)",
+		RequiresArgs: true,
+		ArgsType:     "events.DragEventArgs",
+	},
+
+	// Clipboard events
+	"oncopy": {
+		EventName:    "oncopy",
+		ExpectedSig:  "func(events.ClipboardEventArgs)",
+		RequiresArgs: true,
+		ArgsType:     "events.ClipboardEventArgs",
+	},
+	"oncut": {
+		EventName:    "oncut",
+		ExpectedSig:  "func(events.ClipboardEventArgs)",
+		RequiresArgs: true,
+		ArgsType:     "events.ClipboardEventArgs",
+	},
+	"onpaste": {
+		EventName:    "onpaste",
+		ExpectedSig:  "func(events.ClipboardEventArgs)",
+		RequiresArgs: true,
+		ArgsType:     "events.ClipboardEventArgs",
+	},
+
+	// Composition (IME) events
+	"oncompositionstart": {
+		EventName:    "oncompositionstart",
+		ExpectedSig:  "func(events.CompositionEventArgs)",
+		RequiresArgs: true,
+		ArgsType:     "events.CompositionEventArgs",
+	},
+	"oncompositionend": {
+		EventName:    "oncompositionend",
+		ExpectedSig:  "func(events.CompositionEventArgs)",
+		RequiresArgs: true,
+		ArgsType:     "events.CompositionEventArgs",
+	},
+
+	// CSS animation and transition events
+	"onanimationend": {
+		EventName:    "onanimationend",
+		ExpectedSig:  "func(events.AnimationEventArgs)",
+		RequiresArgs: true,
+		ArgsType:     "events.AnimationEventArgs",
+	},
+	"ontransitionend": {
+		EventName:    "ontransitionend",
+		ExpectedSig:  "func(events.TransitionEventArgs)",
+		RequiresArgs: true,
+		ArgsType:     "events.TransitionEventArgs",
 	},
 }
 
@@ -126,18 +272,3 @@ func GetEventSignature(eventName string) *EventSignature {
 	}
 	return nil
 }
-
-// IsEventSupported checks if an event is supported on a specific HTML tag.
-func IsEventSupported(eventName, tagName string) bool {
-	sig := GetEventSignature(eventName)
-	if sig == nil {
-		return false
-	}
-
-	for _, supportedTag := range sig.SupportedTags {
-		if supportedTag == tagName {
-			return true
-		}
-	}
-	return false
-}
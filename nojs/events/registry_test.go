@@ -0,0 +1,23 @@
+package events
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEventRegistry_EntriesAreConsistent(t *testing.T) {
+	for name, sig := range EventRegistry {
+		if sig.EventName != name {
+			t.Errorf("%s: EventName = %q", name, sig.EventName)
+		}
+		if !strings.HasPrefix(name, "on") || strings.ToLower(name) != name {
+			t.Errorf("%s: event names are lowercase and start with \"on\"", name)
+		}
+		if !strings.HasPrefix(sig.ArgsType, "events.") || !strings.HasSuffix(sig.ArgsType, "EventArgs") {
+			t.Errorf("%s: ArgsType = %q, want events.XxxEventArgs", name, sig.ArgsType)
+		}
+		if !strings.Contains(sig.ExpectedSig, sig.ArgsType) {
+			t.Errorf("%s: ExpectedSig %q does not mention %s", name, sig.ExpectedSig, sig.ArgsType)
+		}
+	}
+}
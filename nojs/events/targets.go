@@ -0,0 +1,137 @@
+package events
+
+import (
+	"math"
+	"strings"
+)
+
+// object is the read-only view of a JavaScript object that event arguments are
+// parsed from. The browser build wraps a js.Value; native tests use plain Go
+// values, so the conversions below are tested without a browser.
+type object interface {
+	// String returns a string property; ok is false if it is missing or not a string.
+	String(name string) (value string, ok bool)
+	// Number returns a number property; ok is false if it is missing or not a number.
+	Number(name string) (value float64, ok bool)
+	// Bool returns whether a property is truthy.
+	Bool(name string) bool
+	// Object returns an object property, or nil if it is undefined or null.
+	Object(name string) object
+	// Len and Index read an array-like object such as a FileList.
+	Len() int
+	Index(i int) object
+	// Keys returns the object's own enumerable property names (Object.keys).
+	Keys() []string
+}
+
+// ElementInfo describes the element an event was dispatched to or handled on.
+type ElementInfo struct {
+	TagName string            // Lowercase tag name (e.g., "input"), or "" if there is no element
+	ID      string            // The id attribute
+	Name    string            // The name attribute (form controls)
+	Dataset map[string]string // data-* attributes, keyed in camelCase (data-item-id => "itemId")
+}
+
+// parseElementInfo reads the ElementInfo of a DOM element. A nil element or a
+// node without a tag name, such as the document, gives the zero ElementInfo.
+func parseElementInfo(el object) ElementInfo {
+	if el == nil {
+		return ElementInfo{}
+	}
+	tagName, ok := el.String("tagName")
+	if !ok {
+		return ElementInfo{}
+	}
+	info := ElementInfo{
+		TagName: strings.ToLower(tagName),
+		Dataset: map[string]string{},
+	}
+	info.ID, _ = el.String("id")
+	info.Name, _ = el.String("name") // Only form controls have a name property
+	if dataset := el.Object("dataset"); dataset != nil {
+		for _, key := range dataset.Keys() {
+			info.Dataset[key], _ = dataset.String(key)
+		}
+	}
+	return info
+}
+
+// File is a file chosen in an <input type="file">, dropped or pasted.
+type File struct {
+	Name         string // The file name, without a path
+	Size         int64  // Size in bytes
+	Type         string // MIME type (e.g., "image/png"), or "" if unknown
+	LastModified int64  // Last modification time in milliseconds since the Unix epoch
+	value        object
+}
+
+// parseFiles converts a FileList to Files. A missing list gives nil; an empty
+// one, such as a file input with nothing chosen, gives an empty slice.
+func parseFiles(list object) []File {
+	if list == nil {
+		return nil
+	}
+	files := make([]File, list.Len())
+	for i := range files {
+		file := list.Index(i)
+		if file == nil {
+			continue
+		}
+		size, _ := file.Number("size")
+		lastModified, _ := file.Number("lastModified")
+		files[i] = File{
+			Size:         int64(size),
+			LastModified: int64(lastModified),
+			value:        file,
+		}
+		files[i].Name, _ = file.String("name")
+		files[i].Type, _ = file.String("type")
+	}
+	return files
+}
+
+// changeTarget holds the form-control state ChangeEventArgs reports about the
+// element that dispatched an input or change event.
+type changeTarget struct {
+	Value           string
+	Checked         bool
+	ValueAsNumber   float64
+	SelectedOptions []string
+	Files           []File
+	SelectionStart  int
+	SelectionEnd    int
+}
+
+// parseChangeTarget reads the state of a form control. Properties an element
+// does not have get their documented empty values: NaN for ValueAsNumber, nil
+// for SelectedOptions and Files, and -1 for the selection range.
+func parseChangeTarget(target object) changeTarget {
+	t := changeTarget{ValueAsNumber: math.NaN(), SelectionStart: -1, SelectionEnd: -1}
+	if target == nil {
+		return t
+	}
+	t.Value, _ = target.String("value")
+	t.Checked = target.Bool("checked")
+	if n, ok := target.Number("valueAsNumber"); ok {
+		t.ValueAsNumber = n
+	}
+	if options := target.Object("selectedOptions"); options != nil {
+		t.SelectedOptions = make([]string, 0, options.Len())
+		for i := 0; i < options.Len(); i++ {
+			if option := options.Index(i); option != nil {
+				value, _ := option.String("value")
+				t.SelectedOptions = append(t.SelectedOptions, value)
+			}
+		}
+	}
+	if kind, _ := target.String("type"); kind == "file" {
+		t.Files = parseFiles(target.Object("files"))
+	}
+	// selectionStart and selectionEnd are null on inputs without a text selection (checkboxes, numbers)
+	start, startOK := target.Number("selectionStart")
+	end, endOK := target.Number("selectionEnd")
+	if startOK && endOK {
+		t.SelectionStart, t.SelectionEnd = int(start), int(end)
+	}
+	return t
+}
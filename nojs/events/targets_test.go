@@ -0,0 +1,186 @@
+package events
+
+import (
+	"math"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// fakeObject is a JavaScript object made of Go values: strings, bools, float64s,
+// nested fakeObjects and fakeLists. Missing keys read as undefined.
+type fakeObject map[string]any
+
+func (o fakeObject) String(name string) (string, bool) {
+	s, ok := o[name].(string)
+	return s, ok
+}
+
+func (o fakeObject) Number(name string) (float64, bool) {
+	n, ok := o[name].(float64)
+	return n, ok
+}
+
+func (o fakeObject) Bool(name string) bool {
+	switch v := o[name].(type) {
+	case bool:
+		return v
+	case string:
+		return v != ""
+	case float64:
+		return v != 0 && !math.IsNaN(v)
+	}
+	return o[name] != nil
+}
+
+func (o fakeObject) Object(name string) object {
+	if v, ok := o[name].(object); ok {
+		return v
+	}
+	return nil
+}
+
+func (o fakeObject) Len() int           { return 0 }
+func (o fakeObject) Index(i int) object { return nil }
+
+func (o fakeObject) Keys() []string {
+	keys := make([]string, 0, len(o))
+	for key := range o {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// fakeList is an array-like JavaScript object such as a FileList.
+type fakeList []object
+
+func (l fakeList) String(string) (string, bool)  { return "", false }
+func (l fakeList) Number(string) (float64, bool) { return 0, false }
+func (l fakeList) Bool(string) bool              { return false }
+func (l fakeList) Object(string) object          { return nil }
+func (l fakeList) Len() int                      { return len(l) }
+func (l fakeList) Index(i int) object            { return l[i] }
+func (l fakeList) Keys() []string                { return nil }
+
+func TestParseElementInfo(t *testing.T) {
+	tests := []struct {
+		name string
+		el   object
+		want ElementInfo
+	}{
+		{"missing element", nil, ElementInfo{}},
+		{"node without a tag name", fakeObject{"nodeName": "#document"}, ElementInfo{}},
+		{
+			"element with dataset",
+			fakeObject{"tagName": "LI", "id": "row-3", "dataset": fakeObject{"itemId": "3", "kind": "task"}},
+			ElementInfo{TagName: "li", ID: "row-3", Dataset: map[string]string{"itemId": "3", "kind": "task"}},
+		},
+		{
+			"form control name",
+			fakeObject{"tagName": "INPUT", "id": "", "name": "email", "dataset": fakeObject{}},
+			ElementInfo{TagName: "input", Name: "email", Dataset: map[string]string{}},
+		},
+		{
+			// A <form> with an input named "name" exposes that input as form.name
+			"name that is not a string",
+			fakeObject{"tagName": "FORM", "name": fakeObject{"tagName": "INPUT"}},
+			ElementInfo{TagName: "form", Dataset: map[string]string{}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseElementInfo(tt.el); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseElementInfo() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFiles(t *testing.T) {
+	photo := fakeObject{"name": "photo.png", "size": 2048.0, "type": "image/png", "lastModified": 1700000000000.0}
+	notes := fakeObject{"name": "notes", "size": 0.0, "type": "", "lastModified": 1.0}
+
+	files := parseFiles(fakeList{photo, notes})
+
+	if len(files) != 2 {
+		t.Fatalf("len(files) = %d, want 2", len(files))
+	}
+	want := File{Name: "photo.png", Size: 2048, Type: "image/png", LastModified: 1700000000000, value: photo}
+	if !reflect.DeepEqual(files[0], want) {
+		t.Errorf("files[0] = %+v, want %+v", files[0], want)
+	}
+	if files[1].Name != "notes" || files[1].Size != 0 || files[1].Type != "" {
+		t.Errorf("files[1] = %+v, want an empty file named notes", files[1])
+	}
+	if got := parseFiles(nil); got != nil {
+		t.Errorf("parseFiles(nil) = %v, want nil", got)
+	}
+	if got := parseFiles(fakeList{}); got == nil || len(got) != 0 {
+		t.Errorf("parseFiles(empty FileList) = %#v, want an empty slice", got)
+	}
+}
+
+func TestParseChangeTarget(t *testing.T) {
+	tests := []struct {
+		name   string
+		target object
+		want   changeTarget
+	}{
+		{
+			"missing target",
+			nil,
+			changeTarget{ValueAsNumber: math.NaN(), SelectionStart: -1, SelectionEnd: -1},
+		},
+		{
+			"text input with a selection",
+			fakeObject{"type": "text", "value": "hello", "checked": false, "valueAsNumber": math.NaN(), "selectionStart": 1.0, "selectionEnd": 4.0},
+			changeTarget{Value: "hello", ValueAsNumber: math.NaN(), SelectionStart: 1, SelectionEnd: 4},
+		},
+		{
+			"number input without a text selection",
+			fakeObject{"type": "number", "value": "2.5", "valueAsNumber": 2.5, "selectionStart": nil, "selectionEnd": nil},
+			changeTarget{Value: "2.5", ValueAsNumber: 2.5, SelectionStart: -1, SelectionEnd: -1},
+		},
+		{
+			"checked checkbox",
+			fakeObject{"type": "checkbox", "value": "on", "checked": true, "valueAsNumber": math.NaN()},
+			changeTarget{Value: "on", Checked: true, ValueAsNumber: math.NaN(), SelectionStart: -1, SelectionEnd: -1},
+		},
+		{
+			"select multiple",
+			fakeObject{"value": "b", "selectedOptions": fakeList{fakeObject{"value": "b"}, fakeObject{"value": "d"}}},
+			changeTarget{Value: "b", ValueAsNumber: math.NaN(), SelectedOptions: []string{"b", "d"}, SelectionStart: -1, SelectionEnd: -1},
+		},
+		{
+			"select without a selection",
+			fakeObject{"value": "", "selectedOptions": fakeList{}},
+			changeTarget{ValueAsNumber: math.NaN(), SelectedOptions: []string{}, SelectionStart: -1, SelectionEnd: -1},
+		},
+		{
+			"file input with nothing chosen",
+			fakeObject{"type": "file", "value": "", "files": fakeList{}},
+			changeTarget{ValueAsNumber: math.NaN(), Files: []File{}, SelectionStart: -1, SelectionEnd: -1},
+		},
+		{
+			"files are only read from file inputs",
+			fakeObject{"type": "text", "files": fakeList{fakeObject{"name": "a.txt"}}},
+			changeTarget{ValueAsNumber: math.NaN(), SelectionStart: -1, SelectionEnd: -1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseChangeTarget(tt.target)
+
+			// NaN never equals itself, so ValueAsNumber is compared on its own
+			if math.IsNaN(tt.want.ValueAsNumber) != math.IsNaN(got.ValueAsNumber) ||
+				(!math.IsNaN(tt.want.ValueAsNumber) && got.ValueAsNumber != tt.want.ValueAsNumber) {
+				t.Errorf("ValueAsNumber = %v, want %v", got.ValueAsNumber, tt.want.ValueAsNumber)
+			}
+			got.ValueAsNumber, tt.want.ValueAsNumber = 0, 0
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseChangeTarget() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,8 +1,8 @@
-//go:build js || wasm
-// +build js wasm
-
 package runtime
 
+// The lifecycle interfaces have NO build tags so the server-side renderer (ssr.go)
+// can drive them in native builds.
+
 // Mountable is implemented by components that need one-time initialization.
 // OnMount is called once after the component instance is created, before the first render.
 //
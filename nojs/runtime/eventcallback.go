@@ -0,0 +1,44 @@
+package runtime
+
+// EventCallback is an event a child component raises to its parent. Declare it as a
+// field tagged `nojs:"event"` and the parent binds a method to it in its template:
+//
+//	type Modal struct {
+//	    runtime.ComponentBase
+//	    OnClose runtime.EventCallback[ModalResult] `nojs:"event"`
+//	}
+//
+//	<Modal @onclose="HandleModalClose">...</Modal>
+//
+// The child raises the event with c.OnClose.Invoke(result). After the parent's handler
+// runs, the parent is re-rendered, so handlers do not need to call StateHasChanged.
+// The zero value has no handler; invoking it does nothing.
+//
+// This type has no build tags and works in both WASM and test environments.
+type EventCallback[T any] struct {
+	receiver interface{ StateHasChanged() }
+	handler  func(T)
+}
+
+// NewEventCallback binds handler to an event, re-rendering receiver after each call.
+// Generated code calls it with the parent component as receiver; receiver may be nil.
+func NewEventCallback[T any](receiver interface{ StateHasChanged() }, handler func(T)) EventCallback[T] {
+	return EventCallback[T]{receiver: receiver, handler: handler}
+}
+
+// Invoke calls the bound handler with arg and then re-renders the component that bound it.
+// It does nothing if no handler is bound.
+func (cb EventCallback[T]) Invoke(arg T) {
+	if cb.handler == nil {
+		return
+	}
+	cb.handler(arg)
+	if cb.receiver != nil {
+		cb.receiver.StateHasChanged()
+	}
+}
+
+// HasDelegate reports whether the parent bound a handler to the event.
+func (cb EventCallback[T]) HasDelegate() bool {
+	return cb.handler != nil
+}
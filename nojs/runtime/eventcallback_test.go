@@ -0,0 +1,48 @@
+package runtime
+
+import "testing"
+
+// countingReceiver records StateHasChanged calls.
+type countingReceiver struct {
+	renders int
+}
+
+func (r *countingReceiver) StateHasChanged() { r.renders++ }
+
+func TestEventCallback_InvokeCallsHandlerThenRerenders(t *testing.T) {
+	// Arrange
+	receiver := &countingReceiver{}
+	var got string
+	rendersBeforeHandler := -1
+	cb := NewEventCallback(receiver, func(value string) {
+		got = value
+		rendersBeforeHandler = receiver.renders
+	})
+
+	// Act
+	cb.Invoke("ok")
+
+	// Assert
+	if got != "ok" {
+		t.Errorf("handler got %q, want %q", got, "ok")
+	}
+	if rendersBeforeHandler != 0 || receiver.renders != 1 {
+		t.Errorf("renders before handler = %d, after = %d; want 0 and 1", rendersBeforeHandler, receiver.renders)
+	}
+	if !cb.HasDelegate() {
+		t.Error("HasDelegate() = false for a bound callback")
+	}
+}
+
+func TestEventCallback_ZeroValueIsNoOp(t *testing.T) {
+	// Arrange
+	var cb EventCallback[int]
+
+	// Act
+	cb.Invoke(1)
+
+	// Assert
+	if cb.HasDelegate() {
+		t.Error("HasDelegate() = true for the zero value")
+	}
+}
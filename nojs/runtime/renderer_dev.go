@@ -4,6 +4,8 @@
 
 package runtime
 
+import "github.com/ForgeLogic/nojs/console"
+
 // callOnMount invokes the OnMount lifecycle method in development mode.
 // In dev mode, panics propagate to aid debugging and fast failure.
 func (r *RendererImpl) callOnMount(mountable Mountable, key string) {
@@ -21,3 +23,19 @@ func (r *RendererImpl) callOnParametersSet(receiver ParameterReceiver, key strin
 func (r *RendererImpl) callOnUnmount(unmountable Unmountable, key string) {
 	unmountable.OnUnmount()
 }
+
+// reportHydrationMismatches logs every node that hydration had to repair.
+// In dev mode, mismatches are surfaced so server and client templates can be kept in sync.
+func (r *RendererImpl) reportHydrationMismatches(mismatches []string) {
+	for _, m := range mismatches {
+		console.Warn("[Hydration mismatch]", m)
+	}
+}
+
+// warnNilSlots logs every content slot the child component was created without.
+// In dev mode, unfilled slots are surfaced, as the compiler does for templates.
+func (r *RendererImpl) warnNilSlots(comp Component) {
+	for _, w := range nilSlotWarnings(comp) {
+		console.Warn(w)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"sync"
 
+	"github.com/ForgeLogic/nojs/console"
 	"github.com/ForgeLogic/nojs/vdom"
 )
 
@@ -28,6 +29,8 @@ type RendererImpl struct {
 	prevVDOM          *vdom.VNode               // Previous VDOM tree for patching
 	instanceVDOMCache map[Component]*vdom.VNode // Track VDOM per component instance (for scoped updates)
 	renderingStack    []Component               // Stack of components currently rendering (for scoped cache keys)
+	hydrate           bool                      // Adopt server-rendered markup on the first RenderRoot
+	transferState     *TransferState            // State handed over from server-side rendering
 }
 
 // NewRenderer creates a new runtime renderer.
@@ -43,9 +46,40 @@ func NewRenderer(navManager NavigationManager, mountID string) *RendererImpl {
 		mountID:           mountID,
 		prevVDOM:          nil,
 		renderingStack:    make([]Component, 0),
+		transferState:     NewTransferState(),
 	}
 }
 
+// EnableHydration makes the first RenderRoot adopt server-rendered markup under the mount
+// element instead of clearing and rebuilding it. It also loads the state written by
+// TransferState.WriteScript, so OnMount can read it via TransferStateOf.
+// Call it before the first RenderRoot. If the mount element turns out to be empty,
+// the first render falls back to building the DOM from scratch.
+func (r *RendererImpl) EnableHydration() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.hydrate = true
+
+	script := vdom.CurrentDOM().QuerySelector("#" + TransferStateScriptID)
+	if script == nil {
+		return
+	}
+	data, _ := vdom.CurrentDOM().GetProperty(script, "textContent").(string)
+	state, err := ParseTransferState(data)
+	if err != nil {
+		console.Error("Hydration:", err.Error())
+		return
+	}
+	r.transferState = state
+}
+
+// TransferState returns the state handed over from the server (empty if none).
+// It does not lock: it is read from lifecycle methods that run while RenderRoot holds the mutex,
+// and the field is only written by EnableHydration before the first render.
+func (r *RendererImpl) TransferState() *TransferState {
+	return r.transferState
+}
+
 // GetCurrentComponent returns the current root component being rendered.
 // This is used by the router engine to access methods on the root component (e.g., AppShell).
 func (r *RendererImpl) GetCurrentComponent() Component {
@@ -110,9 +144,19 @@ func (r *RendererImpl) RenderRoot() {
 	newVDOM.ComponentKey = r.currentKey
 
 	if r.prevVDOM == nil {
-		// Initial render: clear and render fresh
-		vdom.Clear(r.mountID, nil)
-		vdom.RenderToSelector(r.mountID, newVDOM)
+		hydrated := false
+		if r.hydrate {
+			// Initial render over server markup: adopt the existing DOM
+			r.hydrate = false
+			var mismatches []string
+			mismatches, hydrated = vdom.Hydrate(r.mountID, newVDOM)
+			r.reportHydrationMismatches(mismatches)
+		}
+		if !hydrated {
+			// Initial render: clear and render fresh
+			vdom.Clear(r.mountID, nil)
+			vdom.RenderToSelector(r.mountID, newVDOM)
+		}
 	} else {
 		// Check if component key changed (e.g., router navigation)
 		if r.prevVDOM.ComponentKey != newVDOM.ComponentKey {
@@ -191,6 +235,8 @@ func (r *RendererImpl) RenderChild(key string, childWithProps Component) *vdom.V
 
 	// Call lifecycle methods in the correct order
 	if isFirstRender {
+		r.warnNilSlots(instance)
+
 		// Call OnMount only once, before first render
 		if mountable, ok := instance.(Mountable); ok {
 			r.callOnMount(mountable, globalKey)
@@ -41,3 +41,11 @@ func (r *RendererImpl) callOnUnmount(unmountable Unmountable, key string) {
 	}()
 	unmountable.OnUnmount()
 }
+
+// reportHydrationMismatches is a no-op in production mode.
+// Hydration has already repaired the DOM; reporting is a development aid.
+func (r *RendererImpl) reportHydrationMismatches(mismatches []string) {}
+
+// warnNilSlots is a no-op in production mode.
+// An unfilled slot renders nothing; reporting it is a development aid.
+func (r *RendererImpl) warnNilSlots(comp Component) {}
@@ -0,0 +1,38 @@
+package runtime
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/ForgeLogic/nojs/vdom"
+)
+
+var slotType = reflect.TypeOf([]*vdom.VNode(nil))
+
+// nilSlotWarnings returns a warning for every content slot ([]*vdom.VNode field) of comp
+// that is nil. Compiled templates pass an empty, non-nil slice for a slot that is filled
+// with nothing, so a nil slot was never filled: neither by the parent's template nor by the
+// Go code that created the component.
+func nilSlotWarnings(comp Component) []string {
+	v := reflect.ValueOf(comp)
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var warnings []string
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if !field.IsExported() || field.Type != slotType || !v.Field(i).IsNil() {
+			continue
+		}
+		warnings = append(warnings, fmt.Sprintf("[RenderChild] Slot '%s' of component '%s' is nil. Fill it in the parent's template, or pass an empty slice to leave it empty on purpose.",
+			field.Name, v.Type().Name()))
+	}
+	return warnings
+}
@@ -0,0 +1,43 @@
+package runtime
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ForgeLogic/nojs/vdom"
+)
+
+// slotCard is a test component with two content slots.
+type slotCard struct {
+	ComponentBase
+
+	Title  string
+	Body   []*vdom.VNode
+	Footer []*vdom.VNode
+}
+
+func (c *slotCard) Render(r Renderer) *vdom.VNode {
+	return vdom.Div(nil)
+}
+
+func TestNilSlotWarnings(t *testing.T) {
+	tests := []struct {
+		name string
+		comp Component
+		want []string
+	}{
+		{"every slot filled", &slotCard{Body: []*vdom.VNode{vdom.Text("a")}, Footer: []*vdom.VNode{}}, nil},
+		{"nil slot named", &slotCard{Body: []*vdom.VNode{vdom.Text("a")}}, []string{
+			"[RenderChild] Slot 'Footer' of component 'slotCard' is nil. Fill it in the parent's template, or pass an empty slice to leave it empty on purpose.",
+		}},
+		{"nil component", (*slotCard)(nil), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nilSlotWarnings(tt.comp); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("nilSlotWarnings() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package runtime
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ForgeLogic/nojs/vdom"
+)
+
+// Compile-time assertion to ensure staticRenderer implements the Renderer interface.
+var _ Renderer = (*staticRenderer)(nil)
+
+// staticRenderer renders a component tree exactly once, without a DOM.
+// This file has NO build tags: it is the native entry point for server-side rendering.
+//
+// Each component goes through the same first-render lifecycle as in the browser:
+// OnMount, then OnParametersSet, then Render. Work that OnMount starts in a goroutine
+// does not finish before the snapshot is taken; load data synchronously in OnMount
+// (or before calling RenderStatic) when it must appear in the server output.
+type staticRenderer struct {
+	state *TransferState
+}
+
+// RenderStatic runs comp and every child it renders through OnMount, OnParametersSet and
+// Render, and returns the resulting VNode tree. A panic in any lifecycle method is returned
+// as an error so a single failing page cannot crash the server.
+func RenderStatic(comp Component) (*vdom.VNode, error) {
+	return RenderStaticWithState(comp, NewTransferState())
+}
+
+// RenderStaticWithState is RenderStatic with a caller-supplied TransferState. Values that
+// components store via TransferStateOf end up in state; write them into the page with
+// state.WriteScript so the hydrating client can reuse them.
+func RenderStaticWithState(comp Component, state *TransferState) (root *vdom.VNode, err error) {
+	if comp == nil {
+		return nil, fmt.Errorf("RenderStatic called with a nil component")
+	}
+
+	defer func() {
+		if rec := recover(); rec != nil {
+			root = nil
+			err = fmt.Errorf("server-side render of %T panicked: %v", comp, rec)
+		}
+	}()
+
+	return (&staticRenderer{state: state}).render(comp), nil
+}
+
+// RenderToHTML renders comp with RenderStatic and writes its HTML to w.
+//
+// Example (plain net/http first paint):
+//
+//	http.HandleFunc("/", func(w http.ResponseWriter, req *http.Request) {
+//	    io.WriteString(w, `<!DOCTYPE html><html><body><div id="app">`)
+//	    if err := runtime.RenderToHTML(w, &HomePage{}); err != nil {
+//	        log.Println(err)
+//	    }
+//	    io.WriteString(w, `</div><script src="wasm_exec.js"></script>...</body></html>`)
+//	})
+func RenderToHTML(w io.Writer, comp Component) error {
+	root, err := RenderStatic(comp)
+	if err != nil {
+		return err
+	}
+	return vdom.RenderHTML(w, root)
+}
+
+// RenderToString renders comp with RenderStatic and returns its HTML.
+func RenderToString(comp Component) (string, error) {
+	var sb strings.Builder
+	if err := RenderToHTML(&sb, comp); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// TransferState returns the state collected during this render.
+func (r *staticRenderer) TransferState() *TransferState {
+	return r.state
+}
+
+// render runs the first-render lifecycle of a single component.
+func (r *staticRenderer) render(comp Component) *vdom.VNode {
+	comp.SetRenderer(r)
+
+	if mountable, ok := comp.(Mountable); ok {
+		mountable.OnMount()
+	}
+	if paramReceiver, ok := comp.(ParameterReceiver); ok {
+		paramReceiver.OnParametersSet()
+	}
+
+	return comp.Render(r)
+}
+
+// RenderChild renders a fresh child instance; there are no previous instances to reuse.
+func (r *staticRenderer) RenderChild(key string, childWithProps Component) *vdom.VNode {
+	return r.render(childWithProps)
+}
+
+// ReRender is a no-op: the server output is a single snapshot.
+func (r *staticRenderer) ReRender() {}
+
+// ReRenderSlot is a no-op: the server output is a single snapshot.
+func (r *staticRenderer) ReRenderSlot(slotParent Component) error {
+	return nil
+}
+
+// Navigate is not available while rendering on the server.
+func (r *staticRenderer) Navigate(path string) error {
+	return fmt.Errorf("cannot navigate to %q during server-side rendering", path)
+}
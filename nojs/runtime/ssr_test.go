@@ -0,0 +1,107 @@
+package runtime
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ForgeLogic/nojs/events"
+	"github.com/ForgeLogic/nojs/vdom"
+)
+
+type ssrChild struct {
+	ComponentBase
+	Label string
+}
+
+func (c *ssrChild) Render(r Renderer) *vdom.VNode {
+	return vdom.NewVNode("li", nil, nil, c.Label)
+}
+
+type ssrPage struct {
+	ComponentBase
+	calls []string
+	Items []string
+}
+
+func (c *ssrPage) OnMount() {
+	c.calls = append(c.calls, "OnMount")
+	c.Items = []string{"a", "<b>"}
+}
+
+func (c *ssrPage) OnParametersSet() {
+	c.calls = append(c.calls, "OnParametersSet")
+}
+
+func (c *ssrPage) Render(r Renderer) *vdom.VNode {
+	c.calls = append(c.calls, "Render")
+	children := make([]*vdom.VNode, 0, len(c.Items))
+	for i, item := range c.Items {
+		children = append(children, r.RenderChild(string(rune('0'+i)), &ssrChild{Label: item}))
+	}
+	return vdom.NewVNode("ul", map[string]any{"onClick": func() {}}, children, "")
+}
+
+type ssrPanicking struct {
+	ComponentBase
+}
+
+func (c *ssrPanicking) OnMount() { panic("boom") }
+
+func (c *ssrPanicking) Render(r Renderer) *vdom.VNode { return vdom.Div(nil) }
+
+// ssrCounter has typed event handlers, as compiled components with @onclick and
+// @oninput handlers taking event arguments do.
+type ssrCounter struct {
+	ComponentBase
+	Count int
+	Query string
+}
+
+func (c *ssrCounter) Increment(e events.ClickEventArgs) { c.Count++ }
+
+func (c *ssrCounter) Search(e events.ChangeEventArgs) { c.Query = e.Value }
+
+func (c *ssrCounter) Render(r Renderer) *vdom.VNode {
+	return vdom.NewVNode("div", nil, []*vdom.VNode{
+		vdom.NewVNode("button", map[string]any{"onClick": events.AdaptClickEvent(c.Increment)}, nil, "+1"),
+		vdom.NewVNode("input", map[string]any{"type": "search", "onInput": events.AdaptChangeEvent(c.Search)}, nil, ""),
+	}, "")
+}
+
+func TestRenderToString_RunsLifecycleAndRendersChildren(t *testing.T) {
+	// Arrange
+	page := &ssrPage{}
+
+	// Act
+	html, err := RenderToString(page)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("RenderToString() error = %v", err)
+	}
+	if want := "<ul><li>a</li><li>&lt;b&gt;</li></ul>"; html != want {
+		t.Errorf("RenderToString() = %s, want %s", html, want)
+	}
+	if got := strings.Join(page.calls, ","); got != "OnMount,OnParametersSet,Render" {
+		t.Errorf("lifecycle order = %s", got)
+	}
+}
+
+func TestRenderToString_PanicBecomesError(t *testing.T) {
+	if _, err := RenderToString(&ssrPanicking{}); err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Errorf("RenderToString() error = %v, want error mentioning the panic", err)
+	}
+}
+
+func TestRenderToString_TypedEventHandlers(t *testing.T) {
+	// Act
+	html, err := RenderToString(&ssrCounter{})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("RenderToString() error = %v", err)
+	}
+	if want := `<div><button>+1</button><input type="search"></div>`; html != want {
+		t.Errorf("RenderToString() = %s, want %s", html, want)
+	}
+}
@@ -0,0 +1,109 @@
+package runtime
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// TransferStateScriptID is the id of the <script> element that carries server state to the client.
+const TransferStateScriptID = "nojs-state"
+
+// TransferState hands data loaded during server-side rendering to the client, so that
+// OnMount can skip refetching it after hydration. Values are stored as JSON by key.
+// This type has NO build tags and is safe for concurrent use.
+//
+// Example:
+//
+//	func (c *UserList) OnMount() {
+//	    state := runtime.TransferStateOf(c.GetRenderer())
+//	    if state.Get("users", &c.Users) {
+//	        return // Already loaded by the server
+//	    }
+//	    c.Users = loadUsers()
+//	    state.Set("users", c.Users)
+//	}
+type TransferState struct {
+	mu     sync.Mutex
+	values map[string]json.RawMessage
+}
+
+// NewTransferState returns an empty TransferState.
+func NewTransferState() *TransferState {
+	return &TransferState{values: make(map[string]json.RawMessage)}
+}
+
+// ParseTransferState decodes the JSON written by WriteScript.
+func ParseTransferState(data string) (*TransferState, error) {
+	s := NewTransferState()
+	if data == "" {
+		return s, nil
+	}
+	if err := json.Unmarshal([]byte(data), &s.values); err != nil {
+		return nil, fmt.Errorf("invalid transfer state: %w", err)
+	}
+	return s, nil
+}
+
+// Set stores v under key as JSON.
+func (s *TransferState) Set(key string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("transfer state %q: %w", key, err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.values[key] = data
+	return nil
+}
+
+// Get decodes the value stored under key into v. It reports false if the key is
+// missing or the value cannot be decoded into v.
+func (s *TransferState) Get(key string, v any) bool {
+	s.mu.Lock()
+	data, ok := s.values[key]
+	s.mu.Unlock()
+	if !ok {
+		return false
+	}
+	return json.Unmarshal(data, v) == nil
+}
+
+// Len returns the number of stored values.
+func (s *TransferState) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.values)
+}
+
+// WriteScript writes the state as <script type="application/json" id="nojs-state">.
+// json.Marshal escapes <, > and &, so values cannot close the script element early.
+// Place the script outside the mount element.
+func (s *TransferState) WriteScript(w io.Writer) error {
+	s.mu.Lock()
+	data, err := json.Marshal(s.values)
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, `<script type="application/json" id="%s">%s</script>`, TransferStateScriptID, data)
+	return err
+}
+
+// transferStateProvider is implemented by renderers that carry a TransferState.
+type transferStateProvider interface {
+	TransferState() *TransferState
+}
+
+// TransferStateOf returns the TransferState of the renderer a component is attached to.
+// When the renderer has none (e.g. a test renderer), it returns an empty, detached state
+// so callers never need a nil check.
+func TransferStateOf(r Renderer) *TransferState {
+	if p, ok := r.(transferStateProvider); ok {
+		if s := p.TransferState(); s != nil {
+			return s
+		}
+	}
+	return NewTransferState()
+}
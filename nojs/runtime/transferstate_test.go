@@ -0,0 +1,63 @@
+package runtime
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ForgeLogic/nojs/vdom"
+)
+
+func TestTransferState_RoundTripThroughScript(t *testing.T) {
+	// Arrange
+	server := NewTransferState()
+	if err := server.Set("users", []string{"Ann", "</script><b>"}); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	var sb strings.Builder
+	if err := server.WriteScript(&sb); err != nil {
+		t.Fatalf("WriteScript() error = %v", err)
+	}
+	script := sb.String()
+	body := strings.TrimSuffix(strings.TrimPrefix(script, `<script type="application/json" id="nojs-state">`), "</script>")
+
+	// Act
+	client, err := ParseTransferState(body)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("ParseTransferState() error = %v", err)
+	}
+	if strings.Count(script, "</script>") != 1 {
+		t.Errorf("script element can be closed early: %s", script)
+	}
+	var users []string
+	if !client.Get("users", &users) || len(users) != 2 || users[1] != "</script><b>" {
+		t.Errorf("Get() users = %v", users)
+	}
+	if client.Get("missing", &users) {
+		t.Error("Get() reported a missing key as present")
+	}
+}
+
+type statefulPage struct {
+	ComponentBase
+}
+
+func (c *statefulPage) OnMount() {
+	TransferStateOf(c.GetRenderer()).Set("greeting", "hello")
+}
+
+func (c *statefulPage) Render(r Renderer) *vdom.VNode { return vdom.Div(nil) }
+
+func TestRenderStaticWithState_CollectsComponentState(t *testing.T) {
+	state := NewTransferState()
+
+	if _, err := RenderStaticWithState(&statefulPage{}, state); err != nil {
+		t.Fatalf("RenderStaticWithState() error = %v", err)
+	}
+
+	var greeting string
+	if !state.Get("greeting", &greeting) || greeting != "hello" {
+		t.Errorf("greeting = %q, want %q", greeting, "hello")
+	}
+}
@@ -0,0 +1,96 @@
+package vdom
+
+// Node is an opaque handle to a node owned by a DOM backend.
+// The WASM backend uses js.Value; MemoryDOM uses *MemoryNode.
+// A nil Node means "no node" (e.g. a missing child or an empty text VNode).
+type Node any
+
+// DOM abstracts every document operation the VDOM renderer performs.
+// This file has NO build tags: the diff/patch algorithm is written against this
+// interface, so it runs unchanged in the browser (JSDOM) and in native tests (MemoryDOM).
+type DOM interface {
+	// QuerySelector returns the first element matching the CSS selector, or nil.
+	QuerySelector(selector string) Node
+
+	// CreateElement creates a detached element with the given tag name.
+	CreateElement(tag string) Node
+
+	// CreateElementNS creates a detached element in a namespace such as NamespaceSVG.
+	CreateElementNS(namespace, tag string) Node
+
+	// CreateText creates a detached text node.
+	CreateText(content string) Node
+
+	// SetAttribute sets an HTML attribute on an element.
+	SetAttribute(el Node, key, value string)
+
+	// SetAttributeNS sets a namespaced attribute. key is the qualified name, e.g. "xlink:href".
+	SetAttributeNS(el Node, namespace, key, value string)
+
+	// GetAttribute returns the value of an HTML attribute and whether it is present.
+	GetAttribute(el Node, key string) (string, bool)
+
+	// AttributeNames returns the qualified names of every attribute an element has.
+	AttributeNames(el Node) []string
+
+	// RemoveAttribute removes an HTML attribute from an element.
+	RemoveAttribute(el Node, key string)
+
+	// RemoveAttributeNS removes a namespaced attribute by its qualified name.
+	RemoveAttributeNS(el Node, namespace, key string)
+
+	// SetProperty sets a DOM property such as "value" or "textContent".
+	SetProperty(el Node, key string, value any)
+
+	// GetProperty reads a DOM property. Strings, booleans and numbers are returned
+	// as Go string, bool and float64; missing properties return nil.
+	GetProperty(el Node, key string) any
+
+	// InsertBefore inserts child into parent before ref. A nil ref appends child.
+	// Inserting a node that is already attached moves it.
+	InsertBefore(parent, child, ref Node)
+
+	// RemoveChild detaches child from parent.
+	RemoveChild(parent, child Node)
+
+	// ReplaceChild swaps oldChild for newChild in parent.
+	ReplaceChild(parent, newChild, oldChild Node)
+
+	// FirstChild returns the first child node of parent, or nil.
+	FirstChild(parent Node) Node
+
+	// ChildAt returns the child node of parent at index, or nil.
+	ChildAt(parent Node, index int) Node
+
+	// NodeName returns the tag name of an element, lowercase for HTML and as written for
+	// SVG and MathML (e.g. "foreignObject"), "#text" for text nodes and "#comment" for comments.
+	NodeName(node Node) string
+
+	// ParentNode returns the parent of node, or nil if it is detached.
+	ParentNode(node Node) Node
+
+	// HasFocus reports whether the element currently has keyboard focus.
+	HasFocus(el Node) bool
+
+	// AddEventListener attaches handler for eventName and returns a function that
+	// detaches it and releases any backend resources. handler is either a func(),
+	// a backend-specific event callback (func(js.Value) in the browser), or an
+	// EventListener wrapping one of those with listener options.
+	AddEventListener(el Node, eventName string, handler any) (remove func())
+}
+
+// EventListener is an event attribute value that carries addEventListener options
+// along with its handler. The compiler emits it for the .capture, .passive and .once
+// event modifiers; a plain handler function is used otherwise.
+type EventListener struct {
+	Handler any  // func() or a backend-specific event callback
+	Capture bool // Listen during the capture phase instead of bubbling
+	Passive bool // Promise the browser the handler never calls preventDefault
+	Once    bool // Handle only the first event for the lifetime of the DOM element
+}
+
+// onceFlag is the element property that records that a .once listener has fired.
+// It lives on the DOM element so the listener stays spent when re-renders attach it again.
+func onceFlag(eventName string) string {
+	return "__nojsOnce_" + eventName
+}
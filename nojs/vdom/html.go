@@ -0,0 +1,249 @@
+package vdom
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"sort"
+	"strings"
+)
+
+// voidElements never have children or a closing tag.
+var voidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true, "hr": true, "img": true,
+	"input": true, "link": true, "meta": true, "source": true, "track": true, "wbr": true,
+}
+
+// rawTextElements hold text the HTML parser reads without decoding entities, so it is written
+// unescaped.
+var rawTextElements = map[string]bool{"script": true, "style": true}
+
+// RenderHTML writes the HTML serialization of a VNode tree to w.
+// This file has NO build tags, so servers can produce first-paint markup natively.
+//
+// The output follows the same rules as the browser renderer:
+//   - Text and attribute values are HTML-escaped, except the text of <script> and <style>,
+//     which is written as it is. Text containing their closing tag is an error.
+//   - A <textarea> or <pre> whose text starts with a newline gets an extra one, which the
+//     browser's parser drops.
+//   - Boolean attributes are written bare when true and omitted when false.
+//   - Event-handler attributes (onClick, onInput, ...) are skipped.
+//   - Content and Children are laid out per tag exactly like createElement. The value a
+//     <select> carries in Content becomes the selected attribute of its matching <option>.
+//   - Fragments write their children without a wrapper element.
+//   - SVG and MathML elements are written like HTML ones; the browser's parser puts
+//     everything inside <svg> and <math> in their namespace.
+//
+// Attributes are written in sorted order so the output is deterministic.
+func RenderHTML(w io.Writer, n *VNode) error {
+	hw := &htmlWriter{w: w}
+	hw.node(n)
+	return hw.err
+}
+
+// RenderHTMLString returns the HTML serialization of a VNode tree.
+func RenderHTMLString(n *VNode) string {
+	var sb strings.Builder
+	_ = RenderHTML(&sb, n) // strings.Builder never fails
+	return sb.String()
+}
+
+// htmlWriter writes markup and keeps the first write error.
+type htmlWriter struct {
+	w        io.Writer
+	err      error
+	lastText bool    // The previous sibling written was a text node
+	rawText  string  // Tag of the <script> or <style> element being written, if any
+	selected *string // Value of the <select> being written until an <option> matches it
+}
+
+func (hw *htmlWriter) write(s string) {
+	if hw.err != nil {
+		return
+	}
+	_, hw.err = io.WriteString(hw.w, s)
+}
+
+func (hw *htmlWriter) text(s string) {
+	hw.write(html.EscapeString(s))
+}
+
+// rawTextNode writes the text of a <script> or <style> element unescaped. Text that would
+// close the element early is rejected, since it cannot be escaped there.
+func (hw *htmlWriter) rawTextNode(s string) {
+	if strings.Contains(strings.ToLower(s), "</"+hw.rawText) {
+		if hw.err == nil {
+			hw.err = fmt.Errorf("vdom: <%s> text must not contain %q", hw.rawText, "</"+hw.rawText)
+		}
+		return
+	}
+	hw.write(s)
+}
+
+// textNode writes a text node. Adjacent text nodes are separated by an empty comment,
+// otherwise the browser would parse them as one node and Hydrate could not match them.
+func (hw *htmlWriter) textNode(s string) {
+	if s == "" {
+		return
+	}
+	if hw.rawText != "" {
+		hw.rawTextNode(s)
+		return
+	}
+	if hw.lastText {
+		hw.write("<!---->")
+	}
+	hw.text(s)
+	hw.lastText = true
+}
+
+func (hw *htmlWriter) node(n *VNode) {
+	if n == nil {
+		return
+	}
+
+	if n.Tag == "#text" {
+		hw.textNode(n.Content)
+		return
+	}
+
+	if n.Tag == "#fragment" {
+		hw.children(n.Children)
+		return
+	}
+
+	hw.write("<" + n.Tag)
+	hw.attributes(n)
+	hw.write(">")
+	hw.lastText = false
+
+	if n.Namespace == "" {
+		if voidElements[n.Tag] {
+			return
+		}
+		if rawTextElements[n.Tag] {
+			hw.rawText = n.Tag
+			defer func() { hw.rawText = "" }()
+		}
+		if (n.Tag == "textarea" || n.Tag == "pre") && strings.HasPrefix(leadingText(n), "\n") {
+			hw.write("\n")
+		}
+	}
+
+	switch contentLayoutOf(n.Tag) {
+	case layoutValue:
+		// <textarea> carries its initial value as text; <input> is void and handled in attributes.
+		hw.textNode(n.Content)
+	case layoutChildrenOnly:
+		if n.Tag == "select" && n.Content != "" {
+			value := n.Content
+			hw.selected = &value
+		}
+		hw.children(n.Children)
+		hw.selected = nil
+	case layoutContentOrChildren:
+		if n.Content != "" {
+			hw.textNode(n.Content)
+		} else {
+			hw.children(n.Children)
+		}
+	default:
+		hw.textNode(n.Content)
+		hw.children(n.Children)
+	}
+
+	hw.write("</" + n.Tag + ">")
+	hw.lastText = false
+}
+
+// leadingText returns the text an element's markup starts with, if it starts with text.
+func leadingText(n *VNode) string {
+	if n.Content != "" || len(n.Children) == 0 {
+		return n.Content
+	}
+	if first := n.Children[0]; first != nil && first.Tag == "#text" {
+		return first.Content
+	}
+	return ""
+}
+
+func (hw *htmlWriter) children(children []*VNode) {
+	for _, child := range children {
+		hw.node(child)
+	}
+}
+
+func (hw *htmlWriter) attributes(n *VNode) {
+	keys := make([]string, 0, len(n.Attributes))
+	for k := range n.Attributes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := n.Attributes[key]
+		// Event handlers are attached by the client; they have no markup form. Inline
+		// handlers such as onclick="..." are never written either, whatever their value.
+		if isEventHandler(value) || isEventAttribute(strings.ToLower(key)) || !validAttributeName(key) {
+			continue
+		}
+
+		if b, ok := value.(bool); ok {
+			if b {
+				hw.write(" " + key)
+			}
+			continue
+		}
+
+		hw.write(" " + key + `="`)
+		hw.text(attributeString(value))
+		hw.write(`"`)
+	}
+
+	// The browser renderer sets a <select>'s value as a property; on the server the first
+	// matching option gets the selected attribute.
+	if n.Tag == "option" && hw.selected != nil && optionValue(n) == *hw.selected {
+		hw.selected = nil
+		if _, ok := n.Attributes["selected"]; !ok {
+			hw.write(" selected")
+		}
+	}
+
+	// The browser renderer sets an <input>'s value as a property; on the server it becomes the attribute.
+	if n.Tag == "input" && n.Content != "" {
+		if _, ok := n.Attributes["value"]; !ok {
+			hw.write(` value="`)
+			hw.text(n.Content)
+			hw.write(`"`)
+		}
+	}
+}
+
+// optionValue returns the value of an <option>: its value attribute, or else its text with
+// whitespace collapsed, as the browser computes it.
+func optionValue(n *VNode) string {
+	if value, ok := n.Attributes["value"]; ok {
+		return attributeString(value)
+	}
+	var text strings.Builder
+	text.WriteString(n.Content)
+	for _, child := range n.Children {
+		if child != nil && child.Tag == "#text" {
+			text.WriteString(child.Content)
+		}
+	}
+	return strings.Join(strings.Fields(text.String()), " ")
+}
+
+// validAttributeName rejects names that would break out of the tag when written unquoted.
+func validAttributeName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if r <= ' ' || r == '"' || r == '\'' || r == '>' || r == '/' || r == '=' || r == '<' || r == 0x7f {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,67 @@
+package vdom
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRenderHTMLString(t *testing.T) {
+	tests := []struct {
+		name string
+		node *VNode
+		want string
+	}{
+		{"nil", nil, ""},
+		{"text is escaped", Text(`<b>"Tom" & 'Jerry'</b>`), "&lt;b&gt;&#34;Tom&#34; &amp; &#39;Jerry&#39;&lt;/b&gt;"},
+		{"attribute is escaped", Div(map[string]any{"title": `a"><script>`}), `<div title="a&#34;&gt;&lt;script&gt;"></div>`},
+		{"boolean attributes", NewVNode("input", map[string]any{"disabled": true, "hidden": false, "type": "checkbox"}, nil, ""), `<input disabled type="checkbox">`},
+		{"event handlers skipped", Button("Go", map[string]any{"onClick": func() {}, "onInput": func(any) {}, "class": "btn"}), `<button class="btn">Go</button>`},
+		{"inline event handler strings skipped", Div(map[string]any{"onclick": "alert(1)", "ONLOAD": "x()", "id": "a"}), `<div id="a"></div>`},
+		{"non-string attribute", Div(map[string]any{"data-n": 3}), `<div data-n="3"></div>`},
+		{"invalid attribute name skipped", Div(map[string]any{`x" onload="y`: "z"}), `<div></div>`},
+		{"input value from content", NewVNode("input", nil, nil, "typed"), `<input value="typed">`},
+		{"textarea value from content", NewVNode("textarea", nil, nil, "a < b"), `<textarea>a &lt; b</textarea>`},
+		{"void element", NewVNode("br", nil, nil, ""), `<br>`},
+		{"paragraph content wins over children", NewVNode("p", nil, []*VNode{Text("child")}, "content"), `<p>content</p>`},
+		{"nested children", Div(nil, NewVNode("h1", nil, nil, "Hi"), nil, Text("there")), `<div><h1>Hi</h1>there</div>`},
+		{"adjacent text nodes separated", Div(nil, Text("a"), Text("b")), `<div>a<!---->b</div>`},
+		{"svg keeps attribute case", NewVNodeNS(NamespaceSVG, "svg", map[string]any{"viewBox": "0 0 4 4"}, []*VNode{NewVNodeNS(NamespaceSVG, "use", map[string]any{"xlink:href": "#a"}, nil, "")}, ""), `<svg viewBox="0 0 4 4"><use xlink:href="#a"></use></svg>`},
+		{"content then text child separated", NewVNode("li", nil, []*VNode{Text("b")}, "a"), `<li>a<!---->b</li>`},
+		{"style text is not escaped", NewVNode("style", nil, nil, `a > b { content: "&"; }`), `<style>a > b { content: "&"; }</style>`},
+		{"script children are not escaped", NewVNode("script", nil, []*VNode{Text("if (a < b) {"), Text("}")}, ""), `<script>if (a < b) {}</script>`},
+		{"svg style is escaped", NewVNodeNS(NamespaceSVG, "style", nil, nil, "a > b"), `<style>a &gt; b</style>`},
+		{"textarea keeps leading newline", NewVNode("textarea", nil, nil, "\nline"), "<textarea>\n\nline</textarea>"},
+		{"pre keeps leading newline", NewVNode("pre", nil, []*VNode{Text("\ncode")}, ""), "<pre>\n\ncode</pre>"},
+		{"select value marks the matching option", NewVNode("select", nil, []*VNode{NewVNode("option", map[string]any{"value": "a"}, nil, "A"), NewVNode("option", map[string]any{"value": "b"}, nil, "B")}, "b"), `<select><option value="a">A</option><option value="b" selected>B</option></select>`},
+		{"select value matches option text", NewVNode("select", nil, []*VNode{NewVNode("optgroup", nil, []*VNode{NewVNode("option", nil, nil, " Red "), NewVNode("option", nil, nil, "Blue")}, "")}, "Blue"), `<select><optgroup><option> Red </option><option selected>Blue</option></optgroup></select>`},
+		{"fragment writes children only", NewVNode("ul", nil, []*VNode{Fragment(NewVNode("li", nil, nil, "a"), Fragment(NewVNode("li", nil, nil, "b"))), NewVNode("li", nil, nil, "c")}, ""), `<ul><li>a</li><li>b</li><li>c</li></ul>`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RenderHTMLString(tt.node); got != tt.want {
+				t.Errorf("RenderHTMLString() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("closed") }
+
+func TestRenderHTML_ReturnsWriteError(t *testing.T) {
+	if err := RenderHTML(failingWriter{}, Div(nil)); err == nil {
+		t.Error("RenderHTML() error = nil, want write error")
+	}
+}
+
+func TestRenderHTML_RejectsRawTextClosingTag(t *testing.T) {
+	for _, tag := range []string{"script", "style"} {
+		n := NewVNode(tag, nil, nil, "x</"+strings.ToUpper(tag)+"><b>")
+		if err := RenderHTML(&strings.Builder{}, n); err == nil {
+			t.Errorf("RenderHTML(<%s>) error = nil, want closing tag error", tag)
+		}
+	}
+}
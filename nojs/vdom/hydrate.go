@@ -0,0 +1,211 @@
+package vdom
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Hydrate adopts server-rendered markup under the mount element instead of rebuilding it.
+// It walks the existing DOM nodes against the VNode tree, attaches event listeners and
+// repairs any node that does not match, so the DOM ends up exactly as createElement would
+// have built it and later Patch calls work unchanged.
+//
+// It returns a description of every mismatch it repaired. hydrated is false when the
+// mount element is missing or empty; the caller should then render from scratch.
+func Hydrate(selector string, n *VNode) (mismatches []string, hydrated bool) {
+	if n == nil || selector == "" {
+		return nil, false
+	}
+
+	mount := querySelector(selector)
+	if mount == nil || document.FirstChild(mount) == nil {
+		return nil, false
+	}
+
+	h := &hydrator{}
+	h.children(mount, []*VNode{n}, selector, true)
+	return h.mismatches, true
+}
+
+// hydrator collects mismatches while walking the DOM.
+type hydrator struct {
+	mismatches []string
+	selected   string // Value of the <select> being hydrated, whose option RenderHTML marked selected
+}
+
+func (h *hydrator) mismatch(path, format string, args ...any) {
+	h.mismatches = append(h.mismatches, path+": "+fmt.Sprintf(format, args...))
+}
+
+// children matches the DOM children of parent against the expected VNodes, with fragments
+// expanded into their children. Comment nodes (RenderHTML separates adjacent text nodes
+// with <!---->) are removed so DOM indexes line up with the VNode children. At the mount
+// level, whitespace-only text from the page shell is dropped silently as well.
+func (h *hydrator) children(parent Node, expected []*VNode, path string, isMount bool) {
+	expected = flattenFragments(expected)
+
+	var existing []Node
+	for i := 0; ; i++ {
+		child := document.ChildAt(parent, i)
+		if child == nil {
+			break
+		}
+		existing = append(existing, child)
+	}
+
+	pos := 0
+	for _, node := range existing {
+		name := document.NodeName(node)
+		if name == "#comment" {
+			document.RemoveChild(parent, node)
+			continue
+		}
+		if isMount && name == "#text" {
+			if text, _ := document.GetProperty(node, "textContent").(string); strings.TrimSpace(text) == "" {
+				document.RemoveChild(parent, node)
+				continue
+			}
+		}
+		existing[pos] = node
+		pos++
+	}
+	existing = existing[:pos]
+
+	i := 0
+	for _, v := range expected {
+		if !hasDOMNode(v) {
+			continue
+		}
+		childPath := path + " > " + v.Tag
+		if i >= len(existing) {
+			h.mismatch(childPath, "missing in server markup")
+			if el := createElement(v); el != nil {
+				document.InsertBefore(parent, el, nil)
+			}
+			continue
+		}
+		h.node(parent, existing[i], v, childPath)
+		i++
+	}
+
+	for ; i < len(existing); i++ {
+		h.mismatch(path, "unexpected %s in server markup", document.NodeName(existing[i]))
+		document.RemoveChild(parent, existing[i])
+	}
+}
+
+// node adopts a single DOM node for v, replacing it when the node type or tag differs.
+func (h *hydrator) node(parent, node Node, v *VNode, path string) {
+	name := document.NodeName(node)
+
+	if v.Tag == "#text" {
+		if name != "#text" {
+			h.mismatch(path, "expected text, found <%s>", name)
+			document.ReplaceChild(parent, createElement(v), node)
+			return
+		}
+		if text, _ := document.GetProperty(node, "textContent").(string); text != v.Content {
+			h.mismatch(path, "text %q differs from server %q", v.Content, text)
+			document.SetProperty(node, "textContent", v.Content)
+		}
+		return
+	}
+
+	if name != v.Tag {
+		h.mismatch(path, "expected <%s>, found %s", v.Tag, name)
+		document.ReplaceChild(parent, createElement(v), node)
+		return
+	}
+
+	h.attributes(node, v, path)
+	attachEventListeners(node, v, v.Attributes)
+	attachOnClick(node, v)
+	inheritNamespace(v)
+
+	switch contentLayoutOf(v.Tag) {
+	case layoutValue:
+		// Keep whatever the user typed before the WASM module loaded.
+	case layoutChildrenOnly:
+		if v.Tag == "select" {
+			h.selected = v.Content
+		}
+		h.children(node, v.Children, path, false)
+		// A <select>'s value is a property, set once its options are in place
+		if v.Tag == "select" {
+			h.selected = ""
+			if v.Content != "" {
+				document.SetProperty(node, "value", v.Content)
+			}
+		}
+	case layoutContentOrChildren:
+		if v.Content != "" {
+			h.children(node, contentChildren(v.Content, nil), path, false)
+		} else {
+			h.children(node, v.Children, path, false)
+		}
+	default:
+		h.children(node, contentChildren(v.Content, v.Children), path, false)
+	}
+}
+
+// attributes checks every renderable attribute and repairs the ones that differ, then
+// removes the attributes the server rendered that the VNode does not have.
+func (h *hydrator) attributes(node Node, v *VNode, path string) {
+	for key, value := range v.Attributes {
+		if isEventHandler(value) {
+			continue
+		}
+
+		actual, present := document.GetAttribute(node, key)
+		if b, ok := value.(bool); ok {
+			if b != present {
+				h.mismatch(path, "boolean attribute %s should be %t", key, b)
+				if b {
+					setAttribute(node, key, "")
+				} else {
+					removeAttribute(node, key)
+				}
+			}
+			continue
+		}
+
+		expected := attributeString(value)
+		if !present && isEventAttribute(strings.ToLower(key)) {
+			// RenderHTML never writes inline handlers; the client adds them as createElement does
+			setAttribute(node, key, expected)
+			continue
+		}
+		if !present || actual != expected {
+			h.mismatch(path, "attribute %s=%q differs from server %q", key, expected, actual)
+			setAttribute(node, key, expected)
+		}
+	}
+
+	extra := document.AttributeNames(node)
+	sort.Strings(extra)
+	for _, key := range extra {
+		if value, ok := v.Attributes[key]; ok && !isEventHandler(value) {
+			continue
+		}
+		// RenderHTML writes an <input>'s Content as its value attribute and marks the
+		// <option> matching its <select>'s Content as selected
+		if key == "value" && v.Tag == "input" && v.Content != "" {
+			continue
+		}
+		if key == "selected" && v.Tag == "option" && h.selected != "" && optionValue(v) == h.selected {
+			continue
+		}
+		h.mismatch(path, "attribute %s is not rendered by the client", key)
+		removeAttribute(node, key)
+	}
+}
+
+// contentChildren returns the VNodes createElement produces for an element's text content
+// followed by its children.
+func contentChildren(content string, children []*VNode) []*VNode {
+	if content == "" {
+		return children
+	}
+	return append([]*VNode{Text(content)}, children...)
+}
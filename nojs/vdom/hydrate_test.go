@@ -0,0 +1,198 @@
+package vdom
+
+import (
+	"testing"
+)
+
+// serverPage builds the same tree the server rendered; each call returns fresh VNodes.
+func serverPage(title string, onClick func()) *VNode {
+	return Div(map[string]any{"class": "page"},
+		NewVNode("h1", nil, nil, title),
+		Button("Add", map[string]any{"onClick": onClick}),
+		NewVNode("ul", nil, []*VNode{
+			NewVNode("li", nil, nil, "one"),
+			NewVNode("li", nil, nil, "two"),
+		}, ""),
+	)
+}
+
+func TestHydrate_MatchingMarkup_AdoptsNodesAndAttachesListeners(t *testing.T) {
+	// Arrange
+	dom, mount := setupMemoryDOM(t)
+	RenderToSelector("#app", serverPage("Home", func() {}))
+	serverRoot := mount.Children[0]
+	dom.ResetMutations()
+
+	clicks := 0
+	clientTree := serverPage("Home", func() { clicks++ })
+
+	// Act
+	mismatches, hydrated := Hydrate("#app", clientTree)
+
+	// Assert
+	if !hydrated {
+		t.Fatal("Hydrate() hydrated = false, want true")
+	}
+	if len(mismatches) != 0 {
+		t.Errorf("mismatches = %v, want none", mismatches)
+	}
+	for _, m := range dom.Mutations {
+		if m.Op != OpAddEventListener {
+			t.Errorf("unexpected mutation during hydration: %v", m)
+		}
+	}
+	if mount.Children[0] != serverRoot {
+		t.Error("root element was not adopted")
+	}
+	dom.Dispatch(serverRoot.Children[1], "click", nil)
+	if clicks != 1 {
+		t.Errorf("clicks = %d, want 1", clicks)
+	}
+}
+
+func TestHydrate_ThenPatch_UpdatesAdoptedNodes(t *testing.T) {
+	// Arrange
+	_, mount := setupMemoryDOM(t)
+	RenderToSelector("#app", serverPage("Home", func() {}))
+	clientTree := serverPage("Home", func() {})
+	Hydrate("#app", clientTree)
+
+	// Act
+	Patch("#app", clientTree, serverPage("Updated", func() {}))
+
+	// Assert
+	if got := mount.Children[0].Children[0].TextContent(); got != "Updated" {
+		t.Errorf("h1 = %q, want %q", got, "Updated")
+	}
+}
+
+func TestHydrate_Mismatch_RepairsAndReports(t *testing.T) {
+	// Arrange
+	_, mount := setupMemoryDOM(t)
+	RenderToSelector("#app", Div(map[string]any{"class": "old"},
+		NewVNode("span", nil, nil, "x"),
+		Paragraph("extra", nil),
+	))
+
+	// Act
+	mismatches, _ := Hydrate("#app", Div(map[string]any{"class": "new"},
+		NewVNode("em", nil, nil, "x"),
+	))
+
+	// Assert
+	if len(mismatches) != 3 {
+		t.Errorf("mismatches = %v, want 3 (attribute, tag, extra node)", mismatches)
+	}
+	want := `<div id="app"><div class="new"><em>x</em></div></div>`
+	if got := mount.OuterHTML(); got != want {
+		t.Errorf("OuterHTML = %s, want %s", got, want)
+	}
+}
+
+func TestHydrate_ExtraServerAttributes_RemovedAndReported(t *testing.T) {
+	// Arrange
+	_, mount := setupMemoryDOM(t)
+	RenderToSelector("#app", Button("Save", map[string]any{"class": "busy", "disabled": true, "type": "submit"}))
+
+	// Act
+	mismatches, _ := Hydrate("#app", Button("Save", map[string]any{"type": "submit", "onClick": func() {}}))
+
+	// Assert
+	if len(mismatches) != 2 {
+		t.Errorf("mismatches = %v, want 2 (class, disabled)", mismatches)
+	}
+	want := `<div id="app"><button type="submit">Save</button></div>`
+	if got := mount.OuterHTML(); got != want {
+		t.Errorf("OuterHTML = %s, want %s", got, want)
+	}
+}
+
+func TestHydrate_InputValueFromContent_IsKept(t *testing.T) {
+	// Arrange
+	// The server writes an input's Content as its value attribute
+	setupMemoryDOM(t)
+	RenderToSelector("#app", NewVNode("input", map[string]any{"value": "typed"}, nil, ""))
+
+	// Act
+	mismatches, _ := Hydrate("#app", NewVNode("input", nil, nil, "typed"))
+
+	// Assert
+	if len(mismatches) != 0 {
+		t.Errorf("mismatches = %v, want none", mismatches)
+	}
+}
+
+func TestHydrate_InlineHandlerAttribute_AddedWithoutMismatch(t *testing.T) {
+	// Arrange
+	// RenderHTML never writes onclick="...", so the server markup lacks it
+	_, mount := setupMemoryDOM(t)
+	RenderToSelector("#app", Button("Go", nil))
+
+	// Act
+	mismatches, _ := Hydrate("#app", Button("Go", map[string]any{"onclick": "track()"}))
+
+	// Assert
+	if len(mismatches) != 0 {
+		t.Errorf("mismatches = %v, want none", mismatches)
+	}
+	want := `<div id="app"><button onclick="track()">Go</button></div>`
+	if got := mount.OuterHTML(); got != want {
+		t.Errorf("OuterHTML = %s, want %s", got, want)
+	}
+}
+
+// colorSelect builds a <select> bound to value; the server markup marks the matching option instead.
+func colorSelect(value string, selectedAttr bool) *VNode {
+	blue := map[string]any{"value": "blue"}
+	if selectedAttr {
+		blue["selected"] = true
+	}
+	return NewVNode("select", nil, []*VNode{
+		NewVNode("option", map[string]any{"value": "red"}, nil, "Red"),
+		NewVNode("option", blue, nil, "Blue"),
+	}, value)
+}
+
+func TestHydrate_BoundSelect_SetsValueWithoutMismatch(t *testing.T) {
+	// Arrange
+	_, mount := setupMemoryDOM(t)
+	RenderToSelector("#app", colorSelect("", true))
+
+	// Act
+	mismatches, _ := Hydrate("#app", colorSelect("blue", false))
+
+	// Assert
+	if len(mismatches) != 0 {
+		t.Errorf("mismatches = %v, want none", mismatches)
+	}
+	if got := mount.Children[0].Properties["value"]; got != "blue" {
+		t.Errorf("select value = %v, want blue", got)
+	}
+}
+
+func TestHydrate_EmptyMount_ReportsNotHydrated(t *testing.T) {
+	setupMemoryDOM(t)
+
+	if _, hydrated := Hydrate("#app", Div(nil)); hydrated {
+		t.Error("Hydrate() hydrated = true for an empty mount, want false")
+	}
+}
+
+func TestHydrate_FragmentRoot_AdoptsEverySibling(t *testing.T) {
+	// Arrange
+	_, mount := setupMemoryDOM(t)
+	page := func() *VNode { return Fragment(NewVNode("h1", nil, nil, "Home"), Paragraph("intro", nil)) }
+	RenderToSelector("#app", page())
+	serverNodes := append([]*MemoryNode(nil), mount.Children...)
+
+	// Act
+	mismatches, hydrated := Hydrate("#app", page())
+
+	// Assert
+	if !hydrated || len(mismatches) != 0 {
+		t.Fatalf("Hydrate() = %v, %t, want no mismatches", mismatches, hydrated)
+	}
+	if len(mount.Children) != 2 || mount.Children[0] != serverNodes[0] || mount.Children[1] != serverNodes[1] {
+		t.Error("fragment children were not adopted")
+	}
+}
@@ -0,0 +1,57 @@
+package vdom
+
+// longestIncreasingSubsequence returns the positions (indexes into seq) of one
+// longest strictly increasing subsequence of seq. Entries equal to -1 are treated
+// as holes and never take part in the subsequence.
+//
+// Keyed reconciliation uses it to find the largest set of matched children whose
+// relative DOM order is already correct; only the remaining nodes need to move.
+// This file has NO build tags so the algorithm can be unit tested natively.
+func longestIncreasingSubsequence(seq []int) []int {
+	// tails[k] holds the position in seq of the smallest tail of any increasing
+	// subsequence of length k+1 found so far.
+	tails := make([]int, 0, len(seq))
+	// prev[i] links position i to its predecessor in the subsequence ending at i.
+	prev := make([]int, len(seq))
+
+	for i, v := range seq {
+		if v < 0 {
+			continue
+		}
+
+		// Binary search for the first tail whose value is >= v.
+		lo, hi := 0, len(tails)
+		for lo < hi {
+			mid := (lo + hi) / 2
+			if seq[tails[mid]] < v {
+				lo = mid + 1
+			} else {
+				hi = mid
+			}
+		}
+
+		if lo > 0 {
+			prev[i] = tails[lo-1]
+		} else {
+			prev[i] = -1
+		}
+
+		if lo == len(tails) {
+			tails = append(tails, i)
+		} else {
+			tails[lo] = i
+		}
+	}
+
+	// Walk the predecessor links back from the last tail.
+	result := make([]int, len(tails))
+	if len(tails) == 0 {
+		return result
+	}
+	k := tails[len(tails)-1]
+	for idx := len(tails) - 1; idx >= 0; idx-- {
+		result[idx] = k
+		k = prev[k]
+	}
+	return result
+}
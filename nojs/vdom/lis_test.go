@@ -0,0 +1,36 @@
+package vdom
+
+import "testing"
+
+// TestLongestIncreasingSubsequence verifies the positions returned for a range of
+// sequences, including holes (-1) that represent newly created children.
+func TestLongestIncreasingSubsequence(t *testing.T) {
+	tests := []struct {
+		name     string
+		seq      []int
+		expected []int
+	}{
+		{name: "empty", seq: nil, expected: []int{}},
+		{name: "already ordered", seq: []int{0, 1, 2, 3}, expected: []int{0, 1, 2, 3}},
+		{name: "reversed", seq: []int{3, 2, 1, 0}, expected: []int{3}},
+		{name: "insert at top", seq: []int{-1, 0, 1, 2}, expected: []int{1, 2, 3}},
+		{name: "move last to first", seq: []int{3, 0, 1, 2}, expected: []int{1, 2, 3}},
+		{name: "swap two", seq: []int{0, 2, 1, 3}, expected: []int{0, 2, 3}},
+		{name: "only holes", seq: []int{-1, -1}, expected: []int{}},
+		{name: "mixed", seq: []int{4, -1, 1, 5, 2, 3}, expected: []int{2, 4, 5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := longestIncreasingSubsequence(tt.seq)
+			if len(got) != len(tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, got)
+			}
+			for i := range got {
+				if got[i] != tt.expected[i] {
+					t.Fatalf("expected %v, got %v", tt.expected, got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,465 @@
+package vdom
+
+import (
+	"fmt"
+	"html"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// MutationOp identifies the kind of operation recorded by MemoryDOM.
+type MutationOp string
+
+const (
+	OpCreateElement       MutationOp = "createElement"
+	OpCreateText          MutationOp = "createText"
+	OpSetAttribute        MutationOp = "setAttribute"
+	OpRemoveAttribute     MutationOp = "removeAttribute"
+	OpSetProperty         MutationOp = "setProperty"
+	OpInsertBefore        MutationOp = "insertBefore"
+	OpRemoveChild         MutationOp = "removeChild"
+	OpReplaceChild        MutationOp = "replaceChild"
+	OpAddEventListener    MutationOp = "addEventListener"
+	OpRemoveEventListener MutationOp = "removeEventListener"
+)
+
+// Mutation is a single DOM operation recorded by MemoryDOM.
+type Mutation struct {
+	Op     MutationOp
+	Target *MemoryNode // The node operated on (the parent for insert/remove/replace)
+	Node   *MemoryNode // The inserted, removed or replacing child
+	Ref    *MemoryNode // The insertBefore reference node, or the replaced child
+	Name   string      // Tag, attribute, property or event name
+	Value  any         // Attribute or property value, or the namespace of a created element
+}
+
+// String renders the mutation in a compact, human-readable form for test failure messages.
+func (m Mutation) String() string {
+	switch m.Op {
+	case OpCreateElement, OpCreateText:
+		return fmt.Sprintf("%s %s", m.Op, m.Node.label())
+	case OpSetAttribute, OpSetProperty:
+		return fmt.Sprintf("%s %s %s=%q", m.Op, m.Target.label(), m.Name, fmt.Sprint(m.Value))
+	case OpRemoveAttribute, OpAddEventListener, OpRemoveEventListener:
+		return fmt.Sprintf("%s %s %s", m.Op, m.Target.label(), m.Name)
+	case OpInsertBefore:
+		if m.Ref == nil {
+			return fmt.Sprintf("%s %s %s at end", m.Op, m.Target.label(), m.Node.label())
+		}
+		return fmt.Sprintf("%s %s %s before %s", m.Op, m.Target.label(), m.Node.label(), m.Ref.label())
+	case OpReplaceChild:
+		return fmt.Sprintf("%s %s %s with %s", m.Op, m.Target.label(), m.Ref.label(), m.Node.label())
+	default:
+		return fmt.Sprintf("%s %s %s", m.Op, m.Target.label(), m.Node.label())
+	}
+}
+
+// MemoryNode is a node in a MemoryDOM tree.
+type MemoryNode struct {
+	Tag        string            // Element tag name, or "#text" for text nodes
+	Namespace  string            // Namespace URI of an SVG or MathML element; empty for HTML
+	Text       string            // Text content of a "#text" node
+	Attributes map[string]string // Attributes of an element, namespaced ones by qualified name ("xlink:href")
+	Properties map[string]any    // DOM properties set via SetProperty (e.g. "value")
+	Parent     *MemoryNode
+	Children   []*MemoryNode
+
+	listeners map[string][]*memoryListener
+}
+
+// memoryListener is a registered event handler. It is compared by pointer so
+// removing one listener never removes another registration of the same handler.
+type memoryListener struct {
+	handler any
+	once    bool
+}
+
+// label identifies a node in mutation strings: <li#id> for elements, "text" for text nodes.
+func (n *MemoryNode) label() string {
+	if n == nil {
+		return "<nil>"
+	}
+	if n.Tag == "#text" {
+		return fmt.Sprintf("%q", n.Text)
+	}
+	if id, ok := n.Attributes["id"]; ok {
+		return "<" + n.Tag + "#" + id + ">"
+	}
+	return "<" + n.Tag + ">"
+}
+
+// TextContent returns the concatenated text of the node and its descendants.
+func (n *MemoryNode) TextContent() string {
+	if n.Tag == "#text" {
+		return n.Text
+	}
+	var sb strings.Builder
+	for _, child := range n.Children {
+		sb.WriteString(child.TextContent())
+	}
+	return sb.String()
+}
+
+// OuterHTML serializes the node and its descendants. Attributes are sorted by name
+// so the output is deterministic.
+func (n *MemoryNode) OuterHTML() string {
+	var sb strings.Builder
+	n.writeHTML(&sb)
+	return sb.String()
+}
+
+func (n *MemoryNode) writeHTML(sb *strings.Builder) {
+	if n.Tag == "#text" {
+		sb.WriteString(html.EscapeString(n.Text))
+		return
+	}
+
+	sb.WriteString("<" + n.Tag)
+	keys := make([]string, 0, len(n.Attributes))
+	for k := range n.Attributes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(sb, ` %s="%s"`, k, html.EscapeString(n.Attributes[k]))
+	}
+	sb.WriteString(">")
+	for _, child := range n.Children {
+		child.writeHTML(sb)
+	}
+	sb.WriteString("</" + n.Tag + ">")
+}
+
+// indexOf returns the position of child in n.Children, or -1.
+func (n *MemoryNode) indexOf(child *MemoryNode) int {
+	for i, c := range n.Children {
+		if c == child {
+			return i
+		}
+	}
+	return -1
+}
+
+// detach removes the node from its current parent, if any.
+func (n *MemoryNode) detach() {
+	if n.Parent == nil {
+		return
+	}
+	if i := n.Parent.indexOf(n); i >= 0 {
+		n.Parent.Children = append(n.Parent.Children[:i], n.Parent.Children[i+1:]...)
+	}
+	n.Parent = nil
+}
+
+// MemoryDOM is a pure-Go DOM backend. It keeps a node tree in memory and records
+// every operation in Mutations, so tests can render and patch VNodes natively and
+// assert the exact DOM mutations a change produces.
+//
+//	dom := vdom.NewMemoryDOM()
+//	vdom.SetDOM(dom)
+//	dom.Mount("app")
+//	vdom.RenderToSelector("#app", oldTree)
+//	dom.ResetMutations()
+//	vdom.Patch("#app", oldTree, newTree)
+//	// inspect dom.Mutations
+type MemoryDOM struct {
+	Body      *MemoryNode // Root of the document tree
+	Mutations []Mutation  // Operations recorded since the last ResetMutations
+
+	focused *MemoryNode
+}
+
+// NewMemoryDOM returns an empty document with a <body> root.
+func NewMemoryDOM() *MemoryDOM {
+	return &MemoryDOM{Body: &MemoryNode{Tag: "body"}}
+}
+
+// Mount appends a <div id="..."> under the body without recording a mutation and returns it.
+func (d *MemoryDOM) Mount(id string) *MemoryNode {
+	mount := &MemoryNode{Tag: "div", Attributes: map[string]string{"id": id}, Parent: d.Body}
+	d.Body.Children = append(d.Body.Children, mount)
+	return mount
+}
+
+// ResetMutations clears the recorded mutation log.
+func (d *MemoryDOM) ResetMutations() {
+	d.Mutations = nil
+}
+
+// Focus gives keyboard focus to the node (nil clears focus).
+func (d *MemoryDOM) Focus(n *MemoryNode) {
+	d.focused = n
+}
+
+// Dispatch invokes every listener registered for eventName on the node.
+// Handlers taking no arguments are called directly; single-argument handlers
+// receive event when it is assignable to their parameter type.
+func (d *MemoryDOM) Dispatch(n *MemoryNode, eventName string, event any) {
+	// Copy so handlers that re-render (and detach listeners) don't disturb iteration.
+	listeners := append([]*memoryListener(nil), n.listeners[eventName]...)
+	for _, l := range listeners {
+		if l.once {
+			if fired, _ := n.Properties[onceFlag(eventName)].(bool); fired {
+				continue
+			}
+			if n.Properties == nil {
+				n.Properties = make(map[string]any)
+			}
+			n.Properties[onceFlag(eventName)] = true
+		}
+		if h, ok := l.handler.(func()); ok {
+			h()
+			continue
+		}
+		fn := reflect.ValueOf(l.handler)
+		if fn.Type().NumIn() == 0 {
+			fn.Call(nil)
+			continue
+		}
+		if fn.Type().NumIn() == 1 {
+			arg := reflect.Zero(fn.Type().In(0))
+			if event != nil && reflect.TypeOf(event).AssignableTo(fn.Type().In(0)) {
+				arg = reflect.ValueOf(event)
+			}
+			fn.Call([]reflect.Value{arg})
+		}
+	}
+}
+
+// ListenerCount returns the number of listeners registered for eventName on the node.
+func (d *MemoryDOM) ListenerCount(n *MemoryNode, eventName string) int {
+	return len(n.listeners[eventName])
+}
+
+func (d *MemoryDOM) record(m Mutation) {
+	d.Mutations = append(d.Mutations, m)
+}
+
+// memNode converts a Node to *MemoryNode (nil stays nil).
+func memNode(n Node) *MemoryNode {
+	if n == nil {
+		return nil
+	}
+	return n.(*MemoryNode)
+}
+
+// find walks the subtree rooted at n in document order and returns the first match.
+func find(n *MemoryNode, match func(*MemoryNode) bool) *MemoryNode {
+	if match(n) {
+		return n
+	}
+	for _, child := range n.Children {
+		if found := find(child, match); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// QuerySelector supports "#id", ".class" and bare tag selectors.
+func (d *MemoryDOM) QuerySelector(selector string) Node {
+	var match func(*MemoryNode) bool
+	switch {
+	case strings.HasPrefix(selector, "#"):
+		match = func(n *MemoryNode) bool { return n.Tag != "#text" && n.Attributes["id"] == selector[1:] }
+	case strings.HasPrefix(selector, "."):
+		match = func(n *MemoryNode) bool {
+			for _, c := range strings.Fields(n.Attributes["class"]) {
+				if c == selector[1:] {
+					return true
+				}
+			}
+			return false
+		}
+	default:
+		match = func(n *MemoryNode) bool { return n.Tag == selector }
+	}
+
+	if found := find(d.Body, match); found != nil {
+		return found
+	}
+	return nil
+}
+
+func (d *MemoryDOM) CreateElement(tag string) Node {
+	n := &MemoryNode{Tag: tag}
+	d.record(Mutation{Op: OpCreateElement, Node: n, Name: tag})
+	return n
+}
+
+func (d *MemoryDOM) CreateElementNS(namespace, tag string) Node {
+	n := &MemoryNode{Tag: tag, Namespace: namespace}
+	d.record(Mutation{Op: OpCreateElement, Node: n, Name: tag, Value: namespace})
+	return n
+}
+
+func (d *MemoryDOM) CreateText(content string) Node {
+	n := &MemoryNode{Tag: "#text", Text: content}
+	d.record(Mutation{Op: OpCreateText, Node: n, Value: content})
+	return n
+}
+
+func (d *MemoryDOM) SetAttribute(el Node, key, value string) {
+	n := memNode(el)
+	if n.Attributes == nil {
+		n.Attributes = make(map[string]string)
+	}
+	n.Attributes[key] = value
+	d.record(Mutation{Op: OpSetAttribute, Target: n, Name: key, Value: value})
+}
+
+func (d *MemoryDOM) SetAttributeNS(el Node, namespace, key, value string) {
+	d.SetAttribute(el, key, value)
+}
+
+func (d *MemoryDOM) GetAttribute(el Node, key string) (string, bool) {
+	value, ok := memNode(el).Attributes[key]
+	return value, ok
+}
+
+func (d *MemoryDOM) AttributeNames(el Node) []string {
+	n := memNode(el)
+	names := make([]string, 0, len(n.Attributes))
+	for name := range n.Attributes {
+		names = append(names, name)
+	}
+	return names
+}
+
+func (d *MemoryDOM) RemoveAttribute(el Node, key string) {
+	n := memNode(el)
+	delete(n.Attributes, key)
+	d.record(Mutation{Op: OpRemoveAttribute, Target: n, Name: key})
+}
+
+func (d *MemoryDOM) RemoveAttributeNS(el Node, namespace, key string) {
+	d.RemoveAttribute(el, key)
+}
+
+// SetProperty stores the property. "textContent" behaves like the browser: on a text
+// node it replaces the text, on an element it replaces all children with one text node.
+func (d *MemoryDOM) SetProperty(el Node, key string, value any) {
+	n := memNode(el)
+	d.record(Mutation{Op: OpSetProperty, Target: n, Name: key, Value: value})
+
+	if key == "textContent" {
+		text := fmt.Sprint(value)
+		if n.Tag == "#text" {
+			n.Text = text
+			return
+		}
+		for _, child := range n.Children {
+			child.Parent = nil
+		}
+		n.Children = nil
+		if text != "" {
+			n.Children = []*MemoryNode{{Tag: "#text", Text: text, Parent: n}}
+		}
+		return
+	}
+
+	if n.Properties == nil {
+		n.Properties = make(map[string]any)
+	}
+	n.Properties[key] = value
+}
+
+func (d *MemoryDOM) GetProperty(el Node, key string) any {
+	n := memNode(el)
+	if key == "textContent" {
+		return n.TextContent()
+	}
+	return n.Properties[key]
+}
+
+func (d *MemoryDOM) InsertBefore(parent, child, ref Node) {
+	p, c, r := memNode(parent), memNode(child), memNode(ref)
+	if r != nil && r.Parent != p {
+		panic(fmt.Sprintf("vdom: insertBefore reference %s is not a child of %s", r.label(), p.label()))
+	}
+	d.record(Mutation{Op: OpInsertBefore, Target: p, Node: c, Ref: r})
+
+	c.detach()
+	c.Parent = p
+	if r == nil {
+		p.Children = append(p.Children, c)
+		return
+	}
+	i := p.indexOf(r)
+	p.Children = append(p.Children[:i], append([]*MemoryNode{c}, p.Children[i:]...)...)
+}
+
+func (d *MemoryDOM) RemoveChild(parent, child Node) {
+	p, c := memNode(parent), memNode(child)
+	if c.Parent != p {
+		panic(fmt.Sprintf("vdom: removeChild %s is not a child of %s", c.label(), p.label()))
+	}
+	d.record(Mutation{Op: OpRemoveChild, Target: p, Node: c})
+	c.detach()
+}
+
+func (d *MemoryDOM) ReplaceChild(parent, newChild, oldChild Node) {
+	p, nc, oc := memNode(parent), memNode(newChild), memNode(oldChild)
+	if oc.Parent != p {
+		panic(fmt.Sprintf("vdom: replaceChild %s is not a child of %s", oc.label(), p.label()))
+	}
+	d.record(Mutation{Op: OpReplaceChild, Target: p, Node: nc, Ref: oc})
+
+	nc.detach()
+	i := p.indexOf(oc)
+	p.Children[i] = nc
+	nc.Parent = p
+	oc.Parent = nil
+}
+
+func (d *MemoryDOM) FirstChild(parent Node) Node {
+	return d.ChildAt(parent, 0)
+}
+
+func (d *MemoryDOM) ChildAt(parent Node, index int) Node {
+	p := memNode(parent)
+	if index < 0 || index >= len(p.Children) {
+		return nil
+	}
+	return p.Children[index]
+}
+
+func (d *MemoryDOM) NodeName(node Node) string {
+	return memNode(node).Tag
+}
+
+func (d *MemoryDOM) ParentNode(node Node) Node {
+	if p := memNode(node).Parent; p != nil {
+		return p
+	}
+	return nil
+}
+
+func (d *MemoryDOM) HasFocus(el Node) bool {
+	return d.focused != nil && d.focused == memNode(el)
+}
+
+func (d *MemoryDOM) AddEventListener(el Node, eventName string, handler any) func() {
+	n := memNode(el)
+	if n.listeners == nil {
+		n.listeners = make(map[string][]*memoryListener)
+	}
+	l := &memoryListener{handler: handler}
+	if listener, ok := handler.(EventListener); ok {
+		l = &memoryListener{handler: listener.Handler, once: listener.Once}
+	}
+	n.listeners[eventName] = append(n.listeners[eventName], l)
+	d.record(Mutation{Op: OpAddEventListener, Target: n, Name: eventName})
+
+	return func() {
+		list := n.listeners[eventName]
+		for i, registered := range list {
+			if registered == l {
+				n.listeners[eventName] = append(list[:i], list[i+1:]...)
+				break
+			}
+		}
+		d.record(Mutation{Op: OpRemoveEventListener, Target: n, Name: eventName})
+	}
+}
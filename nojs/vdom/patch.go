@@ -0,0 +1,777 @@
+package vdom
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/ForgeLogic/nojs/console"
+)
+
+// document is the DOM backend used by Clear, RenderToSelector and Patch.
+// The WASM build installs the browser backend at init; native code (tests, tools)
+// installs a MemoryDOM with SetDOM.
+var document DOM
+
+// SetDOM replaces the DOM backend used by the renderer.
+func SetDOM(d DOM) {
+	document = d
+}
+
+// CurrentDOM returns the DOM backend used by the renderer, or nil if none is installed.
+func CurrentDOM() DOM {
+	return document
+}
+
+// releaseCallbacks detaches all event listeners stored in a VNode.
+func releaseCallbacks(v *VNode) {
+	if v == nil {
+		return
+	}
+
+	for _, cb := range v.GetEventCallbacks() {
+		if remove, ok := cb.(func()); ok && remove != nil {
+			remove()
+		}
+	}
+	v.ClearEventCallbacks()
+}
+
+// deepReleaseCallbacks recursively releases all callbacks in the entire VNode tree.
+func deepReleaseCallbacks(v *VNode) {
+	if v == nil {
+		return
+	}
+
+	releaseCallbacks(v)
+
+	for _, child := range v.Children {
+		deepReleaseCallbacks(child)
+	}
+}
+
+// querySelector resolves a mount selector against the active backend, logging when it is missing.
+func querySelector(selector string) Node {
+	if document == nil {
+		return nil
+	}
+
+	mount := document.QuerySelector(selector)
+	if mount == nil {
+		console.Error("Mount element not found for selector:", selector)
+	}
+	return mount
+}
+
+// Clear releases the callbacks of prevVDOM and removes every child of the mount element.
+func Clear(selector string, prevVDOM *VNode) {
+	if selector == "" {
+		return
+	}
+
+	// Release all callbacks in the previous VDOM tree
+	if prevVDOM != nil {
+		deepReleaseCallbacks(prevVDOM)
+	}
+
+	mount := querySelector(selector)
+	if mount == nil {
+		return
+	}
+
+	// Setting textContent to an empty string removes all children.
+	document.SetProperty(mount, "textContent", "")
+}
+
+// RenderToSelector mounts the VNode under the first element matching the CSS selector.
+func RenderToSelector(selector string, n *VNode) {
+	if n == nil || selector == "" {
+		return
+	}
+
+	mount := querySelector(selector)
+	if mount == nil {
+		return
+	}
+
+	mountNode(mount, n)
+}
+
+// mountNode appends the rendered node to a specific mount element. A fragment appends
+// each of its children.
+func mountNode(mount Node, n *VNode) {
+	if n == nil {
+		return
+	}
+
+	appendChildren(mount, []*VNode{n})
+}
+
+// isEventAttribute reports whether an attribute key names an event handler (onClick, onInput, ...).
+func isEventAttribute(key string) bool {
+	return len(key) > 2 && key[0] == 'o' && key[1] == 'n'
+}
+
+// isEventHandler reports whether an attribute value is a handler function (or an EventListener)
+// rather than a renderable value.
+func isEventHandler(value any) bool {
+	if _, ok := value.(EventListener); ok {
+		return true
+	}
+	return value != nil && reflect.TypeOf(value).Kind() == reflect.Func
+}
+
+// eventNameFromAttribute converts "onClick" -> "click", "onInput" -> "input", etc.
+func eventNameFromAttribute(key string) string {
+	eventName := key[2:]
+	if eventName[0] >= 'A' && eventName[0] <= 'Z' {
+		eventName = string(eventName[0]+('a'-'A')) + eventName[1:]
+	}
+	return eventName
+}
+
+// setAttributeValue sets an attribute on an element, handling boolean attributes and event handlers correctly.
+func setAttributeValue(el Node, key string, value any) {
+	// Handle boolean attributes
+	if boolVal, ok := value.(bool); ok {
+		if boolVal {
+			// For boolean attributes, set them without a value (or with empty string)
+			setAttribute(el, key, "")
+		}
+		// If false, don't set the attribute at all
+		return
+	}
+
+	// Event handlers are attached via addEventListener, not setAttribute
+	if isEventHandler(value) {
+		return
+	}
+
+	// For all other types, convert to string and set normally
+	setAttribute(el, key, attributeString(value))
+}
+
+// attributeNamespace returns the namespace of a prefixed attribute such as xlink:href,
+// or "" for attributes without a namespace.
+func attributeNamespace(key string) string {
+	prefix, _, ok := strings.Cut(key, ":")
+	if !ok {
+		return ""
+	}
+	switch prefix {
+	case "xlink":
+		return NamespaceXLink
+	case "xml":
+		return NamespaceXML
+	case "xmlns":
+		return NamespaceXMLNS
+	}
+	return ""
+}
+
+// setAttribute sets an attribute, using setAttributeNS for namespaced attributes.
+func setAttribute(el Node, key, value string) {
+	if ns := attributeNamespace(key); ns != "" {
+		document.SetAttributeNS(el, ns, key, value)
+		return
+	}
+	document.SetAttribute(el, key, value)
+}
+
+// removeAttribute removes an attribute, using removeAttributeNS for namespaced attributes.
+func removeAttribute(el Node, key string) {
+	if ns := attributeNamespace(key); ns != "" {
+		document.RemoveAttributeNS(el, ns, key)
+		return
+	}
+	document.RemoveAttribute(el, key)
+}
+
+// attributeString converts a non-boolean attribute value to its HTML string form.
+func attributeString(value any) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	return fmt.Sprint(value)
+}
+
+// attachEventListeners processes attributes and attaches event listeners for event handlers.
+// Event attributes start with "on" (e.g., onClick, onInput, onMousedown).
+// The VNode parameter stores the listener release functions for later cleanup.
+func attachEventListeners(el Node, vnode *VNode, attributes map[string]any) {
+	if attributes == nil {
+		return
+	}
+
+	for key, value := range attributes {
+		if !isEventAttribute(key) || !isEventHandler(value) {
+			continue
+		}
+
+		remove := document.AddEventListener(el, eventNameFromAttribute(key), value)
+
+		// Store the release function in VNode for later cleanup
+		if vnode != nil && remove != nil {
+			vnode.AddEventCallback(remove)
+		}
+	}
+}
+
+// contentLayout describes how an element's Content and Children are emitted.
+// createElement and RenderHTML share it so server and browser output match.
+type contentLayout int
+
+const (
+	layoutContentAndChildren contentLayout = iota // Content as text, then Children (generic fallback)
+	layoutContentOrChildren                       // Content wins over Children (p, button)
+	layoutChildrenOnly                            // Only children; a <select> carries its selected value in Content
+	layoutValue                                   // Form controls carry their value in Content (input, textarea)
+)
+
+// contentLayoutOf returns the content layout for a tag. All other elements use the
+// generic layout; compiled templates set either Content (text-only elements) or Children.
+func contentLayoutOf(tag string) contentLayout {
+	switch tag {
+	case "input", "textarea":
+		return layoutValue
+	case "select":
+		return layoutChildrenOnly
+	case "p", "button":
+		return layoutContentOrChildren
+	default:
+		return layoutContentAndChildren
+	}
+}
+
+// createElement builds the DOM subtree for a VNode and returns its root node.
+// Empty text VNodes and nil VNodes produce no node (nil). Fragments have no node of their
+// own either; callers expand them with flattenFragments first.
+func createElement(n *VNode) Node {
+	if document == nil || n == nil || n.Tag == "#fragment" {
+		return nil
+	}
+
+	if n.Tag == "#text" {
+		// Pure text node - no HTML element wrapper
+		if n.Content == "" {
+			console.Log("[DEBUG] Text node with empty content, returning undefined")
+			return nil
+		}
+		return document.CreateText(n.Content)
+	}
+
+	var el Node
+	if n.Namespace != "" {
+		el = document.CreateElementNS(n.Namespace, n.Tag)
+	} else {
+		el = document.CreateElement(n.Tag)
+	}
+	inheritNamespace(n)
+
+	if n.Attributes != nil {
+		for k, v := range n.Attributes {
+			setAttributeValue(el, k, v)
+		}
+		attachEventListeners(el, n, n.Attributes)
+	}
+
+	switch contentLayoutOf(n.Tag) {
+	case layoutValue:
+		if n.Content != "" {
+			document.SetProperty(el, "value", n.Content)
+		}
+	case layoutChildrenOnly:
+		appendChildren(el, n.Children)
+		// A <select> carries its selected value in Content, applied once its options exist
+		if n.Tag == "select" && n.Content != "" {
+			document.SetProperty(el, "value", n.Content)
+		}
+	case layoutContentOrChildren:
+		if n.Content != "" {
+			document.SetProperty(el, "textContent", n.Content)
+		} else {
+			appendChildren(el, n.Children)
+		}
+	default:
+		if n.Content != "" {
+			document.SetProperty(el, "textContent", n.Content)
+		}
+		appendChildren(el, n.Children)
+	}
+
+	attachOnClick(el, n)
+
+	return el
+}
+
+// inheritNamespace gives the element children of n that have no namespace the namespace of
+// n, so a child component rendering <path> inside an <svg> creates an SVG element. Children
+// of an SVG <foreignObject> are HTML and keep the empty namespace.
+func inheritNamespace(n *VNode) {
+	if n.Namespace == "" || (n.Tag == "foreignObject" && n.Namespace == NamespaceSVG) {
+		return
+	}
+	for _, child := range flattenFragments(n.Children) {
+		if child != nil && child.Namespace == "" && child.Tag != "#text" {
+			child.Namespace = n.Namespace
+		}
+	}
+}
+
+// attachOnClick attaches the Go OnClick handler of a button if present (legacy support).
+func attachOnClick(el Node, n *VNode) {
+	if n.Tag != "button" || n.OnClick == nil {
+		return
+	}
+	if remove := document.AddEventListener(el, "click", n.OnClick); remove != nil {
+		n.AddEventCallback(remove)
+	}
+}
+
+// appendChildren creates and appends the DOM nodes for each child VNode.
+func appendChildren(el Node, children []*VNode) {
+	for _, child := range flattenFragments(children) {
+		if childEl := createElement(child); childEl != nil {
+			document.InsertBefore(el, childEl, nil)
+		}
+	}
+}
+
+// Patch updates the DOM by comparing old and new VDOM trees and applying minimal changes.
+func Patch(mountSelector string, oldVNode, newVNode *VNode) {
+	if oldVNode == nil || newVNode == nil {
+		return
+	}
+
+	mount := querySelector(mountSelector)
+	if mount == nil {
+		return
+	}
+
+	if document.FirstChild(mount) == nil {
+		// No existing DOM, just render fresh
+		RenderToSelector(mountSelector, newVNode)
+		return
+	}
+
+	// Patch the root as the only child of the mount point; a fragment root spans
+	// several DOM nodes
+	patchChildren(mount, []*VNode{oldVNode}, []*VNode{newVNode})
+}
+
+// replaceElement swaps domElement for a freshly created subtree built from newVNode.
+func replaceElement(domElement Node, oldVNode, newVNode *VNode) {
+	deepReleaseCallbacks(oldVNode)
+
+	newElement := createElement(newVNode)
+	if newElement == nil {
+		return
+	}
+	if parent := document.ParentNode(domElement); parent != nil {
+		document.ReplaceChild(parent, newElement, domElement)
+	}
+}
+
+// patchElement updates a single DOM element based on VDOM differences.
+func patchElement(domElement Node, oldVNode, newVNode *VNode) {
+	if domElement == nil || oldVNode == nil || newVNode == nil {
+		return
+	}
+
+	// Check if component keys differ (for router navigation)
+	if oldVNode.ComponentKey != "" && newVNode.ComponentKey != "" && oldVNode.ComponentKey != newVNode.ComponentKey {
+		// Keys are different - replace entire subtree
+		console.Log("[DEBUG] Component keys differ, replacing entire tree. Old:", oldVNode.ComponentKey, "New:", newVNode.ComponentKey)
+		replaceElement(domElement, oldVNode, newVNode)
+		return
+	}
+
+	// If tags or namespaces are different, replace the entire element
+	if oldVNode.Tag != newVNode.Tag || oldVNode.Namespace != newVNode.Namespace {
+		replaceElement(domElement, oldVNode, newVNode)
+		return
+	}
+
+	// Same tag - update attributes
+	patchAttributes(domElement, oldVNode.Attributes, newVNode.Attributes)
+
+	// Update event listeners
+	// Release old callbacks and attach new ones
+	releaseCallbacks(oldVNode)
+	if newVNode.Attributes != nil {
+		attachEventListeners(domElement, newVNode, newVNode.Attributes)
+	}
+	attachOnClick(domElement, newVNode)
+	inheritNamespace(newVNode)
+
+	// Update content for input/textarea elements
+	switch newVNode.Tag {
+	case "input", "textarea":
+		// Only update value if element is NOT currently focused
+		// This preserves the user's typing experience. A value that was set before is
+		// cleared when Content becomes empty (e.g. a bound field reset to "").
+		if !document.HasFocus(domElement) && (newVNode.Content != "" || oldVNode.Content != "") {
+			currentValue, _ := document.GetProperty(domElement, "value").(string)
+			if currentValue != newVNode.Content {
+				document.SetProperty(domElement, "value", newVNode.Content)
+			}
+		}
+	case "select":
+		// Patch the options first: the selected value must match an existing option
+		patchChildren(domElement, oldVNode.Children, newVNode.Children)
+		if newVNode.Content != "" {
+			document.SetProperty(domElement, "value", newVNode.Content)
+		}
+	default:
+		if len(newVNode.Children) == 0 {
+			// No children: update text content directly.
+			// Setting textContent wipes out all child nodes, so only do this when there are none.
+			if oldVNode.Content != newVNode.Content {
+				document.SetProperty(domElement, "textContent", newVNode.Content)
+			}
+			// Release callbacks on old children whose DOM nodes were cleared by textContent,
+			// then return — calling patchChildren would remove the text node we just created.
+			for _, child := range oldVNode.Children {
+				deepReleaseCallbacks(child)
+			}
+			return
+		}
+
+		if oldVNode.Content != "" {
+			// New VNode has children but old had text content set via textContent.
+			// Clear the text so children can be patched in cleanly without the
+			// old text node remaining in the DOM alongside the new child elements.
+			document.SetProperty(domElement, "textContent", "")
+		}
+		// Patch children
+		patchChildren(domElement, oldVNode.Children, newVNode.Children)
+	}
+}
+
+// patchAttributes updates the attributes of a DOM element.
+func patchAttributes(domElement Node, oldAttrs, newAttrs map[string]any) {
+	// Remove old attributes that are not in new attributes
+	for key := range oldAttrs {
+		if _, exists := newAttrs[key]; !exists {
+			// Skip event handlers (they start with "on")
+			if isEventAttribute(key) {
+				continue
+			}
+			removeAttribute(domElement, key)
+		}
+	}
+
+	// Set new attributes
+	for key, value := range newAttrs {
+		// Skip event handlers - they're attached separately
+		if isEventAttribute(key) {
+			continue
+		}
+
+		// Check if attribute changed
+		if oldAttrs == nil || !attributeEqual(oldAttrs[key], value) {
+			// A boolean flipping to false must drop the attribute, not just skip setting it
+			if b, ok := value.(bool); ok && !b {
+				removeAttribute(domElement, key)
+				continue
+			}
+			setAttributeValue(domElement, key, value)
+		}
+	}
+
+	// Once the user toggles a checkbox the checked attribute no longer controls it, so the
+	// live property is synced as well
+	if checked, ok := newAttrs["checked"].(bool); ok {
+		if current, _ := document.GetProperty(domElement, "checked").(bool); current != checked {
+			document.SetProperty(domElement, "checked", checked)
+		}
+	}
+}
+
+// attributeEqual compares two attribute values without panicking on non-comparable types.
+func attributeEqual(a, b any) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	ta, tb := reflect.TypeOf(a), reflect.TypeOf(b)
+	if ta != tb || !ta.Comparable() {
+		return false
+	}
+	return a == b
+}
+
+// patchChildren updates the children of a DOM element.
+// When any child carries a Key (set by {@for ... trackBy} loops), children are
+// reconciled by key so existing DOM nodes are moved instead of patched in place.
+// Otherwise children are diffed strictly by index. Fragments are expanded into their
+// children first.
+func patchChildren(domElement Node, oldChildren, newChildren []*VNode) {
+	oldChildren, oldKeys := flattenKeyed(oldChildren)
+	newChildren, newKeys := flattenKeyed(newChildren)
+
+	if hasKeys(oldKeys) || hasKeys(newKeys) {
+		if keysComparable(oldKeys) && keysComparable(newKeys) {
+			patchKeyedChildren(domElement, oldChildren, oldKeys, newChildren, newKeys)
+			return
+		}
+		console.Warn("[vdom] Non-comparable VNode.Key found; falling back to index-based diffing")
+	}
+
+	oldLen := len(oldChildren)
+	newLen := len(newChildren)
+	minLen := oldLen
+	if newLen < minLen {
+		minLen = newLen
+	}
+
+	// domIndex tracks the actual DOM child position. VNodes without a DOM node (nil, empty text)
+	// have no DOM counterpart, so the DOM index diverges from the VDOM index whenever they are present.
+	domIndex := 0
+
+	// Patch existing children up to minLen
+	for i := 0; i < minLen; i++ {
+		oldChild := oldChildren[i]
+		newChild := newChildren[i]
+		oldHas := hasDOMNode(oldChild)
+		newHas := hasDOMNode(newChild)
+
+		if !oldHas && newHas {
+			// Old was absent from DOM; insert new node at the current DOM position.
+			deepReleaseCallbacks(oldChild)
+			if newChildEl := createElement(newChild); newChildEl != nil {
+				document.InsertBefore(domElement, newChildEl, document.ChildAt(domElement, domIndex))
+				domIndex++
+			}
+		} else if oldHas && !newHas {
+			// Old existed in DOM; remove the node at the current DOM position.
+			deepReleaseCallbacks(oldChild)
+			if childElement := document.ChildAt(domElement, domIndex); childElement != nil {
+				document.RemoveChild(domElement, childElement)
+			}
+			// Don't increment domIndex: after removal the next node slides into this slot.
+		} else if oldHas && newHas {
+			// Both exist — patch the DOM node at the current DOM position.
+			if childElement := document.ChildAt(domElement, domIndex); childElement != nil {
+				patchElement(childElement, oldChild, newChild)
+			}
+			domIndex++
+		}
+		// Neither has a DOM node: domIndex unchanged.
+	}
+
+	// Add new children if newChildren is longer.
+	if newLen > oldLen {
+		appendChildren(domElement, newChildren[oldLen:])
+	}
+
+	// Remove extra children if oldChildren is longer.
+	// After the minLen loop, domIndex points to the first extra old DOM node.
+	if oldLen > newLen {
+		for i := newLen; i < oldLen; i++ {
+			deepReleaseCallbacks(oldChildren[i])
+			if !hasDOMNode(oldChildren[i]) {
+				continue
+			}
+			if childElement := document.ChildAt(domElement, domIndex); childElement != nil {
+				document.RemoveChild(domElement, childElement)
+			}
+			// Don't increment domIndex after removal.
+		}
+	}
+}
+
+// flattenFragments returns children with every fragment replaced by its own children,
+// recursively, so the result lines up with the DOM nodes under the parent.
+func flattenFragments(children []*VNode) []*VNode {
+	flat, _ := flattenKeyed(children)
+	return flat
+}
+
+// flattenKeyed is flattenFragments that also returns the reconciliation key of every
+// flattened child.
+//
+// The children of a keyed fragment (a multi-root component in a {@for} loop) are keyed by
+// the fragment's Key and their own key or index, so reordering the loop moves each
+// component's nodes together. The keys are derived on every call rather than stored on the
+// VNodes: the tree belongs to the caller, and its nodes may be reused across renders.
+func flattenKeyed(children []*VNode) ([]*VNode, []any) {
+	hasFragment := false
+	for _, child := range children {
+		if child != nil && child.Tag == "#fragment" {
+			hasFragment = true
+			break
+		}
+	}
+	if !hasFragment {
+		keys := make([]any, len(children))
+		for i, child := range children {
+			if child != nil {
+				keys[i] = child.Key
+			}
+		}
+		return children, keys
+	}
+
+	flat := make([]*VNode, 0, len(children))
+	keys := make([]any, 0, len(children))
+	return appendFlattened(flat, keys, children, nil)
+}
+
+// appendFlattened appends children to flat, expanding fragments, and their keys to keys.
+// Inside a keyed fragment, parentKey is the fragment's key.
+func appendFlattened(flat []*VNode, keys []any, children []*VNode, parentKey any) ([]*VNode, []any) {
+	for i, child := range children {
+		var key any
+		if child != nil {
+			key = child.Key
+		}
+		if parentKey != nil && child != nil {
+			if key == nil {
+				key = i
+			}
+			key = [2]any{parentKey, key}
+		}
+		if child != nil && child.Tag == "#fragment" {
+			flat, keys = appendFlattened(flat, keys, child.Children, key)
+			continue
+		}
+		flat = append(flat, child)
+		keys = append(keys, key)
+	}
+	return flat, keys
+}
+
+// hasDOMNode reports whether a VNode produces a DOM node when passed to createElement.
+// nil VNodes (e.g. a false {@if}) and empty text nodes render nothing.
+func hasDOMNode(v *VNode) bool {
+	return v != nil && (v.Tag != "#text" || v.Content != "")
+}
+
+// hasKeys reports whether any child in the list carries a reconciliation key.
+func hasKeys(keys []any) bool {
+	for _, key := range keys {
+		if key != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// keysComparable reports whether every key in the list can be used as a map key.
+func keysComparable(keys []any) bool {
+	for _, key := range keys {
+		if key != nil && !reflect.TypeOf(key).Comparable() {
+			return false
+		}
+	}
+	return true
+}
+
+// patchKeyedChildren reconciles children by key: oldKeys and newKeys hold the key of each
+// child, as returned by flattenKeyed.
+//
+// Keyed children are matched to the old child with the same key and tag; unkeyed
+// children are matched in order against the old unkeyed children, so static siblings
+// around a {@for} loop keep their DOM nodes. Unmatched old nodes are removed and
+// unmatched new nodes are created. Matched nodes that sit on the longest increasing
+// subsequence of old positions keep their place; every other node is moved with a
+// single insertBefore, which keeps DOM moves to the minimum.
+func patchKeyedChildren(domElement Node, oldChildren []*VNode, oldKeys []any, newChildren []*VNode, newKeys []any) {
+	// Resolve the DOM node for each old child before anything moves.
+	oldNodes := make([]Node, len(oldChildren))
+	domIndex := 0
+	for i, child := range oldChildren {
+		if !hasDOMNode(child) {
+			continue
+		}
+		oldNodes[i] = document.ChildAt(domElement, domIndex)
+		domIndex++
+	}
+
+	keyToOld := make(map[any]int)
+	var unkeyedOld []int
+	for i, child := range oldChildren {
+		if !hasDOMNode(child) || oldNodes[i] == nil {
+			continue
+		}
+		if oldKeys[i] != nil {
+			keyToOld[oldKeys[i]] = i
+		} else {
+			unkeyedOld = append(unkeyedOld, i)
+		}
+	}
+
+	// newToOld[j] is the index of the old child reused for new child j, or -1.
+	newToOld := make([]int, len(newChildren))
+	matched := make([]bool, len(oldChildren))
+	nextUnkeyed := 0
+	for j, child := range newChildren {
+		newToOld[j] = -1
+		if !hasDOMNode(child) {
+			continue
+		}
+		if key := newKeys[j]; key != nil {
+			if i, ok := keyToOld[key]; ok && oldChildren[i].Tag == child.Tag {
+				newToOld[j] = i
+				matched[i] = true
+				// Remove the key so a duplicate key in the new list creates a fresh node.
+				delete(keyToOld, key)
+			}
+		} else if nextUnkeyed < len(unkeyedOld) {
+			i := unkeyedOld[nextUnkeyed]
+			nextUnkeyed++
+			if oldChildren[i].Tag == child.Tag {
+				newToOld[j] = i
+				matched[i] = true
+			}
+		}
+	}
+
+	// Remove old nodes that have no counterpart in the new list.
+	for i, child := range oldChildren {
+		if child == nil || matched[i] {
+			continue
+		}
+		deepReleaseCallbacks(child)
+		if oldNodes[i] != nil {
+			document.RemoveChild(domElement, oldNodes[i])
+		}
+	}
+
+	// Matched nodes on the longest increasing subsequence are already in order.
+	stable := make(map[int]bool)
+	for _, j := range longestIncreasingSubsequence(newToOld) {
+		stable[j] = true
+	}
+
+	// Walk backwards so every node can be placed before its already-positioned successor.
+	var anchor Node
+	for j := len(newChildren) - 1; j >= 0; j-- {
+		child := newChildren[j]
+		if !hasDOMNode(child) {
+			continue
+		}
+
+		i := newToOld[j]
+		if i < 0 {
+			if el := createElement(child); el != nil {
+				document.InsertBefore(domElement, el, anchor)
+				anchor = el
+			}
+			continue
+		}
+
+		node := oldNodes[i]
+		if !stable[j] {
+			document.InsertBefore(domElement, node, anchor)
+		}
+		anchor = node
+	}
+
+	// Patch matched pairs in place now that every node sits at its final position.
+	for j, i := range newToOld {
+		if i >= 0 {
+			patchElement(oldNodes[i], oldChildren[i], newChildren[j])
+		}
+	}
+}
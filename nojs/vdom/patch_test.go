@@ -0,0 +1,515 @@
+package vdom
+
+import (
+	"testing"
+)
+
+// setupMemoryDOM installs a fresh MemoryDOM with an #app mount and restores the previous backend afterwards.
+func setupMemoryDOM(t *testing.T) (*MemoryDOM, *MemoryNode) {
+	t.Helper()
+	previous := CurrentDOM()
+	dom := NewMemoryDOM()
+	SetDOM(dom)
+	t.Cleanup(func() { SetDOM(previous) })
+	return dom, dom.Mount("app")
+}
+
+// keyedList builds a <ul> with one keyed <li> per key.
+func keyedList(keys ...string) *VNode {
+	items := make([]*VNode, len(keys))
+	for i, k := range keys {
+		items[i] = NewVNode("li", nil, nil, k)
+		items[i].Key = k
+	}
+	return NewVNode("ul", nil, items, "")
+}
+
+// mutationsOf returns the recorded mutations of the given kind.
+func mutationsOf(dom *MemoryDOM, op MutationOp) []Mutation {
+	var result []Mutation
+	for _, m := range dom.Mutations {
+		if m.Op == op {
+			result = append(result, m)
+		}
+	}
+	return result
+}
+
+func TestRenderToSelector_BuildsTree(t *testing.T) {
+	// Arrange
+	_, mount := setupMemoryDOM(t)
+	tree := Div(map[string]any{"class": "card", "hidden": false, "disabled": true},
+		NewVNode("h1", nil, nil, "Title"),
+		Text("body text"),
+	)
+
+	// Act
+	RenderToSelector("#app", tree)
+
+	// Assert
+	want := `<div id="app"><div class="card" disabled=""><h1>Title</h1>body text</div></div>`
+	if got := mount.OuterHTML(); got != want {
+		t.Errorf("OuterHTML = %s, want %s", got, want)
+	}
+}
+
+func TestPatch_TextChange_SetsTextContentOnly(t *testing.T) {
+	// Arrange
+	dom, mount := setupMemoryDOM(t)
+	oldTree := Paragraph("before", nil)
+	RenderToSelector("#app", oldTree)
+	dom.ResetMutations()
+
+	// Act
+	Patch("#app", oldTree, Paragraph("after", nil))
+
+	// Assert
+	if len(dom.Mutations) != 1 || dom.Mutations[0].Op != OpSetProperty || dom.Mutations[0].Name != "textContent" {
+		t.Fatalf("Mutations = %v, want a single textContent update", dom.Mutations)
+	}
+	if got := mount.TextContent(); got != "after" {
+		t.Errorf("TextContent = %q, want %q", got, "after")
+	}
+}
+
+func TestPatch_Attributes_AddsChangesAndRemoves(t *testing.T) {
+	// Arrange
+	dom, mount := setupMemoryDOM(t)
+	oldTree := Div(map[string]any{"class": "a", "title": "t", "disabled": true})
+	RenderToSelector("#app", oldTree)
+	dom.ResetMutations()
+
+	// Act
+	Patch("#app", oldTree, Div(map[string]any{"class": "b", "disabled": false}))
+
+	// Assert
+	div := mount.Children[0]
+	if div.Attributes["class"] != "b" {
+		t.Errorf("class = %q, want %q", div.Attributes["class"], "b")
+	}
+	if _, ok := div.Attributes["title"]; ok {
+		t.Error("title attribute should have been removed")
+	}
+	if _, ok := div.Attributes["disabled"]; ok {
+		t.Error("disabled attribute should have been removed when it became false")
+	}
+	if n := len(mutationsOf(dom, OpCreateElement)); n != 0 {
+		t.Errorf("created %d elements, want 0", n)
+	}
+}
+
+func TestPatch_TagChange_ReplacesElement(t *testing.T) {
+	// Arrange
+	dom, mount := setupMemoryDOM(t)
+	oldTree := Div(nil, Paragraph("x", nil))
+	RenderToSelector("#app", oldTree)
+	dom.ResetMutations()
+
+	// Act
+	Patch("#app", oldTree, Div(nil, NewVNode("span", nil, nil, "x")))
+
+	// Assert
+	replaced := mutationsOf(dom, OpReplaceChild)
+	if len(replaced) != 1 || replaced[0].Ref.Tag != "p" || replaced[0].Node.Tag != "span" {
+		t.Fatalf("replaceChild mutations = %v, want <p> replaced with <span>", replaced)
+	}
+	if got := mount.OuterHTML(); got != `<div id="app"><div><span>x</span></div></div>` {
+		t.Errorf("OuterHTML = %s", got)
+	}
+}
+
+func TestPatch_KeyedInsertAtTop_SingleInsert(t *testing.T) {
+	// Arrange
+	dom, mount := setupMemoryDOM(t)
+	oldTree := keyedList("a", "b", "c")
+	RenderToSelector("#app", oldTree)
+	ul := mount.Children[0]
+	original := append([]*MemoryNode(nil), ul.Children...)
+	dom.ResetMutations()
+
+	// Act
+	Patch("#app", oldTree, keyedList("z", "a", "b", "c"))
+
+	// Assert
+	inserts := mutationsOf(dom, OpInsertBefore)
+	if len(inserts) != 1 {
+		t.Fatalf("insertBefore mutations = %v, want exactly 1", inserts)
+	}
+	if inserts[0].Node.TextContent() != "z" || inserts[0].Ref != original[0] {
+		t.Errorf("insert = %v, want new <li> before the original first <li>", inserts[0])
+	}
+	if n := len(mutationsOf(dom, OpRemoveChild)); n != 0 {
+		t.Errorf("removed %d nodes, want 0", n)
+	}
+	for i, li := range original {
+		if ul.Children[i+1] != li {
+			t.Errorf("child %d was not reused", i+1)
+		}
+	}
+}
+
+func TestPatch_KeyedReverse_MovesWithoutRecreating(t *testing.T) {
+	// Arrange
+	dom, mount := setupMemoryDOM(t)
+	oldTree := keyedList("a", "b", "c", "d")
+	RenderToSelector("#app", oldTree)
+	ul := mount.Children[0]
+	original := append([]*MemoryNode(nil), ul.Children...)
+	dom.ResetMutations()
+
+	// Act
+	Patch("#app", oldTree, keyedList("d", "c", "b", "a"))
+
+	// Assert
+	if n := len(mutationsOf(dom, OpCreateElement)); n != 0 {
+		t.Errorf("created %d elements, want 0", n)
+	}
+	if n := len(mutationsOf(dom, OpInsertBefore)); n != 3 {
+		t.Errorf("moved %d nodes, want 3", n)
+	}
+	for i := range original {
+		if ul.Children[i] != original[len(original)-1-i] {
+			t.Errorf("child %d is not the reused node for key %q", i, original[len(original)-1-i].TextContent())
+		}
+	}
+}
+
+func TestPatch_KeyedRemoveMiddle_SingleRemove(t *testing.T) {
+	// Arrange
+	dom, mount := setupMemoryDOM(t)
+	oldTree := keyedList("a", "b", "c")
+	RenderToSelector("#app", oldTree)
+	dom.ResetMutations()
+
+	// Act
+	Patch("#app", oldTree, keyedList("a", "c"))
+
+	// Assert
+	removes := mutationsOf(dom, OpRemoveChild)
+	if len(removes) != 1 || removes[0].Node.TextContent() != "b" {
+		t.Fatalf("removeChild mutations = %v, want only <li>b", removes)
+	}
+	if n := len(mutationsOf(dom, OpInsertBefore)); n != 0 {
+		t.Errorf("moved %d nodes, want 0", n)
+	}
+	if got := mount.Children[0].TextContent(); got != "ac" {
+		t.Errorf("TextContent = %q, want %q", got, "ac")
+	}
+}
+
+func TestPatch_NilChild_InsertsAtCorrectPosition(t *testing.T) {
+	// Arrange
+	_, mount := setupMemoryDOM(t)
+	oldTree := Div(nil, Paragraph("first", nil), nil, Paragraph("last", nil))
+	RenderToSelector("#app", oldTree)
+
+	// Act
+	Patch("#app", oldTree, Div(nil, Paragraph("first", nil), Paragraph("middle", nil), Paragraph("last", nil)))
+
+	// Assert
+	if got := mount.Children[0].TextContent(); got != "firstmiddlelast" {
+		t.Errorf("TextContent = %q, want %q", got, "firstmiddlelast")
+	}
+}
+
+func TestPatch_EventListeners_ReattachedAndReleased(t *testing.T) {
+	// Arrange
+	dom, mount := setupMemoryDOM(t)
+	clicks := 0
+	handler := func() { clicks++ }
+	newTree := func() *VNode {
+		return Div(nil,
+			NewVNode("span", map[string]any{"onMouseover": handler}, nil, "x"),
+			Button("ok", map[string]any{"onClick": handler}),
+		)
+	}
+	oldTree := newTree()
+	RenderToSelector("#app", oldTree)
+	span := mount.Children[0].Children[0]
+	button := mount.Children[0].Children[1]
+
+	// Act
+	Patch("#app", oldTree, newTree())
+	dom.Dispatch(span, "mouseover", nil)
+	dom.Dispatch(button, "click", nil)
+
+	// Assert
+	if got := dom.ListenerCount(span, "mouseover"); got != 1 {
+		t.Errorf("span mouseover listeners = %d, want 1", got)
+	}
+	if got := dom.ListenerCount(button, "click"); got != 1 {
+		t.Errorf("button click listeners = %d, want 1", got)
+	}
+	if clicks != 2 {
+		t.Errorf("clicks = %d, want 2", clicks)
+	}
+	if _, ok := span.Attributes["onMouseover"]; ok {
+		t.Error("event handler must not be rendered as an attribute")
+	}
+}
+
+func TestPatch_FocusedInput_KeepsUserValue(t *testing.T) {
+	// Arrange
+	dom, mount := setupMemoryDOM(t)
+	oldTree := Div(nil, NewVNode("input", nil, nil, "a"))
+	RenderToSelector("#app", oldTree)
+	input := mount.Children[0].Children[0]
+	dom.Focus(input)
+	dom.ResetMutations()
+
+	// Act
+	Patch("#app", oldTree, Div(nil, NewVNode("input", nil, nil, "b")))
+
+	// Assert
+	if n := len(mutationsOf(dom, OpSetProperty)); n != 0 {
+		t.Errorf("focused input value was overwritten: %v", dom.Mutations)
+	}
+}
+
+func TestPatch_UnfocusedInput_ClearsValue(t *testing.T) {
+	// Arrange
+	_, mount := setupMemoryDOM(t)
+	oldTree := Div(nil, NewVNode("input", nil, nil, "draft"))
+	RenderToSelector("#app", oldTree)
+	input := mount.Children[0].Children[0]
+
+	// Act
+	Patch("#app", oldTree, Div(nil, NewVNode("input", nil, nil, "")))
+
+	// Assert
+	if got := input.Properties["value"]; got != "" {
+		t.Errorf("value = %v, want empty", got)
+	}
+}
+
+func TestPatch_Checkbox_SyncsCheckedProperty(t *testing.T) {
+	// Arrange
+	dom, mount := setupMemoryDOM(t)
+	checkbox := func() *VNode {
+		return Div(nil, NewVNode("input", map[string]any{"type": "checkbox", "checked": true}, nil, ""))
+	}
+	oldTree := checkbox()
+	RenderToSelector("#app", oldTree)
+	input := mount.Children[0].Children[0]
+	dom.SetProperty(input, "checked", false) // The user unticks the box; the component rejects the change
+
+	// Act
+	Patch("#app", oldTree, checkbox())
+
+	// Assert
+	if got := input.Properties["checked"]; got != true {
+		t.Errorf("checked property = %v, want true", got)
+	}
+}
+
+func TestSelect_ValueAppliedAfterOptions(t *testing.T) {
+	// Arrange
+	dom, mount := setupMemoryDOM(t)
+	options := func(values ...string) []*VNode {
+		var result []*VNode
+		for _, v := range values {
+			result = append(result, NewVNode("option", map[string]any{"value": v}, nil, v))
+		}
+		return result
+	}
+	oldTree := NewVNode("select", nil, options("Go", "Rust"), "Rust")
+
+	// Act
+	RenderToSelector("#app", oldTree)
+	selectEl := mount.Children[0]
+	createdValue := selectEl.Properties["value"]
+	dom.ResetMutations()
+	Patch("#app", oldTree, NewVNode("select", nil, options("Go", "Rust", "Zig"), "Zig"))
+
+	// Assert
+	if createdValue != "Rust" {
+		t.Errorf("initial value = %v, want Rust", createdValue)
+	}
+	if n := len(selectEl.Children); n != 3 {
+		t.Fatalf("expected 3 options after patch, got %d", n)
+	}
+	last := dom.Mutations[len(dom.Mutations)-1]
+	if last.Op != OpSetProperty || last.Name != "value" || last.Value != "Zig" {
+		t.Errorf("expected the value to be set after the options, last mutation: %v", last)
+	}
+}
+
+func TestPatch_OnceListener_StaysSpentAcrossRenders(t *testing.T) {
+	// Arrange
+	dom, mount := setupMemoryDOM(t)
+	calls := 0
+	button := func() *VNode {
+		return Button("Go", map[string]any{"onClick": EventListener{Handler: func() { calls++ }, Once: true}})
+	}
+	oldTree := button()
+	RenderToSelector("#app", oldTree)
+	el := mount.Children[0]
+
+	// Act
+	dom.Dispatch(el, "click", nil)
+	Patch("#app", oldTree, button())
+	dom.Dispatch(el, "click", nil)
+
+	// Assert
+	if calls != 1 {
+		t.Errorf("once handler called %d times, want 1", calls)
+	}
+	if n := dom.ListenerCount(el, "click"); n != 1 {
+		t.Errorf("expected the re-rendered listener to be attached, got %d listeners", n)
+	}
+	if _, ok := el.Attributes["onClick"]; ok {
+		t.Error("EventListener must not be rendered as an attribute")
+	}
+}
+
+func TestRenderToSelector_SVG_CreatesNamespacedElements(t *testing.T) {
+	// Arrange
+	dom, mount := setupMemoryDOM(t)
+	icon := NewVNode("path", map[string]any{"d": "M0 0"}, nil, "") // e.g. rendered by a child component
+	tree := NewVNodeNS(NamespaceSVG, "svg", map[string]any{"viewBox": "0 0 10 10"}, []*VNode{
+		icon,
+		NewVNodeNS(NamespaceSVG, "use", map[string]any{"xlink:href": "#dot"}, nil, ""),
+		NewVNodeNS(NamespaceSVG, "foreignObject", nil, []*VNode{NewVNode("p", nil, nil, "hi")}, ""),
+	}, "")
+
+	// Act
+	RenderToSelector("#app", tree)
+
+	// Assert
+	svg := mount.Children[0]
+	if svg.Namespace != NamespaceSVG || svg.Attributes["viewBox"] != "0 0 10 10" {
+		t.Errorf("svg = %+v, want an SVG element with viewBox", svg)
+	}
+	if got := svg.Children[0].Namespace; got != NamespaceSVG {
+		t.Errorf("path namespace = %q, want it inherited from <svg>", got)
+	}
+	if got := svg.Children[2].Children[0].Namespace; got != "" {
+		t.Errorf("<p> in <foreignObject> namespace = %q, want HTML", got)
+	}
+	if len(mutationsOf(dom, OpCreateElement)) != 5 || svg.Children[1].Attributes["xlink:href"] != "#dot" {
+		t.Errorf("Mutations = %v, want 5 elements and xlink:href on <use>", dom.Mutations)
+	}
+}
+
+func TestPatch_NamespaceChange_ReplacesElement(t *testing.T) {
+	// Arrange
+	dom, mount := setupMemoryDOM(t)
+	oldTree := NewVNode("a", map[string]any{"href": "/"}, nil, "home")
+	RenderToSelector("#app", oldTree)
+	dom.ResetMutations()
+
+	// Act
+	Patch("#app", oldTree, NewVNodeNS(NamespaceSVG, "a", map[string]any{"href": "/"}, nil, "home"))
+
+	// Assert
+	if len(mutationsOf(dom, OpReplaceChild)) != 1 || mount.Children[0].Namespace != NamespaceSVG {
+		t.Errorf("Mutations = %v, want the HTML <a> replaced by an SVG <a>", dom.Mutations)
+	}
+}
+
+// keyedRows builds a <tbody> with one keyed fragment of two <tr> per key, like a
+// multi-root component rendered in a {@for} loop.
+func keyedRows(keys ...string) *VNode {
+	rows := make([]*VNode, len(keys))
+	for i, k := range keys {
+		rows[i] = Fragment(NewVNode("tr", nil, nil, k), NewVNode("tr", nil, nil, k+"-detail"))
+		rows[i].Key = k
+	}
+	return NewVNode("tbody", nil, rows, "")
+}
+
+func TestRenderToSelector_FragmentRoot_MountsEverySibling(t *testing.T) {
+	// Arrange
+	_, mount := setupMemoryDOM(t)
+	tree := Fragment(NewVNode("h1", nil, nil, "Title"), nil, Fragment(Paragraph("a", nil), Paragraph("b", nil)))
+
+	// Act
+	RenderToSelector("#app", tree)
+
+	// Assert
+	want := `<div id="app"><h1>Title</h1><p>a</p><p>b</p></div>`
+	if got := mount.OuterHTML(); got != want {
+		t.Errorf("OuterHTML = %s, want %s", got, want)
+	}
+}
+
+func TestPatch_FragmentGrows_KeepsSiblingOrder(t *testing.T) {
+	// Arrange
+	_, mount := setupMemoryDOM(t)
+	oldTree := Div(nil, Fragment(Paragraph("a", nil)), NewVNode("footer", nil, nil, "end"))
+	RenderToSelector("#app", oldTree)
+
+	// Act
+	Patch("#app", oldTree, Div(nil, Fragment(Paragraph("a", nil), Paragraph("b", nil)), NewVNode("footer", nil, nil, "end")))
+
+	// Assert
+	want := `<div id="app"><div><p>a</p><p>b</p><footer>end</footer></div></div>`
+	if got := mount.OuterHTML(); got != want {
+		t.Errorf("OuterHTML = %s, want %s", got, want)
+	}
+}
+
+func TestPatch_FragmentRoot_PatchesEverySibling(t *testing.T) {
+	// Arrange
+	_, mount := setupMemoryDOM(t)
+	oldTree := Fragment(NewVNode("h1", nil, nil, "Old"), Paragraph("a", nil), Paragraph("b", nil))
+	RenderToSelector("#app", oldTree)
+
+	// Act
+	Patch("#app", oldTree, Fragment(NewVNode("h1", nil, nil, "New"), Paragraph("a", nil)))
+
+	// Assert
+	want := `<div id="app"><h1>New</h1><p>a</p></div>`
+	if got := mount.OuterHTML(); got != want {
+		t.Errorf("OuterHTML = %s, want %s", got, want)
+	}
+}
+
+func TestPatch_KeyedFragmentsReversed_MoveTogether(t *testing.T) {
+	// Arrange
+	dom, mount := setupMemoryDOM(t)
+	oldTree := keyedRows("a", "b")
+	RenderToSelector("#app", oldTree)
+	original := append([]*MemoryNode(nil), mount.Children[0].Children...)
+	dom.ResetMutations()
+
+	// Act
+	Patch("#app", oldTree, keyedRows("b", "a"))
+
+	// Assert
+	if n := len(mutationsOf(dom, OpCreateElement)); n != 0 {
+		t.Errorf("created %d elements, want 0", n)
+	}
+	rows := mount.Children[0].Children
+	for i, want := range []int{2, 3, 0, 1} {
+		if rows[i] != original[want] {
+			t.Errorf("row %d = %q, want the reused row %q", i, rows[i].TextContent(), original[want].TextContent())
+		}
+	}
+}
+
+func TestPatch_KeyedFragments_LeaveTheTreeUnchanged(t *testing.T) {
+	// Arrange
+	_, mount := setupMemoryDOM(t)
+	oldTree := keyedRows("a", "b")
+	RenderToSelector("#app", oldTree)
+	newTree := keyedRows("b", "a")
+
+	// Act
+	Patch("#app", oldTree, newTree)
+
+	// Assert
+	for _, tree := range []*VNode{oldTree, newTree} {
+		for _, fragment := range tree.Children {
+			for _, row := range fragment.Children {
+				if row.Key != nil {
+					t.Errorf("row %q Key = %v, want nil", row.Content, row.Key)
+				}
+			}
+		}
+	}
+	want := `<div id="app"><tbody><tr>b</tr><tr>b-detail</tr><tr>a</tr><tr>a-detail</tr></tbody></div>`
+	if got := mount.OuterHTML(); got != want {
+		t.Errorf("OuterHTML = %s, want %s", got, want)
+	}
+}
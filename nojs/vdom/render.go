@@ -4,729 +4,208 @@
 package vdom
 
 import (
+	"strings"
 	"syscall/js"
 
 	"github.com/ForgeLogic/nojs/console"
 )
 
-// eventBinding tracks a DOM listener so it can be removed before releasing the js.Func.
-type eventBinding struct {
-	target    js.Value
-	eventName string
-	callback  js.Func
+// JSDOM is the browser DOM backend. Nodes are js.Value handles.
+type JSDOM struct {
+	doc js.Value
 }
 
-// release detaches the listener and releases the underlying js.Func.
-func (b eventBinding) release() {
-	if b.target.Truthy() && b.eventName != "" {
-		b.target.Call("removeEventListener", b.eventName, b.callback)
-	}
-	b.callback.Release()
+// NewJSDOM returns a backend bound to the global document.
+func NewJSDOM() *JSDOM {
+	return &JSDOM{doc: js.Global().Get("document")}
 }
 
-// releaseCallbacks releases all js.Func objects stored in a VNode.
-func releaseCallbacks(v *VNode) {
-	if v == nil {
-		return
-	}
-
-	callbacks := v.GetEventCallbacks()
-	for _, cb := range callbacks {
-		switch stored := cb.(type) {
-		case eventBinding:
-			stored.release()
-		case *eventBinding:
-			if stored != nil {
-				stored.release()
-			}
-		case js.Func:
-			stored.Release()
-		case *js.Func:
-			if stored != nil {
-				stored.Release()
-			}
-		}
-	}
-	v.ClearEventCallbacks()
+func init() {
+	SetDOM(NewJSDOM())
 }
 
-// deepReleaseCallbacks recursively releases all callbacks in the entire VNode tree.
-func deepReleaseCallbacks(v *VNode) {
-	if v == nil {
+// RenderTo appends the rendered node to a specific mount element.
+func RenderTo(mount js.Value, n *VNode) {
+	if !mount.Truthy() {
 		return
 	}
-
-	releaseCallbacks(v)
-
-	for _, child := range v.Children {
-		deepReleaseCallbacks(child)
-	}
+	mountNode(mount, n)
 }
 
-func Clear(selector string, prevVDOM *VNode) {
-	if selector == "" {
-		return
-	}
-
-	// Release all callbacks in the previous VDOM tree
-	if prevVDOM != nil {
-		deepReleaseCallbacks(prevVDOM)
-	}
-
-	doc := js.Global().Get("document")
-	if !doc.Truthy() {
-		return
-	}
-
-	mount := doc.Call("querySelector", selector)
-	if !mount.Truthy() {
-		console.Error("Mount element not found for selector:", selector)
-		return
+// jsNode converts a js.Value to a Node, mapping null and undefined to nil.
+func jsNode(v js.Value) Node {
+	if v.IsNull() || v.IsUndefined() {
+		return nil
 	}
-
-	// Set innerHTML to an empty string to clear all children.
-	mount.Set("innerHTML", "")
+	return v
 }
 
-// RenderToSelector mounts the VNode under the first element matching the CSS selector.
-func RenderToSelector(selector string, n *VNode) {
-	if n == nil || selector == "" {
-		return
+// jsValue converts a Node back to a js.Value, mapping nil to null.
+func jsValue(n Node) js.Value {
+	if v, ok := n.(js.Value); ok {
+		return v
 	}
+	return js.Null()
+}
 
-	doc := js.Global().Get("document")
-	if !doc.Truthy() {
-		return
-	}
-
-	mount := doc.Call("querySelector", selector)
-
-	if !mount.Truthy() {
-		console.Error("Mount element not found for selector:", selector)
-		return
+func (d *JSDOM) QuerySelector(selector string) Node {
+	if !d.doc.Truthy() {
+		return nil
 	}
+	return jsNode(d.doc.Call("querySelector", selector))
+}
 
-	RenderTo(mount, n)
+func (d *JSDOM) CreateElement(tag string) Node {
+	return d.doc.Call("createElement", tag)
 }
 
-// RenderTo appends the rendered node to a specific mount element.
-func RenderTo(mount js.Value, n *VNode) {
-	if n == nil {
-		return
-	}
+func (d *JSDOM) CreateElementNS(namespace, tag string) Node {
+	return d.doc.Call("createElementNS", namespace, tag)
+}
 
-	el := createElement(n)
+func (d *JSDOM) CreateText(content string) Node {
+	return d.doc.Call("createTextNode", content)
+}
 
-	if el.Truthy() {
-		mount.Call("appendChild", el)
-	}
+func (d *JSDOM) SetAttribute(el Node, key, value string) {
+	jsValue(el).Call("setAttribute", key, value)
 }
 
-// setAttributeValue sets an attribute on an element, handling boolean attributes and event handlers correctly.
-func setAttributeValue(el js.Value, key string, value any) {
-	// Handle boolean attributes
-	if boolVal, ok := value.(bool); ok {
-		if boolVal {
-			// For boolean attributes, set them without a value (or with empty string)
-			el.Call("setAttribute", key, "")
-		}
-		// If false, don't set the attribute at all
-		return
-	}
+func (d *JSDOM) SetAttributeNS(el Node, namespace, key, value string) {
+	jsValue(el).Call("setAttributeNS", namespace, key, value)
+}
 
-	// Handle event handlers (functions that accept js.Value)
-	if _, ok := value.(func(js.Value)); ok {
-		// Event handlers should be attached via addEventListener, not setAttribute
-		// Skip them here - they'll be handled separately
-		return
+func (d *JSDOM) GetAttribute(el Node, key string) (string, bool) {
+	v := jsValue(el).Call("getAttribute", key)
+	if v.IsNull() || v.IsUndefined() {
+		return "", false
 	}
-
-	// For all other types, convert to string and set normally
-	el.Call("setAttribute", key, value)
+	return v.String(), true
 }
 
-// attachEventListeners processes attributes and attaches event listeners for event handlers.
-// Event attributes start with "on" (e.g., onClick, onInput, onMousedown).
-// The VNode parameter is used to store js.Func objects for later cleanup.
-func attachEventListeners(el js.Value, vnode *VNode, attributes map[string]any) {
-	if attributes == nil {
-		return
+func (d *JSDOM) AttributeNames(el Node) []string {
+	names := jsValue(el).Call("getAttributeNames")
+	result := make([]string, names.Length())
+	for i := range result {
+		result[i] = names.Index(i).String()
 	}
+	return result
+}
 
-	for key, value := range attributes {
-		// Check if this is an event handler (starts with "on")
-		if len(key) > 2 && key[0] == 'o' && key[1] == 'n' {
-			if handler, ok := value.(func(js.Value)); ok {
-				// Convert "onClick" -> "click", "onInput" -> "input", etc.
-				// Lowercase the first character after "on" if it's uppercase
-				eventName := key[2:]
-				if eventName[0] >= 'A' && eventName[0] <= 'Z' {
-					eventName = string(eventName[0]+('a'-'A')) + eventName[1:]
-				}
-
-				// Wrap the handler in js.FuncOf
-				cb := js.FuncOf(func(this js.Value, args []js.Value) any {
-					if len(args) > 0 {
-						handler(args[0])
-					}
-					return nil
-				})
-
-				el.Call("addEventListener", eventName, cb)
-
-				// Store the callback in VNode for later cleanup
-				if vnode != nil {
-					vnode.AddEventCallback(eventBinding{target: el, eventName: eventName, callback: cb})
-				}
-			}
-		}
-	}
+func (d *JSDOM) RemoveAttribute(el Node, key string) {
+	jsValue(el).Call("removeAttribute", key)
 }
 
-func createElement(n *VNode) js.Value {
-	doc := js.Global().Get("document")
-	if !doc.Truthy() || n == nil {
-		return js.Undefined()
+// RemoveAttributeNS removes the attribute by its local name, the part of key after the prefix.
+func (d *JSDOM) RemoveAttributeNS(el Node, namespace, key string) {
+	if _, local, ok := strings.Cut(key, ":"); ok {
+		key = local
 	}
+	jsValue(el).Call("removeAttributeNS", namespace, key)
+}
 
-	switch n.Tag {
-	case "#text":
-		// Pure text node - no HTML element wrapper
-		if n.Content == "" {
-			console.Log("[DEBUG] Text node with empty content, returning undefined")
-			return js.Undefined()
-		}
-		textNode := doc.Call("createTextNode", n.Content)
-		return textNode
-
-	case "p":
-		el := doc.Call("createElement", "p")
-
-		if n.Attributes != nil {
-			for k, v := range n.Attributes {
-				setAttributeValue(el, k, v)
-			}
-			attachEventListeners(el, n, n.Attributes)
-		}
-
-		if n.Content != "" {
-			el.Set("textContent", n.Content)
-		} else if n.Children != nil {
-			for _, child := range n.Children {
-				childEl := createElement(child)
-				if childEl.Truthy() {
-					el.Call("appendChild", childEl)
-				}
-			}
-		}
-
-		return el
-	case "div":
-		el := doc.Call("createElement", "div")
-
-		if n.Attributes != nil {
-			for k, v := range n.Attributes {
-				setAttributeValue(el, k, v)
-			}
-			attachEventListeners(el, n, n.Attributes)
-		}
-
-		if n.Content != "" {
-			el.Set("textContent", n.Content)
-		}
-
-		if n.Children != nil {
-			for _, child := range n.Children {
-				childEl := createElement(child)
-				if childEl.Truthy() {
-					el.Call("appendChild", childEl)
-				}
-			}
-		}
-
-		return el
-	case "input":
-		el := doc.Call("createElement", "input")
-
-		if n.Attributes != nil {
-			for k, v := range n.Attributes {
-				setAttributeValue(el, k, v)
-			}
-			attachEventListeners(el, n, n.Attributes)
-		}
-
-		// For text input, set value if provided in Content
-		if n.Content != "" {
-			el.Set("value", n.Content)
-		}
-
-		return el
-	case "button":
-		el := doc.Call("createElement", "button")
-
-		if n.Attributes != nil {
-			for k, v := range n.Attributes {
-				setAttributeValue(el, k, v)
-			}
-			attachEventListeners(el, n, n.Attributes)
-		}
-
-		if n.Content != "" {
-			el.Set("textContent", n.Content)
-		} else if n.Children != nil {
-			for _, child := range n.Children {
-				childEl := createElement(child)
-				if childEl.Truthy() {
-					el.Call("appendChild", childEl)
-				}
-			}
-		}
-
-		// Attach Go OnClick handler if present (legacy support)
-		if n.OnClick != nil {
-			cb := js.FuncOf(func(this js.Value, args []js.Value) any {
-				n.OnClick()
-				return nil
-			})
-			el.Call("addEventListener", "click", cb)
-			// Store callback for cleanup
-			n.AddEventCallback(eventBinding{target: el, eventName: "click", callback: cb})
-		}
-
-		return el
-
-	case "h1", "h2", "h3", "h4", "h5", "h6":
-		// Handle heading tags
-		el := doc.Call("createElement", n.Tag)
-
-		if n.Attributes != nil {
-			for k, v := range n.Attributes {
-				setAttributeValue(el, k, v)
-			}
-			attachEventListeners(el, n, n.Attributes)
-		}
-
-		if n.Content != "" {
-			el.Set("textContent", n.Content)
-		}
-
-		if n.Children != nil {
-			for _, child := range n.Children {
-				childEl := createElement(child)
-				if childEl.Truthy() {
-					el.Call("appendChild", childEl)
-				}
-			}
-		}
-
-		return el
-
-	case "ul", "ol":
-		// Handle list container tags
-		el := doc.Call("createElement", n.Tag)
-
-		if n.Attributes != nil {
-			for k, v := range n.Attributes {
-				setAttributeValue(el, k, v)
-			}
-			attachEventListeners(el, n, n.Attributes)
-		}
-
-		if n.Children != nil {
-			for _, child := range n.Children {
-				childEl := createElement(child)
-				if childEl.Truthy() {
-					el.Call("appendChild", childEl)
-				}
-			}
-		}
-
-		return el
-
-	case "li":
-		// Handle list item tags
-		el := doc.Call("createElement", "li")
-
-		if n.Attributes != nil {
-			for k, v := range n.Attributes {
-				setAttributeValue(el, k, v)
-			}
-			attachEventListeners(el, n, n.Attributes)
-		}
-
-		if n.Content != "" {
-			el.Set("textContent", n.Content)
-		}
-
-		if n.Children != nil {
-			for _, child := range n.Children {
-				childEl := createElement(child)
-				if childEl.Truthy() {
-					el.Call("appendChild", childEl)
-				}
-			}
-		}
-
-		return el
-
-	case "select":
-		// Handle select dropdown element
-		el := doc.Call("createElement", "select")
-
-		if n.Attributes != nil {
-			for k, v := range n.Attributes {
-				setAttributeValue(el, k, v)
-			}
-			attachEventListeners(el, n, n.Attributes)
-		}
-
-		if n.Children != nil {
-			for _, child := range n.Children {
-				childEl := createElement(child)
-				if childEl.Truthy() {
-					el.Call("appendChild", childEl)
-				}
-			}
-		}
-
-		return el
-
-	case "option":
-		// Handle option element
-		el := doc.Call("createElement", "option")
-
-		if n.Attributes != nil {
-			for k, v := range n.Attributes {
-				setAttributeValue(el, k, v)
-			}
-			attachEventListeners(el, n, n.Attributes)
-		}
-
-		if n.Content != "" {
-			el.Set("textContent", n.Content)
-		}
-
-		return el
-
-	case "textarea":
-		// Handle textarea element
-		el := doc.Call("createElement", "textarea")
-
-		if n.Attributes != nil {
-			for k, v := range n.Attributes {
-				setAttributeValue(el, k, v)
-			}
-			attachEventListeners(el, n, n.Attributes)
-		}
-
-		if n.Content != "" {
-			el.Set("value", n.Content)
-		}
-
-		return el
-
-	case "form":
-		// Handle form element
-		el := doc.Call("createElement", "form")
-
-		if n.Attributes != nil {
-			for k, v := range n.Attributes {
-				setAttributeValue(el, k, v)
-			}
-			attachEventListeners(el, n, n.Attributes)
-		}
-
-		if n.Children != nil {
-			for _, child := range n.Children {
-				childEl := createElement(child)
-				if childEl.Truthy() {
-					el.Call("appendChild", childEl)
-				}
-			}
-		}
-
-		return el
-
-	case "a", "nav", "span", "section", "article", "header", "footer", "main", "aside":
-		// Handle semantic HTML5 elements and inline elements
-		el := doc.Call("createElement", n.Tag)
-
-		if n.Attributes != nil {
-			for k, v := range n.Attributes {
-				setAttributeValue(el, k, v)
-			}
-			attachEventListeners(el, n, n.Attributes)
-		}
-
-		if n.Content != "" {
-			el.Set("textContent", n.Content)
-		}
-
-		if n.Children != nil {
-			for _, child := range n.Children {
-				childEl := createElement(child)
-				if childEl.Truthy() {
-					el.Call("appendChild", childEl)
-				}
-			}
-		}
-
-		return el
+func (d *JSDOM) SetProperty(el Node, key string, value any) {
+	jsValue(el).Set(key, value)
+}
 
+func (d *JSDOM) GetProperty(el Node, key string) any {
+	v := jsValue(el).Get(key)
+	switch v.Type() {
+	case js.TypeString:
+		return v.String()
+	case js.TypeBoolean:
+		return v.Bool()
+	case js.TypeNumber:
+		return v.Float()
+	case js.TypeNull, js.TypeUndefined:
+		return nil
 	default:
-		// Generic fallback: create any HTML element by tag name, set attributes, append children.
-		// This handles void elements like <img>, <br>, <hr> as well as any future tags.
-		el := doc.Call("createElement", n.Tag)
-
-		if n.Attributes != nil {
-			for k, v := range n.Attributes {
-				setAttributeValue(el, k, v)
-			}
-			attachEventListeners(el, n, n.Attributes)
-		}
-
-		if n.Content != "" {
-			el.Set("textContent", n.Content)
-		}
-
-		if n.Children != nil {
-			for _, child := range n.Children {
-				childEl := createElement(child)
-				if childEl.Truthy() {
-					el.Call("appendChild", childEl)
-				}
-			}
-		}
-
-		return el
+		return v
 	}
 }
 
-// Patch updates the DOM by comparing old and new VDOM trees and applying minimal changes.
-func Patch(mountSelector string, oldVNode, newVNode *VNode) {
-	if oldVNode == nil || newVNode == nil {
-		return
-	}
-
-	doc := js.Global().Get("document")
-	if !doc.Truthy() {
-		return
-	}
-
-	mount := doc.Call("querySelector", mountSelector)
-	if !mount.Truthy() {
-		console.Error("Mount element not found for selector:", mountSelector)
-		return
-	}
-
-	// Get the root DOM element (first child of mount point)
-	rootElement := mount.Get("firstChild")
-	if !rootElement.Truthy() {
-		// No existing DOM, just render fresh
-		RenderToSelector(mountSelector, newVNode)
-		return
-	}
-
-	// Patch the root element
-	patchElement(rootElement, oldVNode, newVNode)
+func (d *JSDOM) InsertBefore(parent, child, ref Node) {
+	jsValue(parent).Call("insertBefore", jsValue(child), jsValue(ref))
 }
 
-// patchElement updates a single DOM element based on VDOM differences.
-func patchElement(domElement js.Value, oldVNode, newVNode *VNode) {
-	if !domElement.Truthy() || oldVNode == nil || newVNode == nil {
-		return
-	}
-
-	// Check if component keys differ (for router navigation)
-	if oldVNode.ComponentKey != "" && newVNode.ComponentKey != "" && oldVNode.ComponentKey != newVNode.ComponentKey {
-		// Keys are different - replace entire subtree
-		console.Log("[DEBUG] Component keys differ, replacing entire tree. Old:", oldVNode.ComponentKey, "New:", newVNode.ComponentKey)
-		deepReleaseCallbacks(oldVNode)
-
-		newElement := createElement(newVNode)
-		if newElement.Truthy() {
-			parent := domElement.Get("parentNode")
-			if parent.Truthy() {
-				parent.Call("replaceChild", newElement, domElement)
-			}
-		}
-		return
-	}
-
-	// If tags are different, replace the entire element
-	if oldVNode.Tag != newVNode.Tag {
-		// Release callbacks before replacing
-		deepReleaseCallbacks(oldVNode)
-
-		newElement := createElement(newVNode)
-		if newElement.Truthy() {
-			parent := domElement.Get("parentNode")
-			if parent.Truthy() {
-				parent.Call("replaceChild", newElement, domElement)
-			}
-		}
-		return
-	}
+func (d *JSDOM) RemoveChild(parent, child Node) {
+	jsValue(parent).Call("removeChild", jsValue(child))
+}
 
-	// Same tag - update attributes
-	patchAttributes(domElement, oldVNode.Attributes, newVNode.Attributes)
+func (d *JSDOM) ReplaceChild(parent, newChild, oldChild Node) {
+	jsValue(parent).Call("replaceChild", jsValue(newChild), jsValue(oldChild))
+}
 
-	// Update event listeners
-	// Release old callbacks and attach new ones
-	releaseCallbacks(oldVNode)
-	if newVNode.Attributes != nil {
-		attachEventListeners(domElement, newVNode, newVNode.Attributes)
-	}
+func (d *JSDOM) FirstChild(parent Node) Node {
+	return jsNode(jsValue(parent).Get("firstChild"))
+}
 
-	// Update content for input/textarea elements
-	switch newVNode.Tag {
-	case "input", "textarea":
-		// Only update value if element is NOT currently focused
-		// This preserves the user's typing experience
-		isFocused := domElement.Call("matches", ":focus")
-		if !isFocused.Bool() && newVNode.Content != "" {
-			currentValue := domElement.Get("value").String()
-			if currentValue != newVNode.Content {
-				domElement.Set("value", newVNode.Content)
-			}
-		}
-	case "select":
-		// For select elements, update the selected value
-		if newVNode.Content != "" {
-			domElement.Set("value", newVNode.Content)
-		}
-	default:
-		if len(newVNode.Children) == 0 {
-			// No children: update text content directly.
-			// Setting textContent wipes out all child nodes, so only do this when there are none.
-			if oldVNode.Content != newVNode.Content {
-				domElement.Set("textContent", newVNode.Content)
-			}
-			// Release callbacks on old children whose DOM nodes were cleared by textContent,
-			// then return — calling patchChildren would remove the text node we just created.
-			for _, child := range oldVNode.Children {
-				deepReleaseCallbacks(child)
-			}
-			return
-		} else {
-			if oldVNode.Content != "" {
-				// New VNode has children but old had text content set via textContent.
-				// Clear the text so children can be patched in cleanly without the
-				// old text node remaining in the DOM alongside the new child elements.
-				domElement.Set("textContent", "")
-			}
-			// Patch children
-			patchChildren(domElement, oldVNode.Children, newVNode.Children)
-		}
-	}
+func (d *JSDOM) ChildAt(parent Node, index int) Node {
+	return jsNode(jsValue(parent).Get("childNodes").Call("item", index))
 }
 
-// patchAttributes updates the attributes of a DOM element.
-func patchAttributes(domElement js.Value, oldAttrs, newAttrs map[string]any) {
-	// Remove old attributes that are not in new attributes
-	for key := range oldAttrs {
-		if _, exists := newAttrs[key]; !exists {
-			// Skip event handlers (they start with "on")
-			if len(key) > 2 && key[0] == 'o' && key[1] == 'n' {
-				continue
-			}
-			domElement.Call("removeAttribute", key)
-		}
+// NodeName uses localName, which keeps the case of SVG and MathML tags (nodeName
+// upper-cases HTML tags); text and comment nodes have no localName.
+func (d *JSDOM) NodeName(node Node) string {
+	v := jsValue(node)
+	if name := v.Get("localName"); name.Type() == js.TypeString {
+		return name.String()
 	}
+	return strings.ToLower(v.Get("nodeName").String())
+}
 
-	// Set new attributes
-	for key, value := range newAttrs {
-		// Skip event handlers - they're attached separately
-		if len(key) > 2 && key[0] == 'o' && key[1] == 'n' {
-			continue
-		}
+func (d *JSDOM) ParentNode(node Node) Node {
+	return jsNode(jsValue(node).Get("parentNode"))
+}
 
-		// Check if attribute changed
-		if oldAttrs == nil || oldAttrs[key] != value {
-			setAttributeValue(domElement, key, value)
-		}
-	}
+func (d *JSDOM) HasFocus(el Node) bool {
+	return jsValue(el).Call("matches", ":focus").Bool()
 }
 
-// patchChildren updates the children of a DOM element.
-func patchChildren(domElement js.Value, oldChildren, newChildren []*VNode) {
-	oldLen := len(oldChildren)
-	newLen := len(newChildren)
-	minLen := oldLen
-	if newLen < minLen {
-		minLen = newLen
+// AddEventListener wraps handler in a js.Func. The returned function removes the
+// listener and releases the js.Func. An EventListener's options are passed to
+// addEventListener; its Once flag is kept on the element so re-attaching the
+// listener on a later render does not re-arm it.
+func (d *JSDOM) AddEventListener(el Node, eventName string, handler any) func() {
+	var listener EventListener
+	if l, ok := handler.(EventListener); ok {
+		listener = l
+	} else {
+		listener.Handler = handler
 	}
 
-	// Get the DOM children (live NodeList).
-	domChildren := domElement.Get("childNodes")
-
-	// domIndex tracks the actual DOM child position. nil VNodes have no DOM counterpart,
-	// so the DOM index diverges from the VDOM index whenever nils are present.
-	domIndex := 0
-
-	// Patch existing children up to minLen
-	for i := 0; i < minLen; i++ {
-		oldChild := oldChildren[i]
-		newChild := newChildren[i]
-
-		if oldChild == nil && newChild != nil {
-			// Old was absent from DOM; insert new node at the current DOM position.
-			newChildEl := createElement(newChild)
-			if newChildEl.Truthy() {
-				if domIndex < domChildren.Length() {
-					refChild := domChildren.Call("item", domIndex)
-					if refChild.Truthy() {
-						domElement.Call("insertBefore", newChildEl, refChild)
-					} else {
-						domElement.Call("appendChild", newChildEl)
-					}
-				} else {
-					domElement.Call("appendChild", newChildEl)
-				}
-				domIndex++
-			}
-		} else if oldChild != nil && newChild == nil {
-			// Old existed in DOM; remove the node at the current DOM position.
-			deepReleaseCallbacks(oldChild)
-			childElement := domChildren.Call("item", domIndex)
-			if childElement.Truthy() {
-				domElement.Call("removeChild", childElement)
+	var call func(args []js.Value)
+	switch h := listener.Handler.(type) {
+	case func(js.Value):
+		call = func(args []js.Value) {
+			if len(args) > 0 {
+				h(args[0])
 			}
-			// Don't increment domIndex: after removal the next node slides into this slot.
-		} else if oldChild != nil && newChild != nil {
-			// Both exist — patch the DOM node at the current DOM position.
-			childElement := domChildren.Call("item", domIndex)
-			if childElement.Truthy() {
-				patchElement(childElement, oldChild, newChild)
-			}
-			domIndex++
 		}
-		// Both nil: no DOM node involved, domIndex unchanged.
+	case func():
+		call = func(args []js.Value) { h() }
+	default:
+		console.Warn("[vdom] Unsupported event handler type for", eventName)
+		return nil
 	}
 
-	// Add new children if newChildren is longer.
-	if newLen > oldLen {
-		for i := oldLen; i < newLen; i++ {
-			newChild := createElement(newChildren[i])
-			if newChild.Truthy() {
-				domElement.Call("appendChild", newChild)
+	target := jsValue(el)
+	flag := onceFlag(eventName)
+	cb := js.FuncOf(func(this js.Value, args []js.Value) any {
+		if listener.Once {
+			if target.Get(flag).Truthy() {
+				return nil
 			}
+			target.Set(flag, true)
 		}
-	}
+		call(args)
+		return nil
+	})
 
-	// Remove extra children if oldChildren is longer.
-	// After the minLen loop, domIndex points to the first extra old DOM node.
-	// For each non-nil extra old child, remove the DOM node at that position.
-	if oldLen > newLen {
-		for i := newLen; i < oldLen; i++ {
-			if oldChildren[i] != nil {
-				deepReleaseCallbacks(oldChildren[i])
-				childElement := domChildren.Call("item", domIndex)
-				if childElement.Truthy() {
-					domElement.Call("removeChild", childElement)
-				}
-				// Don't increment domIndex after removal.
-			}
-		}
+	options := map[string]any{"capture": listener.Capture, "passive": listener.Passive}
+	target.Call("addEventListener", eventName, cb, options)
+	return func() {
+		target.Call("removeEventListener", eventName, cb, map[string]any{"capture": listener.Capture})
+		cb.Release()
 	}
 }
@@ -4,13 +4,14 @@ package vdom
 // This core file has NO build tags, making it available to both WASM and native test builds.
 type VNode struct {
 	Tag            string         // The HTML tag name
+	Namespace      string         // Element namespace URI (NamespaceSVG, NamespaceMathML); empty for HTML
 	Attributes     map[string]any // The attributes of the node
 	Children       []*VNode       // The child nodes
 	Content        string         // The content of the node
 	OnClick        func()         // Optional click event handler
 	Key            any            // Optional key for list reconciliation (used in {@for} loops)
 	ComponentKey   string         // Key for component-level reconciliation (used in router navigation)
-	eventCallbacks []any          // Stores js.Func objects for cleanup (interface{} to avoid build tag issues)
+	eventCallbacks []any          // Stores listener release functions returned by the DOM backend
 }
 
 // NewVNode creates a new VNode.
@@ -34,6 +35,24 @@ func NewVNode(tag string, attributes map[string]any, children []*VNode, content
 	}
 }
 
+// Namespace URIs of elements and attributes outside HTML.
+const (
+	NamespaceSVG    = "http://www.w3.org/2000/svg"
+	NamespaceMathML = "http://www.w3.org/1998/Math/MathML"
+	NamespaceXLink  = "http://www.w3.org/1999/xlink"
+	NamespaceXML    = "http://www.w3.org/XML/1998/namespace"
+	NamespaceXMLNS  = "http://www.w3.org/2000/xmlns/"
+)
+
+// NewVNodeNS creates a new VNode for an element in the given namespace, such as an SVG
+// <circle>. Children without a namespace inherit it when rendered, except under an SVG
+// <foreignObject>, whose children are HTML.
+func NewVNodeNS(namespace, tag string, attributes map[string]any, children []*VNode, content string) *VNode {
+	v := NewVNode(tag, attributes, children, content)
+	v.Namespace = namespace
+	return v
+}
+
 // SetContent updates the Content field of the VNode.
 func (v *VNode) SetContent(content string) {
 	v.Content = content
@@ -49,6 +68,16 @@ func Text(content string) *VNode {
 	}
 }
 
+// Fragment creates a "#fragment" VNode: a group of sibling nodes without a wrapper element.
+// Its children are rendered directly into the parent, so a component can render several
+// root nodes, such as table rows or list items.
+func Fragment(children ...*VNode) *VNode {
+	return &VNode{
+		Tag:      "#fragment",
+		Children: children,
+	}
+}
+
 // Paragraph creates a <p> VNode with the given text as its child and allows passing attributes.
 func Paragraph(text string, attrs map[string]any) *VNode {
 	return NewVNode("p", attrs, nil, text)
@@ -74,20 +103,20 @@ func Button(content string, attrs map[string]any, children ...*VNode) *VNode {
 	return NewVNode("button", attrs, children, content)
 }
 
-// AddEventCallback stores a js.Func (as interface{}) for later cleanup.
-// This is called from WASM-only code in render.go.
+// AddEventCallback stores a listener release function for later cleanup.
+// This is called by the renderer in patch.go.
 func (v *VNode) AddEventCallback(cb any) {
 	v.eventCallbacks = append(v.eventCallbacks, cb)
 }
 
 // GetEventCallbacks returns all stored event callbacks.
-// This is used by WASM-only code to access the callbacks for cleanup.
+// This is used by the renderer to release listeners before a node is patched or removed.
 func (v *VNode) GetEventCallbacks() []any {
 	return v.eventCallbacks
 }
 
 // ClearEventCallbacks clears the event callbacks slice without releasing them.
-// The actual release is done in WASM-only code in render.go.
+// The actual release is done by releaseCallbacks in patch.go.
 func (v *VNode) ClearEventCallbacks() {
 	v.eventCallbacks = nil
 }
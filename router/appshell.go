@@ -1,5 +1,3 @@
-//go:build js || wasm
-
 package router
 
 import (
@@ -42,6 +40,15 @@ func (a *AppShell) SetPage(chain []runtime.Component, key string) {
 		console.Log("[AppShell.SetPage] First component type:", fmt.Sprintf("%T", chain[0]))
 	}
 
+	a.setChain(chain, key)
+
+	console.Log("[AppShell.SetPage] Calling StateHasChanged")
+	a.StateHasChanged()
+}
+
+// setChain stores the chain without re-rendering. GenerateStaticSite uses it directly
+// because static rendering has no renderer to notify.
+func (a *AppShell) setChain(chain []runtime.Component, key string) {
 	// If the chain doesn't include persistentLayout at index 0, prepend it
 	// (this happens when pivot > 0 and layouts are preserved)
 	if len(chain) == 0 || chain[0] != a.persistentLayout {
@@ -54,9 +61,6 @@ func (a *AppShell) SetPage(chain []runtime.Component, key string) {
 		a.currentChain = chain
 	}
 	a.currentKey = key
-
-	console.Log("[AppShell.SetPage] Calling StateHasChanged")
-	a.StateHasChanged()
 }
 
 // Render composes the persistent layout with the current component chain.
@@ -0,0 +1,64 @@
+package router
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Path helpers shared by the browser Engine and GenerateStaticSite.
+// This file has NO build tags so static site generation can run natively.
+
+func normalizeBasePath(path string) string {
+	if path == "" || path == "/" {
+		return ""
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	path = strings.TrimSuffix(path, "/")
+	if path == "" || path == "/" {
+		return ""
+	}
+	return path
+}
+
+func normalizeRoutePath(path string) string {
+	if path == "" {
+		return "/"
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	if len(path) > 1 {
+		path = strings.TrimSuffix(path, "/")
+	}
+	if path == "" {
+		return "/"
+	}
+	return path
+}
+
+// isParameterized reports whether a route pattern contains {param} segments.
+func isParameterized(pattern string) bool {
+	return strings.Contains(pattern, "{")
+}
+
+// fillRoutePattern substitutes {param} segments of pattern with values from params.
+func fillRoutePattern(pattern string, params map[string]string) (string, error) {
+	parts := strings.Split(strings.Trim(pattern, "/"), "/")
+	for i, part := range parts {
+		if !strings.HasPrefix(part, "{") || !strings.HasSuffix(part, "}") {
+			continue
+		}
+		name := strings.Trim(part, "{}")
+		value, ok := params[name]
+		if !ok || value == "" {
+			return "", fmt.Errorf("route %s: missing value for parameter %q", pattern, name)
+		}
+		if strings.Contains(value, "/") {
+			return "", fmt.Errorf("route %s: value %q for parameter %q must not contain '/'", pattern, value, name)
+		}
+		parts[i] = value
+	}
+	return normalizeRoutePath(strings.Join(parts, "/")), nil
+}
@@ -396,36 +396,6 @@ func (e *Engine) Cleanup() {
 	}
 }
 
-func normalizeBasePath(path string) string {
-	if path == "" || path == "/" {
-		return ""
-	}
-	if !strings.HasPrefix(path, "/") {
-		path = "/" + path
-	}
-	path = strings.TrimSuffix(path, "/")
-	if path == "" || path == "/" {
-		return ""
-	}
-	return path
-}
-
-func normalizeRoutePath(path string) string {
-	if path == "" {
-		return "/"
-	}
-	if !strings.HasPrefix(path, "/") {
-		path = "/" + path
-	}
-	if len(path) > 1 {
-		path = strings.TrimSuffix(path, "/")
-	}
-	if path == "" {
-		return "/"
-	}
-	return path
-}
-
 func (e *Engine) toRoutePath(path string) string {
 	path = normalizeRoutePath(path)
 	if e.basePath == "" {
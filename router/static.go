@@ -0,0 +1,324 @@
+package router
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ForgeLogic/nojs/runtime"
+	"github.com/ForgeLogic/nojs/vdom"
+)
+
+// StaticSiteOptions configures GenerateStaticSite.
+type StaticSiteOptions struct {
+	// OutDir receives one index.html per route plus 404.html.
+	OutDir string
+
+	// Shell is the page template, usually the app's wwwroot/index.html.
+	// It must contain an empty mount element, e.g. <div id="app"></div>.
+	Shell string
+
+	// MountID is the id of the mount element. Defaults to "app".
+	MountID string
+
+	// BasePath is the URL prefix the site is served under, e.g. "/nojs/demo" for a
+	// GitHub Pages project site. Use the same value as Engine.SetBasePath.
+	BasePath string
+
+	// FallbackRoot is the directory the host serves its site-wide 404.html from, when OutDir
+	// is below it. GitHub Pages, for example, only reads the 404.html at the root of the site,
+	// so the 404.html in OutDir is never served. GenerateStaticSite adds a script to
+	// <FallbackRoot>/404.html, creating the file if needed, that shows the app's 404.html for
+	// URLs under BasePath without changing the URL. Other URLs keep the original page.
+	FallbackRoot string
+
+	// Params lists the parameter values to pre-render for each parameterized route,
+	// keyed by route pattern, e.g. {"/blog/{year}": {{"year": "2025"}, {"year": "2026"}}}.
+	Params map[string][]map[string]string
+}
+
+// GenerateStaticSite pre-renders every non-parameterized route, plus each entry of
+// opts.Params, to <OutDir>/<route>/index.html. Each page is rendered through an AppShell
+// whose persistent layout is the first component of the route's chain, exactly like the
+// client, so the output can be hydrated with RendererImpl.EnableHydration.
+//
+// It also writes 404.html: the shell with an empty mount element, which static hosts such
+// as GitHub Pages serve for unknown URLs so the client router can resolve the path.
+// Every file gets a <base href="{BasePath}/"> so relative asset URLs in the shell work from
+// nested directories. With opts.FallbackRoot, the site-wide 404.html there also falls back
+// to the app for URLs under BasePath.
+//
+// It returns the paths of the written files, relative to OutDir.
+// This file has NO build tags so it can run in any build.
+func GenerateStaticSite(routes []Route, opts StaticSiteOptions) ([]string, error) {
+	if opts.MountID == "" {
+		opts.MountID = "app"
+	}
+	basePath := normalizeBasePath(opts.BasePath)
+
+	shell, err := withBaseHref(opts.Shell, basePath+"/")
+	if err != nil {
+		return nil, err
+	}
+	mountEnd, err := findMountContent(shell, opts.MountID)
+	if err != nil {
+		return nil, err
+	}
+
+	pages, err := staticPaths(routes, opts.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	var written []string
+	for _, page := range pages {
+		html, err := renderStaticPage(page.route, page.path, page.params, shell, mountEnd)
+		if err != nil {
+			return written, err
+		}
+		file := filepath.Join(strings.Split(strings.Trim(page.path, "/"), "/")...)
+		file = filepath.Join(file, "index.html")
+		if err := writeStaticFile(opts.OutDir, file, html); err != nil {
+			return written, err
+		}
+		written = append(written, file)
+	}
+
+	if err := writeStaticFile(opts.OutDir, "404.html", shell); err != nil {
+		return written, err
+	}
+	written = append(written, "404.html")
+
+	if opts.FallbackRoot != "" {
+		file, err := mergeFallback(opts.OutDir, opts.FallbackRoot, basePath)
+		if err != nil {
+			return written, err
+		}
+		if file != "" {
+			written = append(written, file)
+		}
+	}
+
+	return written, nil
+}
+
+// fallbackPage is the site-wide 404.html written when the host has none yet.
+const fallbackPage = `<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="utf-8">
+    <title>Page not found</title>
+</head>
+<body>
+    <p>Page not found.</p>
+</body>
+</html>
+`
+
+// fallbackScript shows the app's 404.html in place of the site-wide one for URLs under
+// basePath. The URL is kept, so the client router resolves the requested path.
+const fallbackScript = `
+    <script data-nojs-fallback=%[1]q>
+        // URLs under %[1]s/ belong to the nojs app: show its shell, keeping the URL
+        (function () {
+            var base = %[1]q, path = location.pathname;
+            if (path !== base && path.indexOf(base + "/") !== 0) {
+                return;
+            }
+            document.documentElement.style.visibility = "hidden";
+            fetch(base + "/404.html").then(function (response) {
+                if (!response.ok) {
+                    throw new Error(response.statusText);
+                }
+                return response.text();
+            }).then(function (html) {
+                document.open();
+                document.write(html);
+                document.close();
+            }).catch(function () {
+                document.documentElement.style.visibility = "";
+            });
+        })();
+    </script>`
+
+// mergeFallback adds fallbackScript to <root>/404.html, creating the file if it does not
+// exist, and returns its path relative to outDir. It returns "" when root is outDir itself,
+// whose 404.html is already the app's.
+func mergeFallback(outDir, root, basePath string) (string, error) {
+	absOut, err := filepath.Abs(outDir)
+	if err != nil {
+		return "", err
+	}
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return "", err
+	}
+	if absRoot == absOut {
+		return "", nil
+	}
+	if basePath == "" {
+		return "", fmt.Errorf("a fallback root needs a base path for the URLs that belong to the app")
+	}
+
+	path := filepath.Join(absRoot, "404.html")
+	page := fallbackPage
+	if data, err := os.ReadFile(path); err == nil {
+		page = string(data)
+	} else if !os.IsNotExist(err) {
+		return "", err
+	}
+
+	marker := fmt.Sprintf("data-nojs-fallback=%q", basePath)
+	if !strings.Contains(page, marker) {
+		loc := headOpenRegex.FindStringIndex(page)
+		if loc == nil {
+			return "", fmt.Errorf("%s has no <head> element to receive the app fallback", path)
+		}
+		page = page[:loc[1]] + fmt.Sprintf(fallbackScript, basePath) + page[loc[1]:]
+	}
+	if err := writeStaticFile(absRoot, "404.html", page); err != nil {
+		return "", err
+	}
+	return filepath.Rel(absOut, path)
+}
+
+// staticPage is one URL to pre-render.
+type staticPage struct {
+	route  *Route
+	path   string
+	params map[string]string
+}
+
+// staticPaths expands the route table into concrete URLs, sorted for deterministic output.
+func staticPaths(routes []Route, params map[string][]map[string]string) ([]staticPage, error) {
+	byPattern := make(map[string]*Route, len(routes))
+	for i := range routes {
+		byPattern[routes[i].Path] = &routes[i]
+	}
+	for pattern := range params {
+		route, ok := byPattern[pattern]
+		if !ok {
+			return nil, fmt.Errorf("parameter values given for unknown route %s", pattern)
+		}
+		if !isParameterized(route.Path) {
+			return nil, fmt.Errorf("parameter values given for route %s, which has no parameters", pattern)
+		}
+	}
+
+	var pages []staticPage
+	seen := make(map[string]string)
+	for i := range routes {
+		route := &routes[i]
+		if len(route.Chain) == 0 {
+			continue
+		}
+
+		var paths []staticPage
+		if !isParameterized(route.Path) {
+			paths = append(paths, staticPage{route: route, path: normalizeRoutePath(route.Path), params: map[string]string{}})
+		}
+		for _, values := range params[route.Path] {
+			path, err := fillRoutePattern(route.Path, values)
+			if err != nil {
+				return nil, err
+			}
+			paths = append(paths, staticPage{route: route, path: path, params: values})
+		}
+
+		for _, p := range paths {
+			if other, dup := seen[p.path]; dup {
+				return nil, fmt.Errorf("routes %s and %s both produce %s", other, route.Path, p.path)
+			}
+			seen[p.path] = route.Path
+			pages = append(pages, p)
+		}
+	}
+
+	sort.Slice(pages, func(i, j int) bool { return pages[i].path < pages[j].path })
+	return pages, nil
+}
+
+// renderStaticPage renders one route through an AppShell and splices it into the shell.
+func renderStaticPage(route *Route, path string, params map[string]string, shell string, mountEnd int) (string, error) {
+	chain := make([]runtime.Component, len(route.Chain))
+	for i, meta := range route.Chain {
+		chain[i] = meta.Factory(params)
+	}
+
+	appShell := NewAppShell(chain[0])
+	appShell.setChain(chain, path)
+
+	state := runtime.NewTransferState()
+	root, err := runtime.RenderStaticWithState(appShell, state)
+	if err != nil {
+		return "", fmt.Errorf("rendering %s: %w", path, err)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(shell[:mountEnd])
+	if err := vdom.RenderHTML(&sb, root); err != nil {
+		return "", err
+	}
+	rest := shell[mountEnd:]
+
+	if state.Len() > 0 {
+		// The state script goes right before </body>, outside the mount element.
+		bodyEnd := strings.LastIndex(strings.ToLower(rest), "</body>")
+		if bodyEnd < 0 {
+			bodyEnd = len(rest)
+		}
+		sb.WriteString(rest[:bodyEnd])
+		if err := state.WriteScript(&sb); err != nil {
+			return "", fmt.Errorf("rendering %s: %w", path, err)
+		}
+		rest = rest[bodyEnd:]
+	}
+
+	sb.WriteString(rest)
+	return sb.String(), nil
+}
+
+var (
+	baseTagRegex  = regexp.MustCompile(`(?i)<base[\s>]`)
+	headOpenRegex = regexp.MustCompile(`(?i)<head(\s[^>]*)?>`)
+)
+
+// withBaseHref inserts <base href="..."> as the first element of <head> unless the shell already has one.
+func withBaseHref(shell, href string) (string, error) {
+	if baseTagRegex.MatchString(shell) {
+		return shell, nil
+	}
+	loc := headOpenRegex.FindStringIndex(shell)
+	if loc == nil {
+		return "", fmt.Errorf("shell has no <head> element to receive <base href=%q>", href)
+	}
+	return shell[:loc[1]] + fmt.Sprintf("\n    <base href=%q>", href) + shell[loc[1]:], nil
+}
+
+// findMountContent returns the offset just after the opening tag of the mount element.
+// The element must be empty so the rendered page can be inserted there.
+func findMountContent(shell, mountID string) (int, error) {
+	re := regexp.MustCompile(`<([a-zA-Z][a-zA-Z0-9-]*)\s[^>]*\bid\s*=\s*["']` + regexp.QuoteMeta(mountID) + `["'][^>]*>`)
+	loc := re.FindStringSubmatchIndex(shell)
+	if loc == nil {
+		return 0, fmt.Errorf("shell has no element with id=%q", mountID)
+	}
+	tag := shell[loc[2]:loc[3]]
+	rest := strings.TrimSpace(shell[loc[1]:])
+	if !strings.HasPrefix(strings.ToLower(rest), "</"+strings.ToLower(tag)) {
+		return 0, fmt.Errorf("mount element #%s in the shell must be empty", mountID)
+	}
+	return loc[1], nil
+}
+
+// writeStaticFile writes content to outDir/name, creating directories as needed.
+func writeStaticFile(outDir, name, content string) error {
+	path := filepath.Join(outDir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(content), 0o644)
+}
@@ -0,0 +1,187 @@
+package router
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ForgeLogic/nojs/runtime"
+	"github.com/ForgeLogic/nojs/vdom"
+)
+
+type staticTestLayout struct {
+	runtime.ComponentBase
+	BodyContent []*vdom.VNode
+}
+
+func (l *staticTestLayout) SetBodyContent(body []*vdom.VNode) { l.BodyContent = body }
+
+func (l *staticTestLayout) Render(r runtime.Renderer) *vdom.VNode {
+	return vdom.NewVNode("main", nil, l.BodyContent, "")
+}
+
+type staticTestPage struct {
+	runtime.ComponentBase
+	Title string
+}
+
+func (p *staticTestPage) OnMount() {
+	runtime.TransferStateOf(p.GetRenderer()).Set("title", p.Title)
+}
+
+func (p *staticTestPage) Render(r runtime.Renderer) *vdom.VNode {
+	return vdom.NewVNode("h1", nil, nil, p.Title)
+}
+
+func staticRoutes() []Route {
+	layout := &staticTestLayout{}
+	ml := func(map[string]string) runtime.Component { return layout }
+	page := func(title string) ComponentFactory {
+		return func(p map[string]string) runtime.Component { return &staticTestPage{Title: title + p["id"]} }
+	}
+	return []Route{
+		{Path: "/", Chain: []ComponentMetadata{{Factory: ml, TypeID: 1}, {Factory: page("Home"), TypeID: 2}}},
+		{Path: "/about", Chain: []ComponentMetadata{{Factory: ml, TypeID: 1}, {Factory: page("About"), TypeID: 3}}},
+		{Path: "/items/{id}", Chain: []ComponentMetadata{{Factory: ml, TypeID: 1}, {Factory: page("Item "), TypeID: 4}}},
+	}
+}
+
+const staticShell = `<!DOCTYPE html>
+<html>
+<head>
+    <script src="wasm_exec.js"></script>
+</head>
+<body>
+    <div id="app"></div>
+</body>
+</html>`
+
+func TestGenerateStaticSite_WritesRoutesAndFallback(t *testing.T) {
+	// Arrange
+	outDir := t.TempDir()
+
+	// Act
+	written, err := GenerateStaticSite(staticRoutes(), StaticSiteOptions{
+		OutDir:   outDir,
+		Shell:    staticShell,
+		BasePath: "/repo/demo/",
+		Params:   map[string][]map[string]string{"/items/{id}": {{"id": "7"}}},
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("GenerateStaticSite() error = %v", err)
+	}
+	want := []string{"index.html", filepath.Join("about", "index.html"), filepath.Join("items", "7", "index.html"), "404.html"}
+	if !reflect.DeepEqual(written, want) {
+		t.Errorf("written = %v, want %v", written, want)
+	}
+
+	item, _ := os.ReadFile(filepath.Join(outDir, "items", "7", "index.html"))
+	if !strings.Contains(string(item), `<div id="app"><main><h1>Item 7</h1></main></div>`) {
+		t.Errorf("item page does not contain the rendered route:\n%s", item)
+	}
+	if !strings.Contains(string(item), `<base href="/repo/demo/">`) {
+		t.Errorf("item page is missing the base href:\n%s", item)
+	}
+	if !strings.Contains(string(item), `<script type="application/json" id="nojs-state">{"title":"Item 7"}</script></body>`) {
+		t.Errorf("item page is missing the transfer state before </body>:\n%s", item)
+	}
+
+	notFound, _ := os.ReadFile(filepath.Join(outDir, "404.html"))
+	if !strings.Contains(string(notFound), `<div id="app"></div>`) || !strings.Contains(string(notFound), `<base href="/repo/demo/">`) {
+		t.Errorf("404.html should be the shell with an empty mount and base href:\n%s", notFound)
+	}
+}
+
+func TestGenerateStaticSite_MergesFallbackIntoSiteRoot(t *testing.T) {
+	// Arrange
+	// The app is published under /repo/demo/ of a site whose root 404.html belongs to the docs
+	root := t.TempDir()
+	docs404 := "<!DOCTYPE html>\n<html>\n<head>\n<title>Docs</title>\n</head>\n<body>Docs 404</body>\n</html>\n"
+	if err := os.WriteFile(filepath.Join(root, "404.html"), []byte(docs404), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	opts := StaticSiteOptions{
+		OutDir:       filepath.Join(root, "demo"),
+		FallbackRoot: root,
+		Shell:        staticShell,
+		BasePath:     "/repo/demo",
+	}
+
+	// Act
+	written, err := GenerateStaticSite(staticRoutes(), opts)
+	if err == nil {
+		// A second run must not add the script twice
+		_, err = GenerateStaticSite(staticRoutes(), opts)
+	}
+
+	// Assert
+	if err != nil {
+		t.Fatalf("GenerateStaticSite() error = %v", err)
+	}
+	if got := written[len(written)-1]; got != filepath.Join("..", "404.html") {
+		t.Errorf("last written file = %s, want the site-root 404.html", got)
+	}
+	appShell, _ := os.ReadFile(filepath.Join(root, "demo", "404.html"))
+	if !strings.Contains(string(appShell), `<div id="app"></div>`) {
+		t.Errorf("demo/404.html should be the app shell:\n%s", appShell)
+	}
+	siteRoot, _ := os.ReadFile(filepath.Join(root, "404.html"))
+	page := string(siteRoot)
+	if !strings.Contains(page, "Docs 404") {
+		t.Errorf("the site-root 404.html lost its own content:\n%s", page)
+	}
+	if n := strings.Count(page, `<script data-nojs-fallback="/repo/demo">`); n != 1 {
+		t.Errorf("the site-root 404.html has %d fallback scripts, want 1:\n%s", n, page)
+	}
+	if !strings.Contains(page, `fetch(base + "/404.html")`) || strings.Index(page, "<script") > strings.Index(page, "<title>") {
+		t.Errorf("the fallback script should load the app shell first thing in <head>:\n%s", page)
+	}
+}
+
+func TestGenerateStaticSite_CreatesMissingFallbackPage(t *testing.T) {
+	// Arrange
+	root := t.TempDir()
+
+	// Act
+	_, err := GenerateStaticSite(staticRoutes(), StaticSiteOptions{
+		OutDir:       filepath.Join(root, "demo"),
+		FallbackRoot: root,
+		Shell:        staticShell,
+		BasePath:     "/demo",
+	})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("GenerateStaticSite() error = %v", err)
+	}
+	page, _ := os.ReadFile(filepath.Join(root, "404.html"))
+	if !strings.Contains(string(page), `<script data-nojs-fallback="/demo">`) || !strings.Contains(string(page), "Page not found.") {
+		t.Errorf("the created 404.html should hold the fallback script and a not-found message:\n%s", page)
+	}
+}
+
+func TestGenerateStaticSite_RejectsBadInput(t *testing.T) {
+	tests := []struct {
+		name   string
+		shell  string
+		params map[string][]map[string]string
+	}{
+		{"unknown route", staticShell, map[string][]map[string]string{"/missing/{id}": {{"id": "1"}}}},
+		{"missing parameter value", staticShell, map[string][]map[string]string{"/items/{id}": {{"slug": "1"}}}},
+		{"no mount element", "<html><head></head><body></body></html>", nil},
+		{"mount not empty", strings.Replace(staticShell, `<div id="app"></div>`, `<div id="app">Loading</div>`, 1), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := GenerateStaticSite(staticRoutes(), StaticSiteOptions{OutDir: t.TempDir(), Shell: tt.shell, Params: tt.params})
+			if err == nil {
+				t.Error("GenerateStaticSite() error = nil, want error")
+			}
+		})
+	}
+}